@@ -1,19 +1,184 @@
 package main
 
 import (
+	"errors"
 	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"time"
 
 	"github.com/Anacardo89/order_svc_hex/order_api/config"
+	"github.com/Anacardo89/order_svc_hex/order_api/internal/adapters/in/messaging/kafka/commandresult"
+	"github.com/Anacardo89/order_svc_hex/order_api/internal/adapters/in/messaging/kafka/orderevents"
+	"github.com/Anacardo89/order_svc_hex/order_api/internal/adapters/out/cache/cachedreader"
 	"github.com/Anacardo89/order_svc_hex/order_api/internal/adapters/out/messaging/kafka/orderwriter"
+	"github.com/Anacardo89/order_svc_hex/order_api/internal/adapters/out/readmodel/readrouter"
+	"github.com/Anacardo89/order_svc_hex/order_api/internal/adapters/out/store/memory/commandstore"
+	memidempotency "github.com/Anacardo89/order_svc_hex/order_api/internal/adapters/out/store/memory/idempotencystore"
+	"github.com/Anacardo89/order_svc_hex/order_api/internal/adapters/out/store/memory/ordercache"
+	"github.com/Anacardo89/order_svc_hex/order_api/internal/adapters/out/store/memory/orderprojection"
+	pgidempotency "github.com/Anacardo89/order_svc_hex/order_api/internal/adapters/out/store/pgx/idempotencystore"
+	"github.com/Anacardo89/order_svc_hex/order_api/internal/core"
+	"github.com/Anacardo89/order_svc_hex/order_api/internal/ports"
+	"github.com/Anacardo89/order_svc_hex/order_api/pkg/db"
 	"github.com/Anacardo89/order_svc_hex/order_api/pkg/events"
 	"go.opentelemetry.io/otel/metric"
 )
 
-func initMessaging(cfg config.Kafka, meter metric.Meter, m *orderwriter.ProducerMetrics) (*orderwriter.OrderWriterClient, error) {
+// Shares the database with order_svc, so it keeps its own migrations table
+const migrationsTable = "order_api_schema_migrations"
+
+func initMessaging(cfg config.Kafka, serializer *events.Serializer, meter metric.Meter, m *orderwriter.ProducerMetrics) (*orderwriter.OrderWriterClient, error) {
 	conn := events.NewKafkaConnection(cfg.Brokers)
-	orderWriterClient, err := orderwriter.NewOrderWriterClient(conn, cfg.Topics, meter, m)
+	orderWriterClient, err := orderwriter.NewOrderWriterClient(conn, cfg.Topics, cfg.ContentTypes, serializer, meter, m)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create Order Writer: %s", err)
 	}
 	return orderWriterClient, nil
 }
+
+// Nil when commands go out without the schema registry framing
+func initSchemaRegistry(cfg config.Config) (*events.Serializer, error) {
+	switch cfg.SchemaRegistry.Kind {
+	case "", "none":
+		return nil, nil
+	case "http":
+		return events.NewSerializer(events.NewHTTPRegistry(cfg.SchemaRegistry.URL, cfg.SchemaRegistry.Timeout)), nil
+	case "local":
+		path := filepath.Join(cfg.AppHome, cfg.SchemaRegistry.Path)
+		return events.NewSerializer(events.NewLocalRegistry(path)), nil
+	default:
+		return nil, fmt.Errorf("unknown schema registry: %s", cfg.SchemaRegistry.Kind)
+	}
+}
+
+func initIdempotency(cfg config.Config) (ports.IdempotencyStore, func(), error) {
+	switch cfg.Idempotency.Store {
+	case "", "memory":
+		store := memidempotency.NewStore(cfg.Idempotency.TTL)
+		return store, store.Close, nil
+	case "postgres":
+		dbConn, err := db.Connect(cfg.DB.DSN, cfg.DB.MaxConns, cfg.DB.MinConns, cfg.DB.MaxConnLifetime, cfg.DB.MaxConnIdleTime)
+		if err != nil {
+			return nil, nil, err
+		}
+		migrateDSN, err := withMigrationsTable(cfg.DB.DSN)
+		if err != nil {
+			dbConn.Close()
+			return nil, nil, err
+		}
+		migrationPath := filepath.Join(cfg.AppHome, "db", "migrations")
+		if err := db.Migrate(migrateDSN, migrationPath, db.MigrateUp); err != nil {
+			dbConn.Close()
+			return nil, nil, err
+		}
+		store := pgidempotency.NewStore(dbConn, cfg.Idempotency.TTL)
+		return store, store.Close, nil
+	default:
+		return nil, nil, fmt.Errorf("unknown idempotency store: %s", cfg.Idempotency.Store)
+	}
+}
+
+func withMigrationsTable(dsn string) (string, error) {
+	u, err := url.Parse(dsn)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse DSN: %w", err)
+	}
+	q := u.Query()
+	q.Set("x-migrations-table", migrationsTable)
+	u.RawQuery = q.Encode()
+	return u.String(), nil
+}
+
+// A non-nil cache has each settled command drop the order it touched
+func initCommands(cfg config.Config, cache ports.OrderCache) (*commandstore.CommandStore, *commandresult.CommandResultClient, func(), error) {
+	resultTopic, ok := cfg.Kafka.Topics["OrderCommandResult"]
+	if !ok {
+		return nil, nil, nil, errors.New("no topic for OrderCommandResult defined")
+	}
+	// Every instance reads all results so any of them can answer for a command
+	host, err := os.Hostname()
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to get hostname: %s", err)
+	}
+	groupID := fmt.Sprintf("%s-results-%s", cfg.Kafka.GroupID, host)
+	store := commandstore.NewStore(cfg.Commands.TTL)
+	var tracker ports.CommandTracker = store
+	if cache != nil {
+		tracker = cachedreader.NewCommandTracker(store, cache)
+	}
+	conn := events.NewKafkaConnection(cfg.Kafka.Brokers)
+	resultClient, err := commandresult.NewCommandResultClient(conn, groupID, resultTopic, tracker)
+	if err != nil {
+		store.Close()
+		return nil, nil, nil, fmt.Errorf("failed to create Command Result Client: %s", err)
+	}
+	closeCommands := func() {
+		resultClient.Close()
+		store.Close()
+	}
+	return store, resultClient, closeCommands, nil
+}
+
+// Wraps the reader so point lookups are cached, the cache is nil when disabled
+func initCache(cfg config.Cache, meter metric.Meter, reader ports.OrderReader) (ports.OrderReader, ports.OrderCache, error) {
+	if !cfg.Enabled {
+		return reader, nil, nil
+	}
+	var cache ports.OrderCache
+	switch cfg.Store {
+	case "", "memory":
+		cache = ordercache.NewCache(cfg.Capacity)
+	default:
+		return nil, nil, fmt.Errorf("unknown cache store: %s", cfg.Store)
+	}
+	ttls := cachedreader.TTLs{
+		Default:  cfg.DefaultTTL,
+		ByStatus: make(map[core.Status]time.Duration, len(cfg.StatusTTL)),
+	}
+	for s, ttl := range cfg.StatusTTL {
+		status, err := core.MapStrToStatus(s)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid cache status_ttl %q: %w", s, err)
+		}
+		ttls.ByStatus[*status] = ttl
+	}
+	metrics, err := cachedreader.NewCacheMetrics(meter)
+	if err != nil {
+		return nil, nil, err
+	}
+	return cachedreader.NewOrderReader(reader, cache, ttls, metrics), cache, nil
+}
+
+// Puts the local read model next to order_svc, the returned client keeps it up to date
+func initReadModel(cfg config.Config, meter metric.Meter, remote ports.OrderReader) (ports.OrderReader, *orderevents.OrderEventsClient, error) {
+	if !cfg.ReadModel.Enabled {
+		return remote, nil, nil
+	}
+	mode, err := readrouter.MapStrToMode(cfg.ReadModel.Mode)
+	if err != nil {
+		return nil, nil, err
+	}
+	eventsTopic, ok := cfg.Kafka.Topics["OrderEvents"]
+	if !ok {
+		return nil, nil, errors.New("no topic for OrderEvents defined")
+	}
+	// The projection lives in memory, so every instance reads the whole topic
+	host, err := os.Hostname()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get hostname: %s", err)
+	}
+	groupID := fmt.Sprintf("%s-projection-%s", cfg.Kafka.GroupID, host)
+	metrics, err := orderevents.NewProjectionMetrics(meter)
+	if err != nil {
+		return nil, nil, err
+	}
+	projection := orderprojection.NewProjection()
+	conn := events.NewKafkaConnection(cfg.Kafka.Brokers)
+	eventsClient, err := orderevents.NewOrderEventsClient(conn, groupID, eventsTopic, projection, metrics)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create Order Events Client: %s", err)
+	}
+	return readrouter.NewOrderReader(remote, projection, mode), eventsClient, nil
+}
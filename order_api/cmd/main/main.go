@@ -12,6 +12,7 @@ import (
 	"github.com/Anacardo89/order_svc_hex/order_api/internal/adapters/in/http/rest/orderorchestrator"
 	"github.com/Anacardo89/order_svc_hex/order_api/internal/adapters/infra/log/loki/logger"
 	"github.com/Anacardo89/order_svc_hex/order_api/internal/adapters/out/messaging/kafka/orderwriter"
+	"github.com/Anacardo89/order_svc_hex/order_api/internal/adapters/out/rpc/grpc/deadletteradmin"
 	"github.com/Anacardo89/order_svc_hex/order_api/internal/adapters/out/rpc/grpc/orderreader"
 	"github.com/Anacardo89/order_svc_hex/order_api/internal/ports"
 	"github.com/Anacardo89/order_svc_hex/order_api/pkg/observability"
@@ -58,6 +59,7 @@ func main() {
 	// }()
 	restMeter := otel.GetMeterProvider().Meter("order_api.rest")
 	producerMeter := otel.GetMeterProvider().Meter("order_api.producer")
+	projectionMeter := otel.GetMeterProvider().Meter("order_api.projection")
 	restMetrics, err := orderorchestrator.NewReqMetrics(restMeter)
 	if err != nil {
 		logger.BaseLogger.Error(ctx, "failed to init rest metrics", ports.Field{Key: "error", Value: err})
@@ -68,7 +70,12 @@ func main() {
 		logger.BaseLogger.Error(ctx, "failed to init producer metrics", ports.Field{Key: "error", Value: err})
 		os.Exit(1)
 	}
-	orderWriter, err := initMessaging(cfg.Kafka, producerMeter, producerMetrics)
+	serializer, err := initSchemaRegistry(*cfg)
+	if err != nil {
+		logger.BaseLogger.Error(ctx, "failed to init schema registry", ports.Field{Key: "error", Value: err})
+		os.Exit(1)
+	}
+	orderWriter, err := initMessaging(cfg.Kafka, serializer, producerMeter, producerMetrics)
 	if err != nil {
 		logger.BaseLogger.Error(ctx, "failed to init orderwriter", ports.Field{Key: "error", Value: err})
 		os.Exit(1)
@@ -82,11 +89,50 @@ func main() {
 	}
 	defer orderReader.Close()
 	var or ports.OrderReader = orderReader
-	orderHandler := orderorchestrator.NewOrderHandler(or, ow)
+	or, projectionConsumer, err := initReadModel(*cfg, projectionMeter, or)
+	if err != nil {
+		logger.BaseLogger.Error(ctx, "failed to init read model", ports.Field{Key: "error", Value: err})
+		os.Exit(1)
+	}
+	if projectionConsumer != nil {
+		defer projectionConsumer.Close()
+	}
+	or, orderCache, err := initCache(cfg.Cache, restMeter, or)
+	if err != nil {
+		logger.BaseLogger.Error(ctx, "failed to init order cache", ports.Field{Key: "error", Value: err})
+		os.Exit(1)
+	}
+	idempotencyStore, closeIdempotency, err := initIdempotency(*cfg)
+	if err != nil {
+		logger.BaseLogger.Error(ctx, "failed to init idempotency store", ports.Field{Key: "error", Value: err})
+		os.Exit(1)
+	}
+	defer closeIdempotency()
+	commandStore, resultConsumer, closeCommands, err := initCommands(*cfg, orderCache)
+	if err != nil {
+		logger.BaseLogger.Error(ctx, "failed to init command tracking", ports.Field{Key: "error", Value: err})
+		os.Exit(1)
+	}
+	defer closeCommands()
+	dlqAdmin, err := deadletteradmin.NewDeadLetterAdminClient(cfg.GRPC)
+	if err != nil {
+		logger.BaseLogger.Error(ctx, "failed to init dead letter admin", ports.Field{Key: "error", Value: err})
+		os.Exit(1)
+	}
+	defer dlqAdmin.Close()
+	orderHandler := orderorchestrator.NewOrderHandler(or, ow, commandStore, idempotencyStore, cfg.Commands.MaxWait, cfg.Events.Heartbeat, cfg.Batch.MaxSize)
+	dlqHandler := orderorchestrator.NewDeadLetterHandler(dlqAdmin)
 	orderServer := orderorchestrator.NewServer(&cfg.Server, orderHandler, restMetrics)
+	var adminServer *orderorchestrator.Server
+	if cfg.Admin.Token != "" {
+		adminServer = orderorchestrator.NewAdminServer(&cfg.Server, &cfg.Admin, dlqHandler, restMetrics)
+	} else {
+		logger.BaseLogger.Warn(ctx, "ADMIN_TOKEN not set, admin endpoints are off")
+	}
 
 	stopChan := make(chan os.Signal, 1)
 	errChan := make(chan error, 1)
+	errEventChan := make(chan error, 1)
 	signal.Notify(stopChan, syscall.SIGINT, syscall.SIGTERM)
 
 	// Execution
@@ -94,6 +140,22 @@ func main() {
 		logger.BaseLogger.Info(ctx, "Starting server on", ports.Field{Key: "port", Value: cfg.Server.Port})
 		errChan <- orderServer.Start()
 	}()
+	if adminServer != nil {
+		go func() {
+			logger.BaseLogger.Info(ctx, "Starting admin server on", ports.Field{Key: "port", Value: cfg.Admin.Port})
+			errChan <- adminServer.Start()
+		}()
+	}
+	go func() {
+		logger.BaseLogger.Info(ctx, "command result consumer starting")
+		errEventChan <- resultConsumer.Consume(ctx)
+	}()
+	if projectionConsumer != nil {
+		go func() {
+			logger.BaseLogger.Info(ctx, "order events consumer starting")
+			errEventChan <- projectionConsumer.Consume(ctx)
+		}()
+	}
 
 	// Metrics
 	go func() {
@@ -106,8 +168,13 @@ func main() {
 	case sig := <-stopChan:
 		logger.BaseLogger.Info(ctx, "Shutting down server", ports.Field{Key: "signal", Value: sig})
 		orderServer.Shutdown()
+		if adminServer != nil {
+			adminServer.Shutdown()
+		}
 		logger.BaseLogger.Info(ctx, "Server stopped gracefully")
 	case err := <-errChan:
 		logger.BaseLogger.Error(ctx, "server error", ports.Field{Key: "error", Value: err})
+	case err := <-errEventChan:
+		logger.BaseLogger.Error(ctx, "consumer error", ports.Field{Key: "error", Value: err})
 	}
 }
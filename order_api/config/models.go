@@ -4,23 +4,41 @@ import "time"
 
 func New() *Config {
 	return &Config{
-		Server: Server{},
-		GRPC:   GRPC{},
-		Kafka:  Kafka{},
-		Log:    Log{},
-		Trace:  Trace{},
-		Metric: Metric{},
+		Server:         Server{},
+		Admin:          Admin{},
+		GRPC:           GRPC{},
+		DB:             DB{},
+		Kafka:          Kafka{},
+		Log:            Log{},
+		Trace:          Trace{},
+		Metric:         Metric{},
+		Idempotency:    Idempotency{},
+		Commands:       Commands{},
+		Events:         Events{},
+		Batch:          Batch{},
+		Cache:          Cache{},
+		ReadModel:      ReadModel{},
+		SchemaRegistry: SchemaRegistry{},
 	}
 }
 
 type Config struct {
-	AppHome string `env:"APP_HOME" envDefault:""`
-	Server  Server `yaml:"server"`
-	GRPC    GRPC
-	Kafka   Kafka `yaml:"kafka"`
-	Log     Log
-	Trace   Trace
-	Metric  Metric `yaml:"metric"`
+	AppHome        string `env:"APP_HOME" envDefault:""`
+	Server         Server `yaml:"server"`
+	Admin          Admin
+	GRPC           GRPC
+	DB             DB    `yaml:"db"`
+	Kafka          Kafka `yaml:"kafka"`
+	Log            Log
+	Trace          Trace
+	Metric         Metric         `yaml:"metric"`
+	Idempotency    Idempotency    `yaml:"idempotency"`
+	Commands       Commands       `yaml:"commands"`
+	Events         Events         `yaml:"events"`
+	Batch          Batch          `yaml:"batch"`
+	Cache          Cache          `yaml:"cache"`
+	ReadModel      ReadModel      `yaml:"read_model"`
+	SchemaRegistry SchemaRegistry `yaml:"schema_registry"`
 }
 
 type Server struct {
@@ -33,15 +51,31 @@ type Server struct {
 	ShutdownTimeout   time.Duration `yaml:"shutdown_timeout"`
 }
 
+// Admin endpoints listen on their own port, off when no token is set
+type Admin struct {
+	Port  string `env:"ADMIN_PORT" envDefault:"8082"`
+	Token string `env:"ADMIN_TOKEN" envDefault:""`
+}
+
 type GRPC struct {
 	Host string `env:"GRPC_HOST" envDefault:"localhost"`
 	Port string `env:"GRPC_PORT" envDefault:"50051"`
 }
 
+type DB struct {
+	DSN             string        `env:"DB_DSN" envDefault:"postgres://user:pass@db:5432/dbname?sslmode=disable"`
+	MaxConns        int32         `yaml:"max_conns"`
+	MinConns        int32         `yaml:"min_conns"`
+	MaxConnLifetime time.Duration `yaml:"max_conn_lifetime"`  // minutes
+	MaxConnIdleTime time.Duration `yaml:"max_conn_idle_time"` // minutes
+}
+
 type Kafka struct {
 	Brokers string            `env:"KAFKA_BROKER" envDefault:"kafka:9092"`
 	GroupID string            `yaml:"group_id"`
 	Topics  map[string]string `yaml:"topics"`
+	// Encoding per command topic key, json | protobuf, json when unset
+	ContentTypes map[string]string `yaml:"content_types"`
 }
 
 type Log struct {
@@ -56,3 +90,41 @@ type Metric struct {
 	Endpoint     string        `env:"PROMETHEUS_ENDPOINT" envDefault:"prometheus:4317"`
 	ReaderPeriod time.Duration `yaml:"reader_period"`
 }
+
+type Idempotency struct {
+	Store string        `yaml:"store"` // memory | postgres
+	TTL   time.Duration `yaml:"ttl"`
+}
+
+type Commands struct {
+	TTL     time.Duration `yaml:"ttl"`
+	MaxWait time.Duration `yaml:"max_wait"`
+}
+
+type Events struct {
+	Heartbeat time.Duration `yaml:"heartbeat"`
+}
+
+type Batch struct {
+	MaxSize int `yaml:"max_size"` // ids per POST /orders:batchGet
+}
+
+type Cache struct {
+	Enabled    bool                     `yaml:"enabled"`
+	Store      string                   `yaml:"store"` // memory
+	Capacity   int                      `yaml:"capacity"`
+	DefaultTTL time.Duration            `yaml:"default_ttl"`
+	StatusTTL  map[string]time.Duration `yaml:"status_ttl"` // by order status, overrides default_ttl
+}
+
+type ReadModel struct {
+	Enabled bool   `yaml:"enabled"`
+	Mode    string `yaml:"mode"` // primary | fallback
+}
+
+type SchemaRegistry struct {
+	Kind    string        `yaml:"kind"` // none | http | local
+	URL     string        `env:"SCHEMA_REGISTRY_URL" envDefault:"http://schema-registry:8081"`
+	Path    string        `yaml:"path"` // local registry file, relative to APP_HOME
+	Timeout time.Duration `yaml:"timeout"`
+}
@@ -0,0 +1,12 @@
+CREATE TABLE idempotency_keys (
+    key           TEXT         PRIMARY KEY,
+    request_hash  TEXT         NOT NULL,
+    status_code   INT,
+    headers       JSONB,
+    body          BYTEA,
+    completed     BOOLEAN      NOT NULL DEFAULT FALSE,
+    created_at    TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+    expires_at    TIMESTAMPTZ  NOT NULL
+);
+
+CREATE INDEX idx_idempotency_keys_expires_at ON idempotency_keys (expires_at);
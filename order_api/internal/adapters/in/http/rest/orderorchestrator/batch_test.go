@@ -0,0 +1,104 @@
+package orderorchestrator
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Anacardo89/order_svc_hex/order_api/internal/adapters/infra/log/loki/logger"
+	"github.com/Anacardo89/order_svc_hex/order_api/internal/core"
+	"github.com/Anacardo89/order_svc_hex/order_api/internal/ports"
+)
+
+type batchReader struct {
+	ports.OrderReader
+	found map[uuid.UUID]bool
+	calls int
+}
+
+func (f *batchReader) GetByIDs(ctx context.Context, qry *core.GetOrdersByIDsQry) (*core.OrderBatch, error) {
+	f.calls++
+	batch := &core.OrderBatch{}
+	for _, id := range qry.IDs {
+		if f.found[id] {
+			batch.Orders = append(batch.Orders, &core.Order{ID: id, Status: core.StatusPending})
+		} else {
+			batch.Missing = append(batch.Missing, id)
+		}
+	}
+	return batch, nil
+}
+
+func TestOrderHandler_BatchGetOrders(t *testing.T) {
+	logger.BaseLogger = logger.NopLogger{}
+	known := uuid.MustParse("11111111-1111-1111-1111-111111111111")
+	unknown := uuid.MustParse("22222222-2222-2222-2222-222222222222")
+
+	tests := []struct {
+		name        string
+		body        string
+		wantStatus  int
+		wantFound   []uuid.UUID
+		wantMissing []uuid.UUID
+		wantCalls   int
+	}{
+		{
+			name:        "found and missing reported apart",
+			body:        `{"ids": ["` + known.String() + `", "` + unknown.String() + `"]}`,
+			wantStatus:  http.StatusOK,
+			wantFound:   []uuid.UUID{known},
+			wantMissing: []uuid.UUID{unknown},
+			wantCalls:   1,
+		},
+		{
+			name:       "over the cap",
+			body:       `{"ids": ["` + known.String() + `", "` + known.String() + `", "` + known.String() + `"]}`,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "empty batch",
+			body:       `{"ids": []}`,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "invalid id",
+			body:       `{"ids": ["nope"]}`,
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reader := &batchReader{found: map[uuid.UUID]bool{known: true}}
+			h := NewOrderHandler(reader, nil, nil, nil, 0, 0, 2)
+			router := mux.NewRouter()
+			router.HandleFunc("/orders/{id}", h.GetOrder).Methods("GET")
+			router.HandleFunc("/orders:batchGet", h.BatchGetOrders).Methods("POST")
+			req := httptest.NewRequest(http.MethodPost, "/orders:batchGet", strings.NewReader(tt.body))
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			assert.Equal(t, tt.wantCalls, reader.calls)
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+			var resp BatchGetOrdersResp
+			require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+			found := make([]uuid.UUID, 0, len(resp.Orders))
+			for _, o := range resp.Orders {
+				found = append(found, o.ID)
+			}
+			assert.Equal(t, tt.wantFound, found)
+			assert.Equal(t, tt.wantMissing, resp.MissingIDs)
+		})
+	}
+}
@@ -0,0 +1,273 @@
+package orderorchestrator
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"github.com/Anacardo89/order_svc_hex/order_api/internal/adapters/infra/log/loki/logger"
+	"github.com/Anacardo89/order_svc_hex/order_api/internal/core"
+	"github.com/Anacardo89/order_svc_hex/order_api/internal/ports"
+	"github.com/Anacardo89/order_svc_hex/order_api/pkg/validator"
+)
+
+// Admin endpoints over order_svc's dead letter index
+type DeadLetterHandler struct {
+	admin ports.DeadLetterAdmin
+}
+
+func NewDeadLetterHandler(admin ports.DeadLetterAdmin) *DeadLetterHandler {
+	return &DeadLetterHandler{
+		admin: admin,
+	}
+}
+
+// GET /admin/dead-letters
+func (h *DeadLetterHandler) ListDeadLetters(w http.ResponseWriter, r *http.Request) {
+	// Setup
+	ctx := r.Context()
+	log := logger.LogFromCtx(ctx, logger.BaseLogger)
+	w.Header().Set("Content-Type", "application/json")
+
+	// Execution
+	qry, err := parseListDeadLettersQry(r.URL.Query())
+	if err != nil {
+		log.Error(ctx, "invalid query", ports.Field{Key: "error", Value: err})
+		failHttp(w, ctx, http.StatusBadRequest, err.Error(), err)
+		return
+	}
+	page, err := h.admin.ListDeadLetters(ctx, qry)
+	if err != nil {
+		log.Error(ctx, "failed to list dead letters", ports.Field{Key: "error", Value: err})
+		h.failAdmin(w, ctx, err)
+		return
+	}
+	writeJSON(w, ctx, http.StatusOK, page)
+}
+
+// GET /admin/dead-letters/counts
+type CountDeadLettersResp struct {
+	Counts []*core.DeadLetterCount `json:"counts"`
+}
+
+func (h *DeadLetterHandler) CountDeadLetters(w http.ResponseWriter, r *http.Request) {
+	// Setup
+	ctx := r.Context()
+	log := logger.LogFromCtx(ctx, logger.BaseLogger)
+	w.Header().Set("Content-Type", "application/json")
+
+	// Execution
+	filter, err := parseDeadLetterFilter(r.URL.Query())
+	if err != nil {
+		log.Error(ctx, "invalid query", ports.Field{Key: "error", Value: err})
+		failHttp(w, ctx, http.StatusBadRequest, err.Error(), err)
+		return
+	}
+	counts, err := h.admin.CountDeadLetters(ctx, filter)
+	if err != nil {
+		log.Error(ctx, "failed to count dead letters", ports.Field{Key: "error", Value: err})
+		h.failAdmin(w, ctx, err)
+		return
+	}
+	writeJSON(w, ctx, http.StatusOK, CountDeadLettersResp{Counts: counts})
+}
+
+// GET /admin/dead-letters/{id}
+type DeadLetterResp struct {
+	DeadLetter *core.DeadLetter `json:"dead_letter"`
+}
+
+func (h *DeadLetterHandler) GetDeadLetter(w http.ResponseWriter, r *http.Request) {
+	// Setup
+	ctx := r.Context()
+	log := logger.LogFromCtx(ctx, logger.BaseLogger)
+	w.Header().Set("Content-Type", "application/json")
+
+	// Execution
+	id, err := deadLetterID(r)
+	if err != nil {
+		log.Error(ctx, "failed to parse id from URL", ports.Field{Key: "error", Value: err})
+		failHttp(w, ctx, http.StatusBadRequest, "invalid path", err)
+		return
+	}
+	letter, err := h.admin.GetDeadLetter(ctx, id)
+	if err != nil {
+		log.Error(ctx, "failed to get dead letter", ports.Field{Key: "error", Value: err})
+		h.failAdmin(w, ctx, err)
+		return
+	}
+	writeJSON(w, ctx, http.StatusOK, DeadLetterResp{DeadLetter: letter})
+}
+
+// POST /admin/dead-letters/{id}:resolve
+type ResolveDeadLetterReq struct {
+	Note string `json:"note"`
+}
+
+func (h *DeadLetterHandler) ResolveDeadLetter(w http.ResponseWriter, r *http.Request) {
+	// Setup
+	ctx := r.Context()
+	log := logger.LogFromCtx(ctx, logger.BaseLogger)
+	w.Header().Set("Content-Type", "application/json")
+
+	// Execution
+	id, err := deadLetterID(r)
+	if err != nil {
+		log.Error(ctx, "failed to parse id from URL", ports.Field{Key: "error", Value: err})
+		failHttp(w, ctx, http.StatusBadRequest, "invalid path", err)
+		return
+	}
+	raw, err := io.ReadAll(r.Body)
+	if err != nil {
+		log.Error(ctx, "failed to read request body", ports.Field{Key: "error", Value: err})
+		failHttp(w, ctx, http.StatusBadRequest, "invalid request body", err)
+		return
+	}
+	// The note is optional, so is the body
+	var reqBody ResolveDeadLetterReq
+	if len(bytes.TrimSpace(raw)) > 0 {
+		if err := json.Unmarshal(raw, &reqBody); err != nil {
+			log.Error(ctx, "failed to parse JSON from body", ports.Field{Key: "error", Value: err})
+			failHttp(w, ctx, http.StatusBadRequest, "invalid request body", err)
+			return
+		}
+	}
+	letter, err := h.admin.ResolveDeadLetter(ctx, &core.ResolveDeadLetterCmd{
+		ID:   id,
+		Note: reqBody.Note,
+	})
+	if err != nil {
+		log.Error(ctx, "failed to resolve dead letter", ports.Field{Key: "error", Value: err})
+		h.failAdmin(w, ctx, err)
+		return
+	}
+	writeJSON(w, ctx, http.StatusOK, DeadLetterResp{DeadLetter: letter})
+}
+
+// POST /admin/dead-letters/{id}:replay
+func (h *DeadLetterHandler) ReplayDeadLetter(w http.ResponseWriter, r *http.Request) {
+	// Setup
+	ctx := r.Context()
+	log := logger.LogFromCtx(ctx, logger.BaseLogger)
+	w.Header().Set("Content-Type", "application/json")
+
+	// Execution
+	id, err := deadLetterID(r)
+	if err != nil {
+		log.Error(ctx, "failed to parse id from URL", ports.Field{Key: "error", Value: err})
+		failHttp(w, ctx, http.StatusBadRequest, "invalid path", err)
+		return
+	}
+	letter, err := h.admin.ReplayDeadLetter(ctx, id)
+	if err != nil {
+		log.Error(ctx, "failed to replay dead letter", ports.Field{Key: "error", Value: err})
+		h.failAdmin(w, ctx, err)
+		return
+	}
+	writeJSON(w, ctx, http.StatusOK, DeadLetterResp{DeadLetter: letter})
+}
+
+// POST /admin/dead-letters:purge
+type PurgeDeadLettersReq struct {
+	Reasons        []string   `json:"reasons"`
+	OriginalTopics []string   `json:"original_topics"`
+	ErrorContains  string     `json:"error_contains"`
+	Statuses       []string   `json:"statuses"`
+	FailedAfter    *time.Time `json:"failed_after"`
+	FailedBefore   *time.Time `json:"failed_before"`
+}
+
+type PurgeDeadLettersResp struct {
+	Deleted int64 `json:"deleted"`
+}
+
+func (h *DeadLetterHandler) PurgeDeadLetters(w http.ResponseWriter, r *http.Request) {
+	// Setup
+	ctx := r.Context()
+	log := logger.LogFromCtx(ctx, logger.BaseLogger)
+	w.Header().Set("Content-Type", "application/json")
+
+	// Execution
+	raw, err := io.ReadAll(r.Body)
+	if err != nil {
+		log.Error(ctx, "failed to read request body", ports.Field{Key: "error", Value: err})
+		failHttp(w, ctx, http.StatusBadRequest, "invalid request body", err)
+		return
+	}
+	var reqBody PurgeDeadLettersReq
+	if err := validator.ParseAndValidate(raw, &reqBody); err != nil {
+		log.Error(ctx, "failed to parse JSON from body", ports.Field{Key: "error", Value: err})
+		failHttp(w, ctx, http.StatusBadRequest, "invalid request body", err)
+		return
+	}
+	filter := core.DeadLetterFilter{
+		Reasons:        reqBody.Reasons,
+		OriginalTopics: reqBody.OriginalTopics,
+		ErrorContains:  reqBody.ErrorContains,
+		FailedAfter:    reqBody.FailedAfter,
+		FailedBefore:   reqBody.FailedBefore,
+	}
+	for _, s := range reqBody.Statuses {
+		status, err := core.MapStrToDeadLetterStatus(s)
+		if err != nil {
+			log.Error(ctx, "invalid status", ports.Field{Key: "error", Value: err})
+			failHttp(w, ctx, http.StatusBadRequest, errMsgInvalidDeadLetterStatus, err)
+			return
+		}
+		filter.Statuses = append(filter.Statuses, status)
+	}
+	// An empty filter would wipe the whole index
+	if len(filter.Statuses) == 0 && filter.FailedBefore == nil {
+		err := errors.New("purge without statuses or failed_before")
+		log.Error(ctx, "unbounded purge", ports.Field{Key: "error", Value: err})
+		failHttp(w, ctx, http.StatusBadRequest, errMsgUnboundedPurge, err)
+		return
+	}
+	deleted, err := h.admin.PurgeDeadLetters(ctx, filter)
+	if err != nil {
+		log.Error(ctx, "failed to purge dead letters", ports.Field{Key: "error", Value: err})
+		h.failAdmin(w, ctx, err)
+		return
+	}
+	writeJSON(w, ctx, http.StatusOK, PurgeDeadLettersResp{Deleted: deleted})
+}
+
+func (h *DeadLetterHandler) failAdmin(w http.ResponseWriter, ctx context.Context, err error) {
+	switch {
+	case errors.Is(err, core.ErrDeadLetterNotFound):
+		failHttp(w, ctx, http.StatusNotFound, "dead letter not found", err)
+	case errors.Is(err, core.ErrDeadLetterNotOpen):
+		failHttp(w, ctx, http.StatusConflict, "dead letter is already resolved or replayed", err)
+	case errors.Is(err, core.ErrInvalidDeadLetterQry):
+		// order_svc's message says what was wrong with the request
+		failHttp(w, ctx, http.StatusBadRequest, strings.TrimPrefix(err.Error(), core.ErrInvalidDeadLetterQry.Error()+": "), err)
+	default:
+		failHttp(w, ctx, http.StatusInternalServerError, "internal error", err)
+	}
+}
+
+func deadLetterID(r *http.Request) (int64, error) {
+	return strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+}
+
+func writeJSON(w http.ResponseWriter, ctx context.Context, status int, resp any) {
+	log := logger.LogFromCtx(ctx, logger.BaseLogger)
+	buf := new(bytes.Buffer)
+	if err := json.NewEncoder(buf).Encode(resp); err != nil {
+		log.Error(ctx, "failed to encode response body", ports.Field{Key: "error", Value: err})
+		failHttp(w, ctx, http.StatusInternalServerError, "internal error", err)
+		return
+	}
+	w.WriteHeader(status)
+	if _, err := w.Write(buf.Bytes()); err != nil {
+		log.Error(ctx, "failed to send response to client", ports.Field{Key: "error", Value: err})
+	}
+}
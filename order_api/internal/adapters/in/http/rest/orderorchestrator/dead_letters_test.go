@@ -0,0 +1,158 @@
+package orderorchestrator
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Anacardo89/order_svc_hex/order_api/internal/adapters/infra/log/loki/logger"
+	"github.com/Anacardo89/order_svc_hex/order_api/internal/core"
+	"github.com/Anacardo89/order_svc_hex/order_api/internal/ports"
+)
+
+type fakeDeadLetterAdmin struct {
+	ports.DeadLetterAdmin
+	resolved []*core.ResolveDeadLetterCmd
+	purged   []core.DeadLetterFilter
+}
+
+func (f *fakeDeadLetterAdmin) ResolveDeadLetter(ctx context.Context, cmd *core.ResolveDeadLetterCmd) (*core.DeadLetter, error) {
+	if cmd.ID == 3 {
+		return nil, fmt.Errorf("%w: id %d is replayed", core.ErrDeadLetterNotOpen, cmd.ID)
+	}
+	if cmd.ID != 1 {
+		return nil, fmt.Errorf("%w: id %d", core.ErrDeadLetterNotFound, cmd.ID)
+	}
+	f.resolved = append(f.resolved, cmd)
+	return &core.DeadLetter{ID: cmd.ID, Status: core.DeadLetterResolved, Note: cmd.Note}, nil
+}
+
+func (f *fakeDeadLetterAdmin) PurgeDeadLetters(ctx context.Context, filter core.DeadLetterFilter) (int64, error) {
+	f.purged = append(f.purged, filter)
+	return 3, nil
+}
+
+func TestDeadLetterHandler(t *testing.T) {
+	logger.BaseLogger = logger.NopLogger{}
+	tests := []struct {
+		name       string
+		path       string
+		body       string
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name:       "resolve with note",
+			path:       "/admin/dead-letters/1:resolve",
+			body:       `{"note":"fixed upstream"}`,
+			wantStatus: http.StatusOK,
+			wantBody:   `"note":"fixed upstream"`,
+		},
+		{
+			name:       "resolve without body",
+			path:       "/admin/dead-letters/1:resolve",
+			wantStatus: http.StatusOK,
+			wantBody:   `"status":"resolved"`,
+		},
+		{
+			name:       "resolve unknown id",
+			path:       "/admin/dead-letters/2:resolve",
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "resolve replayed entry",
+			path:       "/admin/dead-letters/3:resolve",
+			wantStatus: http.StatusConflict,
+		},
+		{
+			name:       "purge by status",
+			path:       "/admin/dead-letters:purge",
+			body:       `{"statuses":["resolved","replayed"]}`,
+			wantStatus: http.StatusOK,
+			wantBody:   `"deleted":3`,
+		},
+		{
+			name:       "purge without bound",
+			path:       "/admin/dead-letters:purge",
+			body:       `{"reasons":["handler_error"]}`,
+			wantStatus: http.StatusBadRequest,
+			wantBody:   `purge needs statuses or failed_before`,
+		},
+		{
+			name:       "purge invalid status",
+			path:       "/admin/dead-letters:purge",
+			body:       `{"statuses":["gone"]}`,
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewDeadLetterHandler(&fakeDeadLetterAdmin{})
+			router := mux.NewRouter()
+			router.HandleFunc("/admin/dead-letters:purge", h.PurgeDeadLetters).Methods("POST")
+			router.HandleFunc("/admin/dead-letters/{id:[0-9]+}:resolve", h.ResolveDeadLetter).Methods("POST")
+			req := httptest.NewRequest(http.MethodPost, tt.path, strings.NewReader(tt.body))
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			assert.Contains(t, rec.Body.String(), tt.wantBody)
+		})
+	}
+}
+
+func TestAdminAuth(t *testing.T) {
+	logger.BaseLogger = logger.NopLogger{}
+	tests := []struct {
+		name       string
+		token      string
+		header     string
+		wantStatus int
+	}{
+		{
+			name:       "matching token",
+			token:      "secret",
+			header:     "Bearer secret",
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "wrong token",
+			token:      "secret",
+			header:     "Bearer guess",
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "no header",
+			token:      "secret",
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "no token configured",
+			header:     "Bearer ",
+			wantStatus: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := AdminAuth(tt.token)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			}))
+			req := httptest.NewRequest(http.MethodGet, "/admin/dead-letters", nil)
+			if tt.header != "" {
+				req.Header.Set("Authorization", tt.header)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+		})
+	}
+}
@@ -11,11 +11,28 @@ import (
 	"go.opentelemetry.io/otel/trace"
 )
 
+// Rejection reasons reported by order_svc
+const (
+	reasonInvalidTransition = "invalid_transition"
+	reasonOrderNotFound     = "order_not_found"
+	reasonHandlerError      = "handler_error"
+)
+
+const errMsgInvalidStatus = "status must be one of 'pending', 'confirmed', 'failed', 'cancelled', 'shipped', 'delivered' or 'refunded'"
+
+const errMsgInvalidDeadLetterStatus = "status must be one of 'open', 'resolved' or 'replayed'"
+
+const errMsgUnboundedPurge = "purge needs statuses or failed_before"
+
 type ErrorResp struct {
 	Error string `json:"error"`
 }
 
 func (h *OrderHandler) failHttp(w http.ResponseWriter, ctx context.Context, status int, outMsg string, err error) {
+	failHttp(w, ctx, status, outMsg, err)
+}
+
+func failHttp(w http.ResponseWriter, ctx context.Context, status int, outMsg string, err error) {
 	log := logger.LogFromCtx(ctx, logger.BaseLogger)
 	span := trace.SpanFromContext(ctx)
 	if span != nil {
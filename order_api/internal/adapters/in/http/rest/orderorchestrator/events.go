@@ -0,0 +1,172 @@
+package orderorchestrator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+
+	"github.com/Anacardo89/order_svc_hex/order_api/internal/adapters/infra/log/loki/logger"
+	"github.com/Anacardo89/order_svc_hex/order_api/internal/core"
+	"github.com/Anacardo89/order_svc_hex/order_api/internal/ports"
+	"github.com/Anacardo89/order_svc_hex/order_api/pkg/ptr"
+)
+
+const HeaderLastEventID = "Last-Event-ID"
+
+// SSE event name for status transitions, the current state goes out as eventOrder
+const eventStatus = "status"
+
+const (
+	defaultHeartbeat = 15 * time.Second
+	// Reconnect delay suggested to EventSource clients
+	eventsRetry = 3 * time.Second
+)
+
+// Event ids are the change time so a reconnect can resume right after it
+func eventID(t time.Time) string {
+	return t.UTC().Format(time.RFC3339Nano)
+}
+
+// nil when the client isn't resuming
+func parseLastEventID(r *http.Request) (*time.Time, error) {
+	raw := r.Header.Get(HeaderLastEventID)
+	if raw == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339Nano, raw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", HeaderLastEventID, err)
+	}
+	return &t, nil
+}
+
+func (s *orderStreamWriter) Retry(d time.Duration) error {
+	return s.writeRaw(fmt.Sprintf("retry: %d", d.Milliseconds()))
+}
+
+// Keeps proxies from closing an idle connection
+func (s *orderStreamWriter) Heartbeat() error {
+	return s.writeRaw(": heartbeat")
+}
+
+func (s *orderStreamWriter) Snapshot(o *core.Order) error {
+	return s.writeEvent(eventID(o.UpdatedAt), eventOrder, o)
+}
+
+func (s *orderStreamWriter) Change(c *core.OrderChange) error {
+	return s.writeEvent(eventID(c.ChangedAt), eventStatus, c)
+}
+
+// GET /orders/{id}/events
+func (h *OrderHandler) OrderEvents(w http.ResponseWriter, r *http.Request) {
+	// Setup
+	ctx := r.Context()
+	log := logger.LogFromCtx(ctx, logger.BaseLogger)
+	w.Header().Set("Content-Type", "application/json")
+
+	// Execution
+	vars := mux.Vars(r)
+	id, err := uuid.Parse(vars["id"])
+	if err != nil {
+		log.Error(ctx, "failed to parse id from URL", ports.Field{Key: "error", Value: err})
+		h.failHttp(w, ctx, http.StatusBadRequest, "invalid path", err)
+		return
+	}
+	since, err := parseLastEventID(r)
+	if err != nil {
+		log.Error(ctx, "invalid last event id", ports.Field{Key: "error", Value: err})
+		h.failHttp(w, ctx, http.StatusBadRequest, "invalid Last-Event-ID", err)
+		return
+	}
+	// Fresh subscribers start from the current state
+	var current *core.Order
+	if since == nil {
+		current, err = h.svc.GetOrder(ctx, &core.GetOrderQry{ID: id})
+		if err != nil {
+			log.Error(ctx, "failed to get order from order_svc", ports.Field{Key: "error", Value: err})
+			h.failHttp(w, ctx, http.StatusNotFound, "order not found", err)
+			return
+		}
+		since = ptr.Ptr(current.UpdatedAt)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	changes := make(chan *core.OrderChange)
+	watchErr := make(chan error, 1)
+	watchDone := make(chan struct{})
+	qry := &core.WatchOrderQry{
+		ID:    id,
+		Since: since,
+	}
+	go func() {
+		defer close(watchDone)
+		watchErr <- h.svc.WatchOrder(ctx, qry, func(c *core.OrderChange) error {
+			select {
+			case changes <- c:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		})
+	}()
+	// The order_svc stream is gone once the handler returns
+	defer func() {
+		cancel()
+		<-watchDone
+	}()
+
+	sw := newOrderStreamWriter(w, MediaTypeEventStream)
+	if err := sw.Retry(eventsRetry); err != nil {
+		log.Error(ctx, "failed to send response to client", ports.Field{Key: "error", Value: err})
+		return
+	}
+	if current != nil {
+		if err := sw.Snapshot(current); err != nil {
+			log.Error(ctx, "failed to send response to client", ports.Field{Key: "error", Value: err})
+			return
+		}
+	}
+	last := *since
+	heartbeat := time.NewTicker(h.heartbeat)
+	defer heartbeat.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			log.Debug(ctx, "client disconnected from order events")
+			return
+		case <-h.shutdown:
+			return
+		case <-heartbeat.C:
+			if err := sw.Heartbeat(); err != nil {
+				log.Error(ctx, "failed to send heartbeat to client", ports.Field{Key: "error", Value: err})
+				return
+			}
+		case change := <-changes:
+			// The replay overlaps with what the client already has
+			if !change.ChangedAt.After(last) {
+				continue
+			}
+			last = change.ChangedAt
+			if err := sw.Change(change); err != nil {
+				log.Error(ctx, "failed to send response to client", ports.Field{Key: "error", Value: err})
+				return
+			}
+		case err := <-watchErr:
+			// Closing lets the client reconnect with Last-Event-ID
+			if err == nil || errors.Is(err, core.ErrWatchLagged) {
+				log.Warn(ctx, "order events subscription ended", ports.Field{Key: "error", Value: err})
+				return
+			}
+			log.Error(ctx, "order events subscription failed", ports.Field{Key: "error", Value: err})
+			if err := sw.Fail("internal error"); err != nil {
+				log.Error(ctx, "failed to send response to client", ports.Field{Key: "error", Value: err})
+			}
+			return
+		}
+	}
+}
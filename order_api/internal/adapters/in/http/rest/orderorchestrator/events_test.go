@@ -0,0 +1,167 @@
+package orderorchestrator
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Anacardo89/order_svc_hex/order_api/internal/adapters/infra/log/loki/logger"
+	"github.com/Anacardo89/order_svc_hex/order_api/internal/core"
+	"github.com/Anacardo89/order_svc_hex/order_api/internal/ports"
+)
+
+type watchReader struct {
+	ports.OrderReader
+	order   *core.Order
+	changes []*core.OrderChange
+	// Blocks until ctx is done instead of ending the subscription
+	block   bool
+	since   *time.Time
+	stopped bool
+}
+
+func (f *watchReader) GetByID(ctx context.Context, qry *core.GetOrderQry) (*core.Order, error) {
+	if f.order == nil {
+		return nil, errors.New("not found")
+	}
+	return f.order, nil
+}
+
+func (f *watchReader) Watch(ctx context.Context, qry *core.WatchOrderQry, send func(*core.OrderChange) error) error {
+	f.since = qry.Since
+	for _, c := range f.changes {
+		if err := send(c); err != nil {
+			return err
+		}
+	}
+	if f.block {
+		<-ctx.Done()
+		f.stopped = true
+		return ctx.Err()
+	}
+	return core.ErrWatchLagged
+}
+
+func TestOrderHandler_OrderEvents(t *testing.T) {
+	logger.BaseLogger = logger.NopLogger{}
+	id := uuid.MustParse("11111111-1111-1111-1111-111111111111")
+	t0 := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	t1 := t0.Add(time.Second)
+	t2 := t1.Add(time.Second)
+	change := func(at time.Time, status core.Status) *core.OrderChange {
+		return &core.OrderChange{
+			Type:      core.ChangeUpdated,
+			Order:     &core.Order{ID: id, Status: status, UpdatedAt: at},
+			ChangedAt: at,
+		}
+	}
+
+	tests := []struct {
+		name        string
+		lastEventID string
+		reader      *watchReader
+		wantStatus  int
+		wantSince   time.Time
+		wantLines   []string
+		wantChanges int
+		notWant     string
+	}{
+		{
+			name:       "fresh subscriber gets current state then transitions",
+			reader:     &watchReader{order: &core.Order{ID: id, Status: core.StatusPending, UpdatedAt: t0}, changes: []*core.OrderChange{change(t0, core.StatusPending), change(t1, core.StatusConfirmed)}},
+			wantStatus: http.StatusOK,
+			wantSince:  t0,
+			wantLines: []string{
+				"retry: 3000",
+				"id: " + eventID(t0),
+				"event: order",
+				"id: " + eventID(t1),
+				"event: status",
+				`"status":"confirmed"`,
+			},
+			wantChanges: 1,
+		},
+		{
+			name:        "resume skips changes the client already has",
+			lastEventID: eventID(t1),
+			reader:      &watchReader{changes: []*core.OrderChange{change(t1, core.StatusConfirmed), change(t2, core.StatusShipped)}},
+			wantStatus:  http.StatusOK,
+			wantSince:   t1,
+			wantLines: []string{
+				"id: " + eventID(t2),
+				`"status":"shipped"`,
+			},
+			wantChanges: 1,
+			notWant:     "event: order",
+		},
+		{
+			name:        "invalid last event id",
+			lastEventID: "yesterday",
+			reader:      &watchReader{},
+			wantStatus:  http.StatusBadRequest,
+		},
+		{
+			name:       "unknown order",
+			reader:     &watchReader{},
+			wantStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewOrderHandler(tt.reader, nil, nil, nil, 0, time.Hour, 0)
+			req := httptest.NewRequest(http.MethodGet, "/orders/"+id.String()+"/events", nil)
+			req = mux.SetURLVars(req, map[string]string{"id": id.String()})
+			if tt.lastEventID != "" {
+				req.Header.Set(HeaderLastEventID, tt.lastEventID)
+			}
+			rec := httptest.NewRecorder()
+			h.OrderEvents(rec, req)
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+			assert.Equal(t, MediaTypeEventStream, rec.Header().Get("Content-Type"))
+			require.NotNil(t, tt.reader.since)
+			assert.True(t, tt.wantSince.Equal(*tt.reader.since))
+			body := rec.Body.String()
+			last := 0
+			for _, line := range tt.wantLines {
+				idx := strings.Index(body[last:], line)
+				if assert.GreaterOrEqual(t, idx, 0, "missing %q in order", line) {
+					last += idx + len(line)
+				}
+			}
+			assert.Equal(t, tt.wantChanges, strings.Count(body, "event: status"))
+			if tt.notWant != "" {
+				assert.NotContains(t, body, tt.notWant)
+			}
+		})
+	}
+}
+
+func TestOrderHandler_OrderEventsDisconnect(t *testing.T) {
+	logger.BaseLogger = logger.NopLogger{}
+	id := uuid.MustParse("11111111-1111-1111-1111-111111111111")
+	reader := &watchReader{order: &core.Order{ID: id, Status: core.StatusPending}, block: true}
+	h := NewOrderHandler(reader, nil, nil, nil, 0, 5*time.Millisecond, 0)
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	req := httptest.NewRequestWithContext(ctx, http.MethodGet, "/orders/"+id.String()+"/events", nil)
+	req = mux.SetURLVars(req, map[string]string{"id": id.String()})
+	rec := httptest.NewRecorder()
+	h.OrderEvents(rec, req)
+
+	assert.Contains(t, rec.Body.String(), ": heartbeat")
+	assert.True(t, reader.stopped, "watch still running after disconnect")
+}
@@ -4,9 +4,12 @@ import (
 	"bytes"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
 	"net/http"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/gorilla/mux"
@@ -14,20 +17,48 @@ import (
 	"github.com/Anacardo89/order_svc_hex/order_api/internal/adapters/infra/log/loki/logger"
 	"github.com/Anacardo89/order_svc_hex/order_api/internal/core"
 	"github.com/Anacardo89/order_svc_hex/order_api/internal/ports"
+	"github.com/Anacardo89/order_svc_hex/order_api/pkg/ptr"
 	"github.com/Anacardo89/order_svc_hex/order_api/pkg/validator"
 )
 
 type OrderHandler struct {
-	svc ports.OrderOrchestrator
+	svc         ports.OrderOrchestrator
+	idempotency ports.IdempotencyStore
+	maxWait     time.Duration
+	heartbeat   time.Duration
+	maxBatch    int
+	// Closed on server shutdown to end open event streams
+	shutdown  chan struct{}
+	closeOnce sync.Once
 }
 
-func NewOrderHandler(reader ports.OrderReader, writer ports.OrderWriter) *OrderHandler {
-	svc := NewOrderService(reader, writer)
+func NewOrderHandler(reader ports.OrderReader, writer ports.OrderWriter, commands ports.CommandTracker, idempotency ports.IdempotencyStore, maxWait, heartbeat time.Duration, maxBatch int) *OrderHandler {
+	svc := NewOrderService(reader, writer, commands)
+	if maxWait <= 0 {
+		maxWait = defaultMaxWait
+	}
+	if heartbeat <= 0 {
+		heartbeat = defaultHeartbeat
+	}
+	if maxBatch <= 0 {
+		maxBatch = defaultBatchSize
+	}
 	return &OrderHandler{
-		svc: svc,
+		svc:         svc,
+		idempotency: idempotency,
+		maxWait:     maxWait,
+		heartbeat:   heartbeat,
+		maxBatch:    maxBatch,
+		shutdown:    make(chan struct{}),
 	}
 }
 
+func (h *OrderHandler) Close() {
+	h.closeOnce.Do(func() {
+		close(h.shutdown)
+	})
+}
+
 type HealthCheckResp struct {
 	Status string `json:"status"`
 }
@@ -95,41 +126,129 @@ func (h *OrderHandler) GetOrder(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// POST /orders:batchGet
+const defaultBatchSize = 100
+
+type BatchGetOrdersReq struct {
+	IDs []string `json:"ids" validate:"required"`
+}
+
+type BatchGetOrdersResp struct {
+	Orders     []*core.Order `json:"orders"`
+	MissingIDs []uuid.UUID   `json:"missing_ids"`
+}
+
+func (h *OrderHandler) BatchGetOrders(w http.ResponseWriter, r *http.Request) {
+	// Setup
+	ctx := r.Context()
+	log := logger.LogFromCtx(ctx, logger.BaseLogger)
+	w.Header().Set("Content-Type", "application/json")
+
+	// Execution
+	raw, err := io.ReadAll(r.Body)
+	if err != nil {
+		log.Error(ctx, "failed to read request body", ports.Field{Key: "error", Value: err})
+		h.failHttp(w, ctx, http.StatusBadRequest, "invalid request body", err)
+		return
+	}
+	var reqBody BatchGetOrdersReq
+	if err := validator.ParseAndValidate(raw, &reqBody); err != nil {
+		if strings.Contains(err.Error(), "missing fields") {
+			log.Error(ctx, "missing required fields", ports.Field{Key: "error", Value: err})
+			h.failHttp(w, ctx, http.StatusBadRequest, err.Error(), err)
+		} else {
+			log.Error(ctx, "failed to parse JSON from body", ports.Field{Key: "error", Value: err})
+			h.failHttp(w, ctx, http.StatusBadRequest, "invalid request body", err)
+		}
+		return
+	}
+	if len(reqBody.IDs) == 0 || len(reqBody.IDs) > h.maxBatch {
+		err := fmt.Errorf("got %d ids, max %d", len(reqBody.IDs), h.maxBatch)
+		log.Error(ctx, "invalid batch size", ports.Field{Key: "error", Value: err})
+		h.failHttp(w, ctx, http.StatusBadRequest, fmt.Sprintf("ids must hold between 1 and %d ids", h.maxBatch), err)
+		return
+	}
+	qry := &core.GetOrdersByIDsQry{
+		IDs: make([]uuid.UUID, 0, len(reqBody.IDs)),
+	}
+	for _, raw := range reqBody.IDs {
+		id, err := uuid.Parse(raw)
+		if err != nil {
+			log.Error(ctx, "invalid id in batch", ports.Field{Key: "error", Value: err})
+			h.failHttp(w, ctx, http.StatusBadRequest, "ids must be valid UUIDs", err)
+			return
+		}
+		qry.IDs = append(qry.IDs, id)
+	}
+	batch, err := h.svc.GetOrdersByIDs(ctx, qry)
+	if errors.Is(err, core.ErrInvalidBatch) {
+		log.Error(ctx, "batch rejected by order_svc", ports.Field{Key: "error", Value: err})
+		h.failHttp(w, ctx, http.StatusBadRequest, "invalid batch", err)
+		return
+	}
+	if err != nil {
+		log.Error(ctx, "failed to get orders from order_svc", ports.Field{Key: "error", Value: err})
+		h.failHttp(w, ctx, http.StatusInternalServerError, "internal error", err)
+		return
+	}
+	resp := BatchGetOrdersResp{
+		Orders:     batch.Orders,
+		MissingIDs: batch.Missing,
+	}
+	buf := new(bytes.Buffer)
+	if err := json.NewEncoder(buf).Encode(resp); err != nil {
+		log.Error(ctx, "failed to encode response body", ports.Field{Key: "error", Value: err})
+		h.failHttp(w, ctx, http.StatusInternalServerError, "internal error", err)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(buf.Bytes()); err != nil {
+		log.Error(ctx, "failed to send response to client", ports.Field{Key: "error", Value: err})
+	}
+}
+
 // GET /orders
 type GetOrdersResp struct {
-	Orders []*core.Order `json:"orders"`
+	Orders        []*core.Order `json:"orders"`
+	NextPageToken string        `json:"next_page_token,omitempty"`
 }
 
-func (h *OrderHandler) ListOrdersByStatus(w http.ResponseWriter, r *http.Request) {
+func (h *OrderHandler) ListOrders(w http.ResponseWriter, r *http.Request) {
 	// Setup
 	ctx := r.Context()
 	log := logger.LogFromCtx(ctx, logger.BaseLogger)
 	w.Header().Set("Content-Type", "application/json")
 
 	// Execution
-	statusStr := r.URL.Query().Get("status")
-	if statusStr == "" {
-		log.Error(ctx, "request with empty query")
-		h.failHttp(w, ctx, http.StatusBadRequest, "request must contain 'status' in query", errors.New("request with empty query"))
+	qry, err := parseListOrdersQry(r.URL.Query())
+	if err != nil {
+		log.Error(ctx, "invalid query", ports.Field{Key: "error", Value: err})
+		h.failHttp(w, ctx, http.StatusBadRequest, err.Error(), err)
 		return
 	}
-	status, err := core.MapStrToStatus(statusStr)
-	if err != nil {
-		log.Error(ctx, "invalid status", ports.Field{Key: "error", Value: err})
-		h.failHttp(w, ctx, http.StatusBadRequest, "status must be either 'pending', 'confirmed' or 'failed'", err)
+	if mediaType := streamMediaType(r); mediaType != "" {
+		h.streamOrders(w, r, qry, mediaType)
 		return
 	}
-	qry := &core.ListOrdersByStatusQry{
-		Status: *status,
+	page, err := h.svc.ListOrders(ctx, qry)
+	if errors.Is(err, core.ErrInvalidCursor) {
+		log.Error(ctx, "invalid cursor", ports.Field{Key: "error", Value: err})
+		h.failHttp(w, ctx, http.StatusBadRequest, "invalid cursor", err)
+		return
+	}
+	if errors.Is(err, core.ErrInvalidListQry) {
+		log.Error(ctx, "invalid query", ports.Field{Key: "error", Value: err})
+		h.failHttp(w, ctx, http.StatusBadRequest, err.Error(), err)
+		return
 	}
-	orders, err := h.svc.ListOrdersByStatus(ctx, qry)
 	if err != nil {
 		log.Error(ctx, "failed to get order from order_svc", ports.Field{Key: "error", Value: err})
 		h.failHttp(w, ctx, http.StatusInternalServerError, "internal error", err)
 		return
 	}
 	resp := GetOrdersResp{
-		Orders: orders,
+		Orders:        page.Orders,
+		NextPageToken: page.NextCursor,
 	}
 	buf := new(bytes.Buffer)
 	if err := json.NewEncoder(buf).Encode(resp); err != nil {
@@ -143,12 +262,61 @@ func (h *OrderHandler) ListOrdersByStatus(w http.ResponseWriter, r *http.Request
 	}
 }
 
+// GET /orders with Accept: application/x-ndjson or text/event-stream
+func (h *OrderHandler) streamOrders(w http.ResponseWriter, r *http.Request, qry *core.ListOrdersQry, mediaType string) {
+	// Setup
+	ctx := r.Context()
+	log := logger.LogFromCtx(ctx, logger.BaseLogger)
+	sw := newOrderStreamWriter(w, mediaType)
+
+	// Execution
+	next, err := h.svc.StreamOrders(ctx, qry, sw.Order)
+	if err != nil && !sw.started {
+		if errors.Is(err, core.ErrInvalidCursor) {
+			log.Error(ctx, "invalid cursor", ports.Field{Key: "error", Value: err})
+			h.failHttp(w, ctx, http.StatusBadRequest, "invalid cursor", err)
+			return
+		}
+		if errors.Is(err, core.ErrInvalidListQry) {
+			log.Error(ctx, "invalid query", ports.Field{Key: "error", Value: err})
+			h.failHttp(w, ctx, http.StatusBadRequest, err.Error(), err)
+			return
+		}
+		log.Error(ctx, "failed to stream orders from order_svc", ports.Field{Key: "error", Value: err})
+		h.failHttp(w, ctx, http.StatusInternalServerError, "internal error", err)
+		return
+	}
+	if err != nil {
+		log.Error(ctx, "order stream interrupted", ports.Field{Key: "error", Value: err})
+		if ctx.Err() == nil {
+			if err := sw.Fail("internal error"); err != nil {
+				log.Error(ctx, "failed to send response to client", ports.Field{Key: "error", Value: err})
+			}
+		}
+		return
+	}
+	if next != "" {
+		if err := sw.NextPage(next); err != nil {
+			log.Error(ctx, "failed to send response to client", ports.Field{Key: "error", Value: err})
+			return
+		}
+	}
+	if err := sw.Done(); err != nil {
+		log.Error(ctx, "failed to send response to client", ports.Field{Key: "error", Value: err})
+	}
+}
+
 // POST /orders
 type CreateOrderReq struct {
 	Items  map[string]int `json:"items" validate:"required"`
 	Status string         `json:"status"`
 }
 
+type CreateOrderResp struct {
+	ID        uuid.UUID `json:"id"`
+	CommandID uuid.UUID `json:"command_id"`
+}
+
 func (h *OrderHandler) CreateOrder(w http.ResponseWriter, r *http.Request) {
 	// Setup
 	ctx := r.Context()
@@ -156,6 +324,12 @@ func (h *OrderHandler) CreateOrder(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 
 	// Execution
+	wait, err := h.parseWait(r)
+	if err != nil {
+		log.Error(ctx, "invalid wait", ports.Field{Key: "error", Value: err})
+		h.failHttp(w, ctx, http.StatusBadRequest, "wait must be a positive duration", err)
+		return
+	}
 	raw, err := io.ReadAll(r.Body)
 	if err != nil {
 		log.Error(ctx, "failed to read request body", ports.Field{Key: "error", Value: err})
@@ -178,20 +352,39 @@ func (h *OrderHandler) CreateOrder(w http.ResponseWriter, r *http.Request) {
 		status, err = core.MapStrToStatus(reqBody.Status)
 		if err != nil {
 			log.Error(ctx, "invalid status", ports.Field{Key: "error", Value: err})
-			h.failHttp(w, ctx, http.StatusBadRequest, "status must be either 'pending', 'confirmed' or 'failed'", err)
+			h.failHttp(w, ctx, http.StatusBadRequest, errMsgInvalidStatus, err)
 			return
 		}
 	}
 	cmd := &core.CreateOrderCmd{
-		Items:  reqBody.Items,
-		Status: *status,
+		CommandID: uuid.New(),
+		ID:        uuid.New(),
+		Items:     reqBody.Items,
+		Status:    ptr.Val(status),
 	}
 	if err := h.svc.CreateOrder(ctx, cmd); err != nil {
 		log.Error(ctx, "failed to create order", ports.Field{Key: "error", Value: err})
 		h.failHttp(w, ctx, http.StatusInternalServerError, "internal error", err)
 		return
 	}
+	w.Header().Set("Location", "/orders/"+cmd.ID.String())
+	if wait > 0 && h.respondCompleted(w, r, cmd.CommandID, cmd.ID, wait, http.StatusCreated) {
+		return
+	}
+	resp := CreateOrderResp{
+		ID:        cmd.ID,
+		CommandID: cmd.CommandID,
+	}
+	buf := new(bytes.Buffer)
+	if err := json.NewEncoder(buf).Encode(resp); err != nil {
+		log.Error(ctx, "failed to encode response body", ports.Field{Key: "error", Value: err})
+		h.failHttp(w, ctx, http.StatusInternalServerError, "internal error", err)
+		return
+	}
 	w.WriteHeader(http.StatusAccepted)
+	if _, err := w.Write(buf.Bytes()); err != nil {
+		log.Error(ctx, "failed to send response to client", ports.Field{Key: "error", Value: err})
+	}
 }
 
 // PUT /orders/{id}/status
@@ -199,6 +392,10 @@ type UpdateOrderStatusReq struct {
 	Status string `json:"status" validate:"required"`
 }
 
+type UpdateOrderStatusResp struct {
+	CommandID uuid.UUID `json:"command_id"`
+}
+
 func (h *OrderHandler) UpdateOrderStatus(w http.ResponseWriter, r *http.Request) {
 	// Setup
 	ctx := r.Context()
@@ -208,12 +405,18 @@ func (h *OrderHandler) UpdateOrderStatus(w http.ResponseWriter, r *http.Request)
 	// Execution
 	vars := mux.Vars(r)
 	id := vars["id"]
-	_, err := uuid.Parse(id)
+	orderID, err := uuid.Parse(id)
 	if err != nil {
 		log.Error(ctx, "id provided not valid", ports.Field{Key: "error", Value: err})
 		h.failHttp(w, ctx, http.StatusBadRequest, "invalid path", err)
 		return
 	}
+	wait, err := h.parseWait(r)
+	if err != nil {
+		log.Error(ctx, "invalid wait", ports.Field{Key: "error", Value: err})
+		h.failHttp(w, ctx, http.StatusBadRequest, "wait must be a positive duration", err)
+		return
+	}
 	raw, err := io.ReadAll(r.Body)
 	if err != nil {
 		log.Error(ctx, "failed to read request body", ports.Field{Key: "error", Value: err})
@@ -234,17 +437,81 @@ func (h *OrderHandler) UpdateOrderStatus(w http.ResponseWriter, r *http.Request)
 	status, err := core.MapStrToStatus(reqBody.Status)
 	if err != nil {
 		log.Error(ctx, "invalid status", ports.Field{Key: "error", Value: err})
-		h.failHttp(w, ctx, http.StatusBadRequest, "status must be either 'pending', 'confirmed' or 'failed'", err)
+		h.failHttp(w, ctx, http.StatusBadRequest, errMsgInvalidStatus, err)
 		return
 	}
 	cmd := &core.UpdateOrderStatusCmd{
-		ID:     id,
-		Status: *status,
+		CommandID: uuid.New(),
+		ID:        id,
+		Status:    *status,
 	}
 	if err := h.svc.UpdateOrderStatus(ctx, cmd); err != nil {
 		log.Error(ctx, "failed to update order", ports.Field{Key: "error", Value: err})
 		h.failHttp(w, ctx, http.StatusInternalServerError, "internal error", err)
 		return
 	}
+	if wait > 0 && h.respondCompleted(w, r, cmd.CommandID, orderID, wait, http.StatusOK) {
+		return
+	}
+	resp := UpdateOrderStatusResp{
+		CommandID: cmd.CommandID,
+	}
+	buf := new(bytes.Buffer)
+	if err := json.NewEncoder(buf).Encode(resp); err != nil {
+		log.Error(ctx, "failed to encode response body", ports.Field{Key: "error", Value: err})
+		h.failHttp(w, ctx, http.StatusInternalServerError, "internal error", err)
+		return
+	}
 	w.WriteHeader(http.StatusAccepted)
+	if _, err := w.Write(buf.Bytes()); err != nil {
+		log.Error(ctx, "failed to send response to client", ports.Field{Key: "error", Value: err})
+	}
+}
+
+// GET /commands/{id}
+type GetCommandResp struct {
+	Command *core.CommandStatus `json:"command"`
+}
+
+func (h *OrderHandler) GetCommand(w http.ResponseWriter, r *http.Request) {
+	// Setup
+	ctx := r.Context()
+	log := logger.LogFromCtx(ctx, logger.BaseLogger)
+	w.Header().Set("Content-Type", "application/json")
+
+	// Execution
+	vars := mux.Vars(r)
+	id, err := uuid.Parse(vars["id"])
+	if err != nil {
+		log.Error(ctx, "failed to parse id from URL", ports.Field{Key: "error", Value: err})
+		h.failHttp(w, ctx, http.StatusBadRequest, "invalid path", err)
+		return
+	}
+	qry := &core.GetCommandQry{
+		ID: id,
+	}
+	command, err := h.svc.GetCommand(ctx, qry)
+	if errors.Is(err, core.ErrCommandNotFound) {
+		log.Error(ctx, "command not found", ports.Field{Key: "error", Value: err})
+		h.failHttp(w, ctx, http.StatusNotFound, "command not found", err)
+		return
+	}
+	if err != nil {
+		log.Error(ctx, "failed to get command", ports.Field{Key: "error", Value: err})
+		h.failHttp(w, ctx, http.StatusInternalServerError, "internal error", err)
+		return
+	}
+	resp := GetCommandResp{
+		Command: command,
+	}
+	buf := new(bytes.Buffer)
+	if err := json.NewEncoder(buf).Encode(resp); err != nil {
+		log.Error(ctx, "failed to encode response body", ports.Field{Key: "error", Value: err})
+		h.failHttp(w, ctx, http.StatusInternalServerError, "internal error", err)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(buf.Bytes()); err != nil {
+		log.Error(ctx, "failed to send response to client", ports.Field{Key: "error", Value: err})
+	}
 }
@@ -0,0 +1,161 @@
+package orderorchestrator
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/Anacardo89/order_svc_hex/order_api/internal/adapters/infra/log/loki/logger"
+	"github.com/Anacardo89/order_svc_hex/order_api/internal/core"
+	"github.com/Anacardo89/order_svc_hex/order_api/internal/ports"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	HeaderIdempotencyKey      = "Idempotency-Key"
+	HeaderIdempotencyReplayed = "Idempotency-Replayed"
+	maxIdempotencyKeyLen      = 255
+	// Bounds the release or completion after the client may have gone
+	idempotencyStoreTimeout = 5 * time.Second
+)
+
+// Response headers kept for replays
+var replayHeaders = []string{"Content-Type", "Location"}
+
+func (h *OrderHandler) Idempotent(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Setup
+		key := r.Header.Get(HeaderIdempotencyKey)
+		if key == "" || h.idempotency == nil {
+			next(w, r)
+			return
+		}
+		ctx := r.Context()
+		log := logger.LogFromCtx(ctx, logger.BaseLogger)
+		w.Header().Set("Content-Type", "application/json")
+		if len(key) > maxIdempotencyKeyLen {
+			h.failHttp(w, ctx, http.StatusBadRequest, "Idempotency-Key too long", errors.New("idempotency key too long"))
+			return
+		}
+		span := trace.SpanFromContext(ctx)
+		span.SetAttributes(attribute.String("http.idempotency_key", key))
+
+		// Execution
+		raw, err := io.ReadAll(r.Body)
+		if err != nil {
+			log.Error(ctx, "failed to read request body", ports.Field{Key: "error", Value: err})
+			h.failHttp(w, ctx, http.StatusBadRequest, "invalid request body", err)
+			return
+		}
+		r.Body = io.NopCloser(bytes.NewReader(raw))
+		reqHash := hashRequest(r, raw)
+		existing, err := h.idempotency.Reserve(ctx, key, reqHash)
+		if err != nil {
+			log.Error(ctx, "failed to reserve idempotency key", ports.Field{Key: "error", Value: err})
+			h.failHttp(w, ctx, http.StatusInternalServerError, "internal error", err)
+			return
+		}
+		if existing != nil {
+			h.replay(w, r, existing, reqHash)
+			return
+		}
+		rec := newRecorder(w)
+		next(rec, r)
+		// The command may already be out, so a disconnect must not leave the key reserved
+		storeCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), idempotencyStoreTimeout)
+		defer cancel()
+		if rec.status >= http.StatusInternalServerError {
+			if err := h.idempotency.Release(storeCtx, key); err != nil {
+				log.Error(ctx, "failed to release idempotency key", ports.Field{Key: "error", Value: err})
+			}
+			return
+		}
+		headers := make(map[string]string, len(replayHeaders))
+		for _, k := range replayHeaders {
+			if v := rec.Header().Get(k); v != "" {
+				headers[k] = v
+			}
+		}
+		if err := h.idempotency.Complete(storeCtx, &core.IdempotencyRecord{
+			Key:         key,
+			RequestHash: reqHash,
+			StatusCode:  rec.status,
+			Headers:     headers,
+			Body:        rec.body.Bytes(),
+		}); err != nil {
+			log.Error(ctx, "failed to store idempotent response", ports.Field{Key: "error", Value: err})
+		}
+	}
+}
+
+func (h *OrderHandler) replay(w http.ResponseWriter, r *http.Request, existing *core.IdempotencyRecord, reqHash string) {
+	ctx := r.Context()
+	log := logger.LogFromCtx(ctx, logger.BaseLogger)
+	if existing.RequestHash != reqHash {
+		h.failHttp(w, ctx, http.StatusUnprocessableEntity, "Idempotency-Key already used with a different request", errors.New("idempotency key reused with different payload"))
+		return
+	}
+	if !existing.Completed {
+		h.failHttp(w, ctx, http.StatusConflict, "request with this Idempotency-Key is still being processed", errors.New("idempotency key in progress"))
+		return
+	}
+	for k, v := range existing.Headers {
+		w.Header().Set(k, v)
+	}
+	w.Header().Set(HeaderIdempotencyReplayed, "true")
+	w.WriteHeader(existing.StatusCode)
+	if _, err := w.Write(existing.Body); err != nil {
+		log.Error(ctx, "failed to send response to client", ports.Field{Key: "error", Value: err})
+	}
+}
+
+func hashRequest(r *http.Request, raw []byte) string {
+	body := new(bytes.Buffer)
+	if err := json.Compact(body, raw); err != nil {
+		body.Reset()
+		body.Write(raw)
+	}
+	sum := sha256.New()
+	sum.Write([]byte(r.Method + " " + r.URL.Path + "\n"))
+	sum.Write(body.Bytes())
+	return hex.EncodeToString(sum.Sum(nil))
+}
+
+// To capture the response for replays
+type recorder struct {
+	http.ResponseWriter
+	status      int
+	wroteHeader bool
+	body        bytes.Buffer
+}
+
+func newRecorder(w http.ResponseWriter) *recorder {
+	return &recorder{
+		ResponseWriter: w,
+		status:         http.StatusOK,
+	}
+}
+
+func (w *recorder) WriteHeader(status int) {
+	if w.wroteHeader {
+		return
+	}
+	w.status = status
+	w.wroteHeader = true
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *recorder) Write(b []byte) (int, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+	w.body.Write(b)
+	return w.ResponseWriter.Write(b)
+}
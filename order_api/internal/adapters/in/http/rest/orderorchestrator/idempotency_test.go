@@ -0,0 +1,120 @@
+package orderorchestrator
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Anacardo89/order_svc_hex/order_api/internal/adapters/infra/log/loki/logger"
+	"github.com/Anacardo89/order_svc_hex/order_api/internal/adapters/out/store/memory/idempotencystore"
+	"github.com/Anacardo89/order_svc_hex/order_api/internal/core"
+	"github.com/Anacardo89/order_svc_hex/order_api/internal/ports"
+)
+
+type fakeOrchestrator struct {
+	ports.OrderOrchestrator
+	created   int
+	createErr error
+}
+
+func (f *fakeOrchestrator) CreateOrder(ctx context.Context, cmd *core.CreateOrderCmd) error {
+	f.created++
+	return f.createErr
+}
+
+func TestOrderHandler_Idempotent(t *testing.T) {
+	logger.BaseLogger = logger.NopLogger{}
+	const body = `{"items": {"sku_1": 2}}`
+
+	tests := []struct {
+		name         string
+		firstKey     string
+		secondKey    string
+		secondBody   string
+		createErr    error
+		wantStatus   int
+		wantCreated  int
+		wantReplayed bool
+	}{
+		{
+			name:         "same key and body replays first response",
+			firstKey:     "key-1",
+			secondKey:    "key-1",
+			secondBody:   `{"items":{"sku_1":2}}`,
+			wantStatus:   http.StatusAccepted,
+			wantCreated:  1,
+			wantReplayed: true,
+		},
+		{
+			name:        "same key with different body is rejected",
+			firstKey:    "key-1",
+			secondKey:   "key-1",
+			secondBody:  `{"items": {"sku_1": 3}}`,
+			wantStatus:  http.StatusUnprocessableEntity,
+			wantCreated: 1,
+		},
+		{
+			name:        "different keys create twice",
+			firstKey:    "key-1",
+			secondKey:   "key-2",
+			secondBody:  body,
+			wantStatus:  http.StatusAccepted,
+			wantCreated: 2,
+		},
+		{
+			name:        "no key creates twice",
+			secondBody:  body,
+			wantStatus:  http.StatusAccepted,
+			wantCreated: 2,
+		},
+		{
+			name:        "server error releases the key",
+			firstKey:    "key-1",
+			secondKey:   "key-1",
+			secondBody:  body,
+			createErr:   errors.New("kafka down"),
+			wantStatus:  http.StatusInternalServerError,
+			wantCreated: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := idempotencystore.NewStore(time.Hour)
+			defer store.Close()
+			svc := &fakeOrchestrator{createErr: tt.createErr}
+			h := &OrderHandler{svc: svc, idempotency: store}
+			handler := h.Idempotent(h.CreateOrder)
+
+			send := func(key, reqBody string) *httptest.ResponseRecorder {
+				req := httptest.NewRequest(http.MethodPost, "/orders", strings.NewReader(reqBody))
+				if key != "" {
+					req.Header.Set(HeaderIdempotencyKey, key)
+				}
+				rec := httptest.NewRecorder()
+				handler(rec, req)
+				return rec
+			}
+
+			first := send(tt.firstKey, body)
+			second := send(tt.secondKey, tt.secondBody)
+
+			assert.Equal(t, tt.wantStatus, second.Code)
+			assert.Equal(t, tt.wantCreated, svc.created)
+			if tt.wantReplayed {
+				require.Equal(t, "true", second.Header().Get(HeaderIdempotencyReplayed))
+				assert.Equal(t, first.Body.String(), second.Body.String())
+				assert.Equal(t, first.Header().Get("Location"), second.Header().Get("Location"))
+			} else {
+				assert.Empty(t, second.Header().Get(HeaderIdempotencyReplayed))
+			}
+		})
+	}
+}
@@ -0,0 +1,134 @@
+package orderorchestrator
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Anacardo89/order_svc_hex/order_api/internal/core"
+)
+
+// Query params for GET /orders
+const (
+	QueryStatus        = "status"
+	QueryCreatedAfter  = "created_after"
+	QueryCreatedBefore = "created_before"
+	QueryUpdatedSince  = "updated_since"
+	QuerySKU           = "sku"
+	QuerySort          = "sort"
+	QueryLimit         = "limit"
+	QueryCursor        = "cursor"
+)
+
+// Query params for GET /admin/dead-letters and /admin/dead-letters/counts
+const (
+	QueryReason        = "reason"
+	QueryOriginalTopic = "original_topic"
+	QueryError         = "error"
+	QueryFailedAfter   = "failed_after"
+	QueryFailedBefore  = "failed_before"
+)
+
+// Repeated params and comma separated lists are both accepted
+func queryList(values url.Values, key string) []string {
+	var out []string
+	for _, v := range values[key] {
+		for _, part := range strings.Split(v, ",") {
+			if part = strings.TrimSpace(part); part != "" {
+				out = append(out, part)
+			}
+		}
+	}
+	return out
+}
+
+func queryTime(values url.Values, key string) (*time.Time, error) {
+	raw := values.Get(key)
+	if raw == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, raw)
+	if err != nil {
+		return nil, fmt.Errorf("%s must be an RFC 3339 timestamp", key)
+	}
+	return &t, nil
+}
+
+// Errors are safe to send back to the client
+func parseListOrdersQry(values url.Values) (*core.ListOrdersQry, error) {
+	qry := &core.ListOrdersQry{
+		Cursor: values.Get(QueryCursor),
+	}
+	for _, s := range queryList(values, QueryStatus) {
+		status, err := core.MapStrToStatus(s)
+		if err != nil {
+			return nil, errors.New(errMsgInvalidStatus)
+		}
+		qry.Filter.Statuses = append(qry.Filter.Statuses, *status)
+	}
+	var err error
+	if qry.Filter.CreatedAfter, err = queryTime(values, QueryCreatedAfter); err != nil {
+		return nil, err
+	}
+	if qry.Filter.CreatedBefore, err = queryTime(values, QueryCreatedBefore); err != nil {
+		return nil, err
+	}
+	if qry.Filter.UpdatedSince, err = queryTime(values, QueryUpdatedSince); err != nil {
+		return nil, err
+	}
+	qry.Filter.SKUs = queryList(values, QuerySKU)
+	if qry.Sort, err = core.MapStrToSort(values.Get(QuerySort)); err != nil {
+		return nil, errors.New("sort must be one of 'created_at', '-created_at', 'updated_at' or '-updated_at'")
+	}
+	if limitStr := values.Get(QueryLimit); limitStr != "" {
+		qry.Limit, err = strconv.Atoi(limitStr)
+		if err != nil || qry.Limit <= 0 {
+			return nil, errors.New("limit must be a positive integer")
+		}
+	}
+	return qry, nil
+}
+
+func parseDeadLetterFilter(values url.Values) (core.DeadLetterFilter, error) {
+	filter := core.DeadLetterFilter{
+		Reasons:        queryList(values, QueryReason),
+		OriginalTopics: queryList(values, QueryOriginalTopic),
+		ErrorContains:  values.Get(QueryError),
+	}
+	for _, s := range queryList(values, QueryStatus) {
+		status, err := core.MapStrToDeadLetterStatus(s)
+		if err != nil {
+			return core.DeadLetterFilter{}, errors.New(errMsgInvalidDeadLetterStatus)
+		}
+		filter.Statuses = append(filter.Statuses, status)
+	}
+	var err error
+	if filter.FailedAfter, err = queryTime(values, QueryFailedAfter); err != nil {
+		return core.DeadLetterFilter{}, err
+	}
+	if filter.FailedBefore, err = queryTime(values, QueryFailedBefore); err != nil {
+		return core.DeadLetterFilter{}, err
+	}
+	return filter, nil
+}
+
+func parseListDeadLettersQry(values url.Values) (*core.ListDeadLettersQry, error) {
+	filter, err := parseDeadLetterFilter(values)
+	if err != nil {
+		return nil, err
+	}
+	qry := &core.ListDeadLettersQry{
+		Filter: filter,
+		Cursor: values.Get(QueryCursor),
+	}
+	if limitStr := values.Get(QueryLimit); limitStr != "" {
+		qry.Limit, err = strconv.Atoi(limitStr)
+		if err != nil || qry.Limit <= 0 {
+			return nil, errors.New("limit must be a positive integer")
+		}
+	}
+	return qry, nil
+}
@@ -0,0 +1,87 @@
+package orderorchestrator
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Anacardo89/order_svc_hex/order_api/internal/core"
+	"github.com/Anacardo89/order_svc_hex/order_api/pkg/ptr"
+)
+
+func TestParseListOrdersQry(t *testing.T) {
+	tests := []struct {
+		name        string
+		query       string
+		expected    *core.ListOrdersQry
+		expectError bool
+	}{
+		{
+			name:     "no filters",
+			query:    "",
+			expected: &core.ListOrdersQry{Sort: core.SortCreatedAt},
+		},
+		{
+			name:  "repeated and comma separated statuses",
+			query: "status=pending,confirmed&status=shipped",
+			expected: &core.ListOrdersQry{
+				Filter: core.OrderFilter{
+					Statuses: []core.Status{core.StatusPending, core.StatusConfirmed, core.StatusShipped},
+				},
+				Sort: core.SortCreatedAt,
+			},
+		},
+		{
+			name:  "all filters",
+			query: "created_after=2026-01-01T00:00:00Z&created_before=2026-02-01T00:00:00Z&updated_since=2026-01-15T00:00:00Z&sku=sku_1&sku=sku_2&sort=-updated_at&limit=20&cursor=abc",
+			expected: &core.ListOrdersQry{
+				Filter: core.OrderFilter{
+					CreatedAfter:  ptr.Ptr(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)),
+					CreatedBefore: ptr.Ptr(time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)),
+					UpdatedSince:  ptr.Ptr(time.Date(2026, 1, 15, 0, 0, 0, 0, time.UTC)),
+					SKUs:          []string{"sku_1", "sku_2"},
+				},
+				Sort:   core.SortUpdatedAtDesc,
+				Limit:  20,
+				Cursor: "abc",
+			},
+		},
+		{
+			name:        "unknown status",
+			query:       "status=archived",
+			expectError: true,
+		},
+		{
+			name:        "bad timestamp",
+			query:       "created_after=yesterday",
+			expectError: true,
+		},
+		{
+			name:        "unknown sort",
+			query:       "sort=id",
+			expectError: true,
+		},
+		{
+			name:        "non positive limit",
+			query:       "limit=0",
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			values, err := url.ParseQuery(tt.query)
+			require.NoError(t, err)
+			qry, err := parseListOrdersQry(values)
+			if tt.expectError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, qry)
+		})
+	}
+}
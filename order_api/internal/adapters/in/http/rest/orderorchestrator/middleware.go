@@ -2,7 +2,10 @@ package orderorchestrator
 
 import (
 	"context"
+	"crypto/subtle"
+	"errors"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/Anacardo89/order_svc_hex/order_api/internal/adapters/infra/log/loki/logger"
@@ -44,6 +47,22 @@ func Log(baseLogger ports.Logger) func(http.Handler) http.Handler {
 	}
 }
 
+// Expects "Authorization: Bearer <token>", an empty token lets nothing through
+func AdminAuth(token string) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			got, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+			if !ok || token == "" || subtle.ConstantTimeCompare([]byte(got), []byte(token)) != 1 {
+				w.Header().Set("Content-Type", "application/json")
+				w.Header().Set("WWW-Authenticate", "Bearer")
+				failHttp(w, r.Context(), http.StatusUnauthorized, "unauthorized", errors.New("missing or invalid admin token"))
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
 func Metrics(metrics *ReqMetrics) mux.MiddlewareFunc {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -99,3 +118,8 @@ func (w *RWInterceptor) Write(b []byte) (int, error) {
 	}
 	return w.ResponseWriter.Write(b)
 }
+
+// Lets http.ResponseController reach Flush and deadlines on the wrapped writer
+func (w *RWInterceptor) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
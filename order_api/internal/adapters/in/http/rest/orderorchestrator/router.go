@@ -17,10 +17,34 @@ func NewRouter(h *OrderHandler, metrics *ReqMetrics) http.Handler {
 	// Health check
 	r.Handle("/", http.HandlerFunc(HealthCheck)).Methods("GET")
 	// Orders
-	r.Handle("/orders", http.HandlerFunc(h.CreateOrder)).Methods("POST")
-	r.Handle("/orders", http.HandlerFunc(h.ListOrdersByStatus)).Methods("GET")
+	r.Handle("/orders", h.Idempotent(h.CreateOrder)).Methods("POST")
+	r.Handle("/orders", http.HandlerFunc(h.ListOrders)).Methods("GET")
+	r.Handle("/orders:batchGet", http.HandlerFunc(h.BatchGetOrders)).Methods("POST")
 	r.Handle("/orders/{id}", http.HandlerFunc(h.GetOrder)).Methods("GET")
+	r.Handle("/orders/{id}/events", http.HandlerFunc(h.OrderEvents)).Methods("GET")
 	r.Handle("/orders/{id}/status", http.HandlerFunc(h.UpdateOrderStatus)).Methods("PUT")
+	// Commands
+	r.Handle("/commands/{id}", http.HandlerFunc(h.GetCommand)).Methods("GET")
+	// Catch-all 404
+	r.NotFoundHandler = http.HandlerFunc(CatchAll)
+	return r
+}
+
+// Served on the admin listener only, never next to the public routes
+func NewAdminRouter(dlq *DeadLetterHandler, token string, metrics *ReqMetrics) http.Handler {
+	r := mux.NewRouter()
+	r.Use(otelmux.Middleware("order_api.admin"))
+	r.Use(Metrics(metrics))
+	r.Use(ReqID)
+	r.Use(Log(logger.BaseLogger))
+	r.Use(AdminAuth(token))
+	// Dead letters
+	r.Handle("/admin/dead-letters", http.HandlerFunc(dlq.ListDeadLetters)).Methods("GET")
+	r.Handle("/admin/dead-letters:purge", http.HandlerFunc(dlq.PurgeDeadLetters)).Methods("POST")
+	r.Handle("/admin/dead-letters/counts", http.HandlerFunc(dlq.CountDeadLetters)).Methods("GET")
+	r.Handle("/admin/dead-letters/{id:[0-9]+}", http.HandlerFunc(dlq.GetDeadLetter)).Methods("GET")
+	r.Handle("/admin/dead-letters/{id:[0-9]+}:resolve", http.HandlerFunc(dlq.ResolveDeadLetter)).Methods("POST")
+	r.Handle("/admin/dead-letters/{id:[0-9]+}:replay", http.HandlerFunc(dlq.ReplayDeadLetter)).Methods("POST")
 	// Catch-all 404
 	r.NotFoundHandler = http.HandlerFunc(CatchAll)
 	return r
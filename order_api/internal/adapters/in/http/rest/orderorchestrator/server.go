@@ -29,6 +29,25 @@ func NewServer(cfg *config.Server, handler *OrderHandler, metrics *ReqMetrics) *
 		WriteTimeout:      cfg.WriteTimeout,
 		IdleTimeout:       cfg.IdleTimeout,
 	}
+	// Shutdown doesn't cancel request contexts, event streams would hold it up
+	s.httpSrv.RegisterOnShutdown(handler.Close)
+	return s
+}
+
+// Same timeouts as the public server, on the admin port
+func NewAdminServer(cfg *config.Server, adminCfg *config.Admin, dlqHandler *DeadLetterHandler, metrics *ReqMetrics) *Server {
+	s := &Server{
+		router: NewAdminRouter(dlqHandler, adminCfg.Token, metrics),
+		addr:   fmt.Sprintf(":%s", adminCfg.Port),
+	}
+	s.httpSrv = &http.Server{
+		Addr:              s.addr,
+		Handler:           s.router,
+		ReadTimeout:       cfg.ReadTimeout,
+		ReadHeaderTimeout: cfg.ReadHeaderTimeout,
+		WriteTimeout:      cfg.WriteTimeout,
+		IdleTimeout:       cfg.IdleTimeout,
+	}
 	return s
 }
 
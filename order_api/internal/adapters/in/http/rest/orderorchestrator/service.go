@@ -2,20 +2,26 @@ package orderorchestrator
 
 import (
 	"context"
+	"time"
+
+	"github.com/google/uuid"
 
 	"github.com/Anacardo89/order_svc_hex/order_api/internal/core"
 	"github.com/Anacardo89/order_svc_hex/order_api/internal/ports"
+	"github.com/Anacardo89/order_svc_hex/order_api/pkg/ptr"
 )
 
 type OrderService struct {
-	reader ports.OrderReader
-	writer ports.OrderWriter
+	reader   ports.OrderReader
+	writer   ports.OrderWriter
+	commands ports.CommandTracker
 }
 
-func NewOrderService(reader ports.OrderReader, writer ports.OrderWriter) *OrderService {
+func NewOrderService(reader ports.OrderReader, writer ports.OrderWriter, commands ports.CommandTracker) *OrderService {
 	return &OrderService{
-		reader: reader,
-		writer: writer,
+		reader:   reader,
+		writer:   writer,
+		commands: commands,
 	}
 }
 
@@ -23,14 +29,58 @@ func (s *OrderService) GetOrder(ctx context.Context, qry *core.GetOrderQry) (*co
 	return s.reader.GetByID(ctx, qry)
 }
 
-func (s *OrderService) ListOrdersByStatus(ctx context.Context, qry *core.ListOrdersByStatusQry) ([]*core.Order, error) {
-	return s.reader.ListByStatus(ctx, qry)
+func (s *OrderService) GetOrdersByIDs(ctx context.Context, qry *core.GetOrdersByIDsQry) (*core.OrderBatch, error) {
+	return s.reader.GetByIDs(ctx, qry)
+}
+
+func (s *OrderService) ListOrders(ctx context.Context, qry *core.ListOrdersQry) (*core.OrderPage, error) {
+	return s.reader.List(ctx, qry)
+}
+
+func (s *OrderService) StreamOrders(ctx context.Context, qry *core.ListOrdersQry, send func(*core.Order) error) (string, error) {
+	return s.reader.Stream(ctx, qry, send)
+}
+
+func (s *OrderService) WatchOrder(ctx context.Context, qry *core.WatchOrderQry, send func(*core.OrderChange) error) error {
+	return s.reader.Watch(ctx, qry, send)
 }
 
 func (s *OrderService) CreateOrder(ctx context.Context, cmd *core.CreateOrderCmd) error {
+	if err := s.track(ctx, cmd.CommandID, core.CommandCreateOrder, cmd.ID); err != nil {
+		return err
+	}
 	return s.writer.PublishCreate(ctx, cmd)
 }
 
 func (s *OrderService) UpdateOrderStatus(ctx context.Context, cmd *core.UpdateOrderStatusCmd) error {
+	orderID, err := uuid.Parse(cmd.ID)
+	if err != nil {
+		return err
+	}
+	if err := s.track(ctx, cmd.CommandID, core.CommandUpdateOrderStatus, orderID); err != nil {
+		return err
+	}
 	return s.writer.PublishStatusUpdate(ctx, cmd)
 }
+
+func (s *OrderService) GetCommand(ctx context.Context, qry *core.GetCommandQry) (*core.CommandStatus, error) {
+	return s.commands.Get(ctx, qry)
+}
+
+// A still pending status means the timeout ran out first
+func (s *OrderService) WaitCommand(ctx context.Context, qry *core.WaitCommandQry) (*core.CommandStatus, error) {
+	ctx, cancel := context.WithTimeout(ctx, qry.Timeout)
+	defer cancel()
+	return s.commands.Wait(ctx, &core.GetCommandQry{ID: qry.ID})
+}
+
+// Registered before publishing so a fast result always finds it
+func (s *OrderService) track(ctx context.Context, cmdID uuid.UUID, cmdType core.CommandType, orderID uuid.UUID) error {
+	return s.commands.Track(ctx, &core.CommandStatus{
+		ID:         cmdID,
+		Type:       cmdType,
+		OrderID:    orderID,
+		State:      core.CommandPending,
+		AcceptedAt: ptr.Ptr(time.Now().UTC()),
+	})
+}
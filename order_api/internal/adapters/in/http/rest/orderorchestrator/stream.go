@@ -0,0 +1,144 @@
+package orderorchestrator
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Anacardo89/order_svc_hex/order_api/internal/core"
+)
+
+const (
+	MediaTypeNDJSON      = "application/x-ndjson"
+	MediaTypeEventStream = "text/event-stream"
+)
+
+// SSE event names
+const (
+	eventOrder    = "order"
+	eventNextPage = "next_page"
+	eventError    = "error"
+	eventDone     = "done"
+)
+
+type NextPageResp struct {
+	NextPageToken string `json:"next_page_token"`
+}
+
+// Streaming media type asked for in Accept, empty means a regular JSON response
+func streamMediaType(r *http.Request) string {
+	for _, part := range strings.Split(r.Header.Get("Accept"), ",") {
+		mediaType, _, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+		switch mediaType {
+		case MediaTypeNDJSON, MediaTypeEventStream:
+			return mediaType
+		}
+	}
+	return ""
+}
+
+// Writes one message per order and flushes each, headers go out with the first message
+type orderStreamWriter struct {
+	w         http.ResponseWriter
+	rc        *http.ResponseController
+	mediaType string
+	started   bool
+}
+
+func newOrderStreamWriter(w http.ResponseWriter, mediaType string) *orderStreamWriter {
+	return &orderStreamWriter{
+		w:         w,
+		rc:        http.NewResponseController(w),
+		mediaType: mediaType,
+	}
+}
+
+func (s *orderStreamWriter) start() {
+	s.started = true
+	// Streams outlive the server write timeout
+	_ = s.rc.SetWriteDeadline(time.Time{})
+	s.w.Header().Set("Content-Type", s.mediaType)
+	s.w.Header().Set("Cache-Control", "no-cache")
+	if s.mediaType == MediaTypeEventStream {
+		s.w.Header().Set("X-Accel-Buffering", "no")
+	}
+	s.w.WriteHeader(http.StatusOK)
+}
+
+func (s *orderStreamWriter) write(event string, v any) error {
+	return s.writeEvent("", event, v)
+}
+
+// id is only sent on SSE, where clients echo it back in Last-Event-ID
+func (s *orderStreamWriter) writeEvent(id, event string, v any) error {
+	if !s.started {
+		s.start()
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	if s.mediaType == MediaTypeEventStream {
+		if id != "" {
+			if _, err := fmt.Fprintf(s.w, "id: %s\n", id); err != nil {
+				return err
+			}
+		}
+		_, err = fmt.Fprintf(s.w, "event: %s\ndata: %s\n\n", event, data)
+	} else {
+		_, err = s.w.Write(append(data, '\n'))
+	}
+	if err != nil {
+		return err
+	}
+	return s.flush()
+}
+
+// Raw SSE lines that carry no event, like comments and the retry hint
+func (s *orderStreamWriter) writeRaw(line string) error {
+	if !s.started {
+		s.start()
+	}
+	if _, err := fmt.Fprintf(s.w, "%s\n\n", line); err != nil {
+		return err
+	}
+	return s.flush()
+}
+
+func (s *orderStreamWriter) flush() error {
+	if err := s.rc.Flush(); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		return err
+	}
+	return nil
+}
+
+func (s *orderStreamWriter) Order(o *core.Order) error {
+	return s.write(eventOrder, o)
+}
+
+func (s *orderStreamWriter) NextPage(token string) error {
+	return s.write(eventNextPage, NextPageResp{NextPageToken: token})
+}
+
+// The status line is already sent, so failures are reported in-band
+func (s *orderStreamWriter) Fail(outMsg string) error {
+	return s.write(eventError, ErrorResp{Error: outMsg})
+}
+
+// NDJSON ends with the body, SSE clients get an explicit end marker
+func (s *orderStreamWriter) Done() error {
+	if s.mediaType != MediaTypeEventStream {
+		if !s.started {
+			s.start()
+		}
+		return nil
+	}
+	return s.write(eventDone, struct{}{})
+}
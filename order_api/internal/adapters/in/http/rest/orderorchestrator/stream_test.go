@@ -0,0 +1,114 @@
+package orderorchestrator
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Anacardo89/order_svc_hex/order_api/internal/adapters/infra/log/loki/logger"
+	"github.com/Anacardo89/order_svc_hex/order_api/internal/core"
+	"github.com/Anacardo89/order_svc_hex/order_api/internal/ports"
+)
+
+type streamReader struct {
+	ports.OrderReader
+	orders []*core.Order
+	next   string
+	err    error
+}
+
+func (f *streamReader) Stream(ctx context.Context, qry *core.ListOrdersQry, send func(*core.Order) error) (string, error) {
+	for _, o := range f.orders {
+		if err := send(o); err != nil {
+			return "", err
+		}
+	}
+	return f.next, f.err
+}
+
+func TestOrderHandler_ListOrdersStream(t *testing.T) {
+	logger.BaseLogger = logger.NopLogger{}
+	orders := []*core.Order{
+		{ID: uuid.MustParse("11111111-1111-1111-1111-111111111111"), Status: core.StatusPending},
+		{ID: uuid.MustParse("22222222-2222-2222-2222-222222222222"), Status: core.StatusConfirmed},
+	}
+
+	tests := []struct {
+		name            string
+		accept          string
+		reader          *streamReader
+		wantStatus      int
+		wantContentType string
+		wantLines       []string
+	}{
+		{
+			name:            "ndjson with next page",
+			accept:          MediaTypeNDJSON,
+			reader:          &streamReader{orders: orders, next: "tok"},
+			wantStatus:      http.StatusOK,
+			wantContentType: MediaTypeNDJSON,
+			wantLines: []string{
+				`{"id":"11111111-1111-1111-1111-111111111111"`,
+				`{"id":"22222222-2222-2222-2222-222222222222"`,
+				`{"next_page_token":"tok"}`,
+			},
+		},
+		{
+			name:            "event stream ends with done",
+			accept:          "text/event-stream, */*;q=0.1",
+			reader:          &streamReader{orders: orders[:1]},
+			wantStatus:      http.StatusOK,
+			wantContentType: MediaTypeEventStream,
+			wantLines: []string{
+				"event: order",
+				`data: {"id":"11111111-1111-1111-1111-111111111111"`,
+				"event: done",
+			},
+		},
+		{
+			name:            "error before first order keeps the status code",
+			accept:          MediaTypeNDJSON,
+			reader:          &streamReader{err: core.ErrInvalidCursor},
+			wantStatus:      http.StatusBadRequest,
+			wantContentType: "application/json",
+		},
+		{
+			name:            "error mid stream is reported in band",
+			accept:          MediaTypeNDJSON,
+			reader:          &streamReader{orders: orders[:1], err: context.DeadlineExceeded},
+			wantStatus:      http.StatusOK,
+			wantContentType: MediaTypeNDJSON,
+			wantLines: []string{
+				`{"id":"11111111-1111-1111-1111-111111111111"`,
+				`{"error":"internal error"}`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewOrderHandler(tt.reader, nil, nil, nil, 0, 0, 0)
+			req := httptest.NewRequest(http.MethodGet, "/orders", nil)
+			req.Header.Set("Accept", tt.accept)
+			rec := httptest.NewRecorder()
+			h.ListOrders(rec, req)
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			assert.Equal(t, tt.wantContentType, rec.Header().Get("Content-Type"))
+			body := rec.Body.String()
+			last := 0
+			for _, line := range tt.wantLines {
+				idx := strings.Index(body[last:], line)
+				if assert.GreaterOrEqual(t, idx, 0, "missing %q in order", line) {
+					last += idx + len(line)
+				}
+			}
+			assert.True(t, rec.Flushed || len(tt.wantLines) == 0)
+		})
+	}
+}
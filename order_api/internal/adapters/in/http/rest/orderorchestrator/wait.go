@@ -0,0 +1,99 @@
+package orderorchestrator
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/Anacardo89/order_svc_hex/order_api/internal/adapters/infra/log/loki/logger"
+	"github.com/Anacardo89/order_svc_hex/order_api/internal/core"
+	"github.com/Anacardo89/order_svc_hex/order_api/internal/ports"
+)
+
+const QueryWait = "wait"
+
+// Used when max_wait is left unset
+const defaultMaxWait = 30 * time.Second
+
+// Completed response for ?wait= requests
+type CommandCompletedResp struct {
+	CommandID uuid.UUID   `json:"command_id"`
+	Order     *core.Order `json:"order"`
+}
+
+// Returns 0 when the caller doesn't want to wait, capped at maxWait
+func (h *OrderHandler) parseWait(r *http.Request) (time.Duration, error) {
+	raw := r.URL.Query().Get(QueryWait)
+	if raw == "" {
+		return 0, nil
+	}
+	wait, err := time.ParseDuration(raw)
+	if err != nil {
+		return 0, fmt.Errorf("invalid wait: %w", err)
+	}
+	if wait <= 0 {
+		return 0, errors.New("wait must be positive")
+	}
+	return min(wait, h.maxWait), nil
+}
+
+// Writes the final response when order_svc answered in time, false means fall back to 202
+func (h *OrderHandler) respondCompleted(w http.ResponseWriter, r *http.Request, cmdID, orderID uuid.UUID, wait time.Duration, okStatus int) bool {
+	// Setup
+	ctx := r.Context()
+	log := logger.LogFromCtx(ctx, logger.BaseLogger)
+
+	// Execution
+	qry := &core.WaitCommandQry{
+		ID:      cmdID,
+		Timeout: wait,
+	}
+	command, err := h.svc.WaitCommand(ctx, qry)
+	if err != nil {
+		log.Warn(ctx, "failed to wait for command", ports.Field{Key: "error", Value: err})
+		return false
+	}
+	switch command.State {
+	case core.CommandRejected:
+		status := http.StatusUnprocessableEntity
+		switch command.Reason {
+		case reasonInvalidTransition:
+			status = http.StatusConflict
+		case reasonOrderNotFound:
+			status = http.StatusNotFound
+		case reasonHandlerError:
+			// order_svc failed to apply it, nothing wrong with the request
+			status = http.StatusBadGateway
+		}
+		h.failHttp(w, ctx, status, "command rejected: "+command.Error, errors.New(command.Error))
+		return true
+	case core.CommandApplied:
+		order, err := h.svc.GetOrder(ctx, &core.GetOrderQry{ID: orderID})
+		if err != nil {
+			log.Warn(ctx, "failed to get order after command applied", ports.Field{Key: "error", Value: err})
+			return false
+		}
+		resp := CommandCompletedResp{
+			CommandID: cmdID,
+			Order:     order,
+		}
+		buf := new(bytes.Buffer)
+		if err := json.NewEncoder(buf).Encode(resp); err != nil {
+			log.Error(ctx, "failed to encode response body", ports.Field{Key: "error", Value: err})
+			return false
+		}
+		w.WriteHeader(okStatus)
+		if _, err := w.Write(buf.Bytes()); err != nil {
+			log.Error(ctx, "failed to send response to client", ports.Field{Key: "error", Value: err})
+		}
+		return true
+	default:
+		log.Info(ctx, "wait timed out, falling back to accepted", ports.Field{Key: "command_id", Value: cmdID})
+		return false
+	}
+}
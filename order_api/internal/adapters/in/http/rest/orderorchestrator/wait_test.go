@@ -0,0 +1,124 @@
+package orderorchestrator
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Anacardo89/order_svc_hex/order_api/internal/adapters/infra/log/loki/logger"
+	"github.com/Anacardo89/order_svc_hex/order_api/internal/adapters/out/store/memory/commandstore"
+	"github.com/Anacardo89/order_svc_hex/order_api/internal/core"
+	"github.com/Anacardo89/order_svc_hex/order_api/internal/ports"
+)
+
+type fakeReader struct {
+	ports.OrderReader
+}
+
+func (f *fakeReader) GetByID(ctx context.Context, qry *core.GetOrderQry) (*core.Order, error) {
+	return &core.Order{ID: qry.ID, Status: core.StatusPending}, nil
+}
+
+// Answers every published command the way order_svc would
+type fakeWriter struct {
+	commands ports.CommandTracker
+	reply    *core.CommandResult
+}
+
+func (f *fakeWriter) PublishCreate(ctx context.Context, cmd *core.CreateOrderCmd) error {
+	if f.reply != nil {
+		reply := *f.reply
+		reply.CommandID = cmd.CommandID
+		reply.OrderID = cmd.ID
+		go f.commands.Resolve(context.Background(), &reply)
+	}
+	return nil
+}
+
+func (f *fakeWriter) PublishStatusUpdate(ctx context.Context, cmd *core.UpdateOrderStatusCmd) error {
+	return nil
+}
+
+func TestOrderHandler_CreateOrderWait(t *testing.T) {
+	logger.BaseLogger = logger.NopLogger{}
+
+	tests := []struct {
+		name       string
+		query      string
+		reply      *core.CommandResult
+		wantStatus int
+		wantOrder  bool
+	}{
+		{
+			name:       "applied in time returns the stored order",
+			query:      "?wait=1s",
+			reply:      &core.CommandResult{State: core.CommandApplied},
+			wantStatus: http.StatusCreated,
+			wantOrder:  true,
+		},
+		{
+			name:       "rejected in time returns the reason",
+			query:      "?wait=1s",
+			reply:      &core.CommandResult{State: core.CommandRejected, Reason: "unmarshal_failed", Error: "bad payload"},
+			wantStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:       "failed to apply in time is a bad gateway",
+			query:      "?wait=1s",
+			reply:      &core.CommandResult{State: core.CommandRejected, Reason: "handler_error", Error: "boom"},
+			wantStatus: http.StatusBadGateway,
+		},
+		{
+			name:       "timeout falls back to accepted",
+			query:      "?wait=10ms",
+			wantStatus: http.StatusAccepted,
+		},
+		{
+			name:       "no wait is accepted right away",
+			reply:      &core.CommandResult{State: core.CommandApplied},
+			wantStatus: http.StatusAccepted,
+		},
+		{
+			name:       "invalid wait is rejected",
+			query:      "?wait=soon",
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := commandstore.NewStore(time.Hour)
+			defer store.Close()
+			writer := &fakeWriter{commands: store, reply: tt.reply}
+			h := NewOrderHandler(&fakeReader{}, writer, store, nil, 5*time.Second, 0, 0)
+
+			req := httptest.NewRequest(http.MethodPost, "/orders"+tt.query, strings.NewReader(`{"items": {"sku_1": 2}}`))
+			rec := httptest.NewRecorder()
+			h.CreateOrder(rec, req)
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			if tt.wantOrder {
+				var resp CommandCompletedResp
+				require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+				require.NotNil(t, resp.Order)
+				assert.Equal(t, "/orders/"+resp.Order.ID.String(), rec.Header().Get("Location"))
+			}
+		})
+	}
+}
+
+func TestParseWait_DefaultsMaxWait(t *testing.T) {
+	h := NewOrderHandler(&fakeReader{}, nil, nil, nil, 0, 0, 0)
+
+	req := httptest.NewRequest(http.MethodPost, "/orders?wait=1m", nil)
+	wait, err := h.parseWait(req)
+	require.NoError(t, err)
+	assert.Equal(t, defaultMaxWait, wait)
+}
@@ -0,0 +1,30 @@
+package commandresult
+
+import (
+	"github.com/Anacardo89/order_svc_hex/order_api/internal/ports"
+	"github.com/Anacardo89/order_svc_hex/order_api/pkg/events"
+	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+)
+
+type CommandResultClient struct {
+	consumer *kafka.Consumer
+	tracker  ports.CommandTracker
+	topic    string
+}
+
+func NewCommandResultClient(kc *events.KafkaConnection, groupID, topic string, tracker ports.CommandTracker) (*CommandResultClient, error) {
+	// Only results of commands sent from now on are waited for
+	c, err := kc.MakeTailConsumer(groupID, []string{topic})
+	if err != nil {
+		return nil, err
+	}
+	return &CommandResultClient{
+		consumer: c,
+		tracker:  tracker,
+		topic:    topic,
+	}, nil
+}
+
+func (c *CommandResultClient) Close() {
+	c.consumer.Close()
+}
@@ -0,0 +1,54 @@
+package commandresult
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/Anacardo89/order_svc_hex/order_api/internal/core"
+	"github.com/google/uuid"
+)
+
+type CommandResultEvent struct {
+	CommandID   string    `json:"command_id"`
+	OrderID     string    `json:"order_id,omitempty"`
+	Outcome     string    `json:"outcome"`
+	Reason      string    `json:"reason,omitempty"`
+	Error       string    `json:"error,omitempty"`
+	ProcessedAt time.Time `json:"processed_at"`
+}
+
+func mapEventPayloadToResult(value []byte) (*core.CommandResult, error) {
+	var e CommandResultEvent
+	if err := json.Unmarshal(value, &e); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal CommandResult: %w", err)
+	}
+	cmdID, err := uuid.Parse(e.CommandID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid command UUID in CommandResult: %w", err)
+	}
+	var orderID uuid.UUID
+	if e.OrderID != "" {
+		orderID, err = uuid.Parse(e.OrderID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid order UUID in CommandResult: %w", err)
+		}
+	}
+	var state core.CommandState
+	switch e.Outcome {
+	case string(core.CommandApplied):
+		state = core.CommandApplied
+	case string(core.CommandRejected):
+		state = core.CommandRejected
+	default:
+		return nil, fmt.Errorf("unknown outcome in CommandResult: %s", e.Outcome)
+	}
+	return &core.CommandResult{
+		CommandID:   cmdID,
+		OrderID:     orderID,
+		State:       state,
+		Reason:      e.Reason,
+		Error:       e.Error,
+		ProcessedAt: e.ProcessedAt,
+	}, nil
+}
@@ -0,0 +1,70 @@
+package commandresult
+
+import (
+	"context"
+
+	"github.com/Anacardo89/order_svc_hex/order_api/internal/adapters/infra/log/loki/logger"
+	"github.com/Anacardo89/order_svc_hex/order_api/internal/ports"
+	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func (c *CommandResultClient) Consume(ctx context.Context) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+			msg, err := c.consumer.ReadMessage(-1)
+			if err != nil {
+				logger.BaseLogger.Error(ctx, "failed to read message", ports.Field{Key: "error", Value: err})
+				continue
+			}
+			c.handleMessage(msg)
+		}
+	}
+}
+
+func (c *CommandResultClient) handleMessage(msg *kafka.Message) {
+	// Observability
+	msgCtx := extractContextFromKafka(msg)
+	tracer := otel.Tracer("order_api.kafka")
+	msgCtx, span := tracer.Start(msgCtx, "kafka.consume",
+		trace.WithSpanKind(trace.SpanKindConsumer),
+		trace.WithAttributes(
+			attribute.String("messaging.system", "kafka"),
+			attribute.String("messaging.operation", "consume"),
+			attribute.String("messaging.source", c.topic),
+		),
+	)
+	log := logger.BaseLogger
+	defer span.End()
+
+	// Execution
+	result, err := mapEventPayloadToResult(msg.Value)
+	if err != nil {
+		log.Error(msgCtx, "failed to unmarshal payload", ports.Field{Key: "error", Value: err})
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "unmarshal_failed")
+		return
+	}
+	span.SetAttributes(attribute.String("command.id", result.CommandID.String()))
+	if err := c.tracker.Resolve(msgCtx, result); err != nil {
+		log.Error(msgCtx, "failed to resolve command", ports.Field{Key: "error", Value: err})
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "resolve_failed")
+	}
+}
+
+func extractContextFromKafka(msg *kafka.Message) context.Context {
+	propagator := otel.GetTextMapPropagator()
+	carrier := propagation.MapCarrier{}
+	for _, h := range msg.Headers {
+		carrier[h.Key] = string(h.Value)
+	}
+	return propagator.Extract(context.Background(), carrier)
+}
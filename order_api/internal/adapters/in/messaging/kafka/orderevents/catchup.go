@@ -0,0 +1,57 @@
+package orderevents
+
+import (
+	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+)
+
+// Stands for a partition whose end offset could not be read yet
+const unknownEnd = kafka.OffsetInvalid
+
+// Offsets each assigned partition has to be read up to before the read model is complete.
+// Only used from the polling goroutine
+type catchUp struct {
+	assigned bool
+	pending  map[int32]kafka.Offset
+}
+
+func newCatchUp() *catchUp {
+	return &catchUp{
+		pending: make(map[int32]kafka.Offset),
+	}
+}
+
+// Partitions left out of ends had nothing in them when assigned
+func (c *catchUp) assign(ends map[int32]kafka.Offset) {
+	c.assigned = true
+	for partition, end := range ends {
+		c.pending[partition] = end
+	}
+}
+
+func (c *catchUp) revoke(partitions []int32) {
+	for _, partition := range partitions {
+		delete(c.pending, partition)
+	}
+}
+
+func (c *catchUp) setEnd(partition int32, end kafka.Offset) {
+	if _, ok := c.pending[partition]; ok {
+		c.pending[partition] = end
+	}
+}
+
+func (c *catchUp) unknown(partition int32) bool {
+	end, ok := c.pending[partition]
+	return ok && end == unknownEnd
+}
+
+func (c *catchUp) consumed(partition int32, offset kafka.Offset) {
+	end, ok := c.pending[partition]
+	if ok && end != unknownEnd && offset+1 >= end {
+		delete(c.pending, partition)
+	}
+}
+
+func (c *catchUp) done() bool {
+	return c.assigned && len(c.pending) == 0
+}
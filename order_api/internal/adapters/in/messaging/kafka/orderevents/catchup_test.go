@@ -0,0 +1,32 @@
+package orderevents
+
+import (
+	"testing"
+
+	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCatchUp(t *testing.T) {
+	c := newCatchUp()
+	// Nothing is known before the first assignment
+	assert.False(t, c.done())
+
+	c.assign(map[int32]kafka.Offset{0: 3, 1: unknownEnd})
+	c.consumed(0, 1)
+	assert.False(t, c.done())
+	c.consumed(0, 2)
+	assert.False(t, c.done(), "partition 1 still pending")
+
+	// An unknown end is never reached until it is read
+	c.consumed(1, 100)
+	assert.True(t, c.unknown(1))
+	c.setEnd(1, 5)
+	c.consumed(1, 4)
+	assert.True(t, c.done())
+
+	c.assign(map[int32]kafka.Offset{3: 10})
+	assert.False(t, c.done())
+	c.revoke([]int32{3})
+	assert.True(t, c.done())
+}
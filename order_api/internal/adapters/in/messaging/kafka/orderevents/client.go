@@ -0,0 +1,82 @@
+package orderevents
+
+import (
+	"context"
+
+	"github.com/Anacardo89/order_svc_hex/order_api/internal/adapters/infra/log/loki/logger"
+	"github.com/Anacardo89/order_svc_hex/order_api/internal/ports"
+	"github.com/Anacardo89/order_svc_hex/order_api/pkg/events"
+	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+)
+
+const watermarkTimeoutMs = 5000
+
+type OrderEventsClient struct {
+	consumer   *kafka.Consumer
+	projection ports.OrderProjection
+	topic      string
+	metrics    *ProjectionMetrics
+	catchUp    *catchUp
+}
+
+func NewOrderEventsClient(kc *events.KafkaConnection, groupID, topic string, projection ports.OrderProjection, metrics *ProjectionMetrics) (*OrderEventsClient, error) {
+	client := &OrderEventsClient{
+		projection: projection,
+		topic:      topic,
+		metrics:    metrics,
+		catchUp:    newCatchUp(),
+	}
+	c, err := kc.MakeReplayConsumer(groupID, []string{topic}, client.rebalance)
+	if err != nil {
+		return nil, err
+	}
+	client.consumer = c
+	return client, nil
+}
+
+func (c *OrderEventsClient) Close() {
+	c.consumer.Close()
+}
+
+// Notes where each assigned partition ends, the read model is served once it got there
+func (c *OrderEventsClient) rebalance(consumer *kafka.Consumer, e kafka.Event) error {
+	switch ev := e.(type) {
+	case kafka.AssignedPartitions:
+		ends := make(map[int32]kafka.Offset, len(ev.Partitions))
+		for _, tp := range ev.Partitions {
+			low, high, err := consumer.QueryWatermarkOffsets(*tp.Topic, tp.Partition, watermarkTimeoutMs)
+			if err != nil {
+				logger.BaseLogger.Warn(context.Background(), "failed to read partition end, retrying on its next message",
+					ports.Field{Key: "partition", Value: tp.Partition}, ports.Field{Key: "error", Value: err})
+				ends[tp.Partition] = unknownEnd
+				continue
+			}
+			if high > low {
+				ends[tp.Partition] = kafka.Offset(high)
+			}
+		}
+		c.catchUp.assign(ends)
+		c.projection.SetCaughtUp(c.catchUp.done())
+		return consumer.Assign(ev.Partitions)
+	case kafka.RevokedPartitions:
+		partitions := make([]int32, 0, len(ev.Partitions))
+		for _, tp := range ev.Partitions {
+			partitions = append(partitions, tp.Partition)
+		}
+		c.catchUp.revoke(partitions)
+		c.projection.SetCaughtUp(c.catchUp.done())
+		return consumer.Unassign()
+	}
+	return nil
+}
+
+// Called for every message read, applied or not
+func (c *OrderEventsClient) advance(tp kafka.TopicPartition) {
+	if c.catchUp.unknown(tp.Partition) {
+		if _, high, err := c.consumer.QueryWatermarkOffsets(*tp.Topic, tp.Partition, watermarkTimeoutMs); err == nil {
+			c.catchUp.setEnd(tp.Partition, kafka.Offset(high))
+		}
+	}
+	c.catchUp.consumed(tp.Partition, tp.Offset)
+	c.projection.SetCaughtUp(c.catchUp.done())
+}
@@ -0,0 +1,42 @@
+package orderevents
+
+import (
+	"fmt"
+
+	"go.opentelemetry.io/otel/metric"
+)
+
+type ProjectionMetrics struct {
+	applied metric.Int64Counter
+	failed  metric.Int64Counter
+	lag     metric.Float64Gauge
+}
+
+func NewProjectionMetrics(meter metric.Meter) (*ProjectionMetrics, error) {
+	applied, err := meter.Int64Counter("order.projection.applied.total",
+		metric.WithDescription("Order events applied to the local read model"),
+		metric.WithUnit("{event}"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create applied counter: %w", err)
+	}
+	failed, err := meter.Int64Counter("order.projection.failed.total",
+		metric.WithDescription("Order events the local read model could not apply"),
+		metric.WithUnit("{event}"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create failed counter: %w", err)
+	}
+	lag, err := meter.Float64Gauge("order.projection.lag",
+		metric.WithDescription("Time between an order change and the read model applying it"),
+		metric.WithUnit("s"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create lag gauge: %w", err)
+	}
+	return &ProjectionMetrics{
+		applied: applied,
+		failed:  failed,
+		lag:     lag,
+	}, nil
+}
@@ -0,0 +1,42 @@
+package orderevents
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/Anacardo89/order_svc_hex/order_api/internal/core"
+	"github.com/google/uuid"
+)
+
+// Published by order_svc with the full order state
+type OrderEvent struct {
+	EventType string         `json:"event_type"`
+	ID        string         `json:"id"`
+	Items     map[string]int `json:"items"`
+	Status    string         `json:"status"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+}
+
+func mapEventPayloadToOrder(value []byte) (*core.Order, error) {
+	var e OrderEvent
+	if err := json.Unmarshal(value, &e); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal OrderEvent: %w", err)
+	}
+	id, err := uuid.Parse(e.ID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid order UUID in OrderEvent: %w", err)
+	}
+	status, err := core.MapStrToStatus(e.Status)
+	if err != nil {
+		return nil, fmt.Errorf("invalid status in OrderEvent: %w", err)
+	}
+	return &core.Order{
+		ID:        id,
+		Items:     e.Items,
+		Status:    *status,
+		CreatedAt: e.CreatedAt,
+		UpdatedAt: e.UpdatedAt,
+	}, nil
+}
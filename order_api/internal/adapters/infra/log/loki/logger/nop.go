@@ -0,0 +1,16 @@
+package logger
+
+import (
+	"context"
+
+	"github.com/Anacardo89/order_svc_hex/order_api/internal/ports"
+)
+
+// Drops everything, for tests that go through code logging via BaseLogger
+type NopLogger struct{}
+
+func (l NopLogger) With(fields ...ports.Field) ports.Logger                      { return l }
+func (l NopLogger) Debug(ctx context.Context, msg string, fields ...ports.Field) {}
+func (l NopLogger) Info(ctx context.Context, msg string, fields ...ports.Field)  {}
+func (l NopLogger) Warn(ctx context.Context, msg string, fields ...ports.Field)  {}
+func (l NopLogger) Error(ctx context.Context, msg string, fields ...ports.Field) {}
@@ -0,0 +1,45 @@
+package cachedreader
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+type CacheMetrics struct {
+	hits   metric.Int64Counter
+	misses metric.Int64Counter
+}
+
+func NewCacheMetrics(meter metric.Meter) (*CacheMetrics, error) {
+	hits, err := meter.Int64Counter("order.cache.hits",
+		metric.WithDescription("Orders served from the read cache"),
+		metric.WithUnit("{order}"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cache hit counter: %w", err)
+	}
+	misses, err := meter.Int64Counter("order.cache.misses",
+		metric.WithDescription("Orders that had to be fetched from order_svc"),
+		metric.WithUnit("{order}"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cache miss counter: %w", err)
+	}
+	return &CacheMetrics{
+		hits:   hits,
+		misses: misses,
+	}, nil
+}
+
+func (m *CacheMetrics) record(ctx context.Context, op string, hits, misses int) {
+	attrs := metric.WithAttributes(attribute.String("op", op))
+	if hits > 0 {
+		m.hits.Add(ctx, int64(hits), attrs)
+	}
+	if misses > 0 {
+		m.misses.Add(ctx, int64(misses), attrs)
+	}
+}
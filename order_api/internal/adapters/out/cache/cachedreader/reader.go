@@ -0,0 +1,125 @@
+package cachedreader
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/Anacardo89/order_svc_hex/order_api/internal/adapters/infra/log/loki/logger"
+	"github.com/Anacardo89/order_svc_hex/order_api/internal/core"
+	"github.com/Anacardo89/order_svc_hex/order_api/internal/ports"
+)
+
+// Cache lifetime per order status, zero leaves that status uncached
+type TTLs struct {
+	Default  time.Duration
+	ByStatus map[core.Status]time.Duration
+}
+
+func (t TTLs) For(status core.Status) time.Duration {
+	if ttl, ok := t.ByStatus[status]; ok {
+		return ttl
+	}
+	return t.Default
+}
+
+// Read-through cache in front of another reader, only point lookups are cached
+type OrderReader struct {
+	next    ports.OrderReader
+	cache   ports.OrderCache
+	ttls    TTLs
+	metrics *CacheMetrics
+}
+
+func NewOrderReader(next ports.OrderReader, cache ports.OrderCache, ttls TTLs, metrics *CacheMetrics) *OrderReader {
+	return &OrderReader{
+		next:    next,
+		cache:   cache,
+		ttls:    ttls,
+		metrics: metrics,
+	}
+}
+
+func (r *OrderReader) GetByID(ctx context.Context, qry *core.GetOrderQry) (*core.Order, error) {
+	if order := r.lookup(ctx, qry.ID); order != nil {
+		r.metrics.record(ctx, "get_by_id", 1, 0)
+		return order, nil
+	}
+	r.metrics.record(ctx, "get_by_id", 0, 1)
+	order, err := r.next.GetByID(ctx, qry)
+	if err != nil {
+		return nil, err
+	}
+	r.store(ctx, order)
+	return order, nil
+}
+
+// Only the ids not in cache go to the next reader, the result keeps the requested order
+func (r *OrderReader) GetByIDs(ctx context.Context, qry *core.GetOrdersByIDsQry) (*core.OrderBatch, error) {
+	cached := make(map[uuid.UUID]*core.Order, len(qry.IDs))
+	var misses []uuid.UUID
+	for _, id := range qry.IDs {
+		if order := r.lookup(ctx, id); order != nil {
+			cached[id] = order
+		} else {
+			misses = append(misses, id)
+		}
+	}
+	r.metrics.record(ctx, "get_by_ids", len(cached), len(misses))
+	batch := &core.OrderBatch{}
+	if len(misses) > 0 {
+		fetched, err := r.next.GetByIDs(ctx, &core.GetOrdersByIDsQry{IDs: misses})
+		if err != nil {
+			return nil, err
+		}
+		for _, order := range fetched.Orders {
+			r.store(ctx, order)
+			cached[order.ID] = order
+		}
+		batch.Missing = fetched.Missing
+	}
+	batch.Orders = make([]*core.Order, 0, len(cached))
+	for _, id := range qry.IDs {
+		if order, ok := cached[id]; ok {
+			batch.Orders = append(batch.Orders, order)
+			// Duplicated ids are returned once
+			delete(cached, id)
+		}
+	}
+	return batch, nil
+}
+
+func (r *OrderReader) List(ctx context.Context, qry *core.ListOrdersQry) (*core.OrderPage, error) {
+	return r.next.List(ctx, qry)
+}
+
+func (r *OrderReader) Stream(ctx context.Context, qry *core.ListOrdersQry, send func(*core.Order) error) (string, error) {
+	return r.next.Stream(ctx, qry, send)
+}
+
+func (r *OrderReader) Watch(ctx context.Context, qry *core.WatchOrderQry, send func(*core.OrderChange) error) error {
+	return r.next.Watch(ctx, qry, send)
+}
+
+// Cache failures are treated as misses so a shared cache going down doesn't take reads with it
+func (r *OrderReader) lookup(ctx context.Context, id uuid.UUID) *core.Order {
+	order, err := r.cache.Get(ctx, id)
+	if err != nil {
+		log := logger.LogFromCtx(ctx, logger.BaseLogger)
+		log.Warn(ctx, "failed to read order from cache", ports.Field{Key: "order_id", Value: id}, ports.Field{Key: "error", Value: err})
+		return nil
+	}
+	return order
+}
+
+func (r *OrderReader) store(ctx context.Context, order *core.Order) {
+	ttl := r.ttls.For(order.Status)
+	if ttl <= 0 {
+		return
+	}
+	if err := r.cache.Set(ctx, order, ttl); err != nil {
+		log := logger.LogFromCtx(ctx, logger.BaseLogger)
+		log.Warn(ctx, "failed to cache order", ports.Field{Key: "order_id", Value: order.ID}, ports.Field{Key: "error", Value: err})
+	}
+}
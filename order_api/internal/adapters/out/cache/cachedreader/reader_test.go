@@ -0,0 +1,157 @@
+package cachedreader
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/metric/noop"
+
+	"github.com/Anacardo89/order_svc_hex/order_api/internal/adapters/infra/log/loki/logger"
+	"github.com/Anacardo89/order_svc_hex/order_api/internal/adapters/out/store/memory/ordercache"
+	"github.com/Anacardo89/order_svc_hex/order_api/internal/core"
+	"github.com/Anacardo89/order_svc_hex/order_api/internal/ports"
+)
+
+type countingReader struct {
+	ports.OrderReader
+	orders  map[uuid.UUID]*core.Order
+	gets    int
+	batched [][]uuid.UUID
+}
+
+func (f *countingReader) GetByID(ctx context.Context, qry *core.GetOrderQry) (*core.Order, error) {
+	f.gets++
+	order := *f.orders[qry.ID]
+	return &order, nil
+}
+
+func (f *countingReader) GetByIDs(ctx context.Context, qry *core.GetOrdersByIDsQry) (*core.OrderBatch, error) {
+	f.batched = append(f.batched, qry.IDs)
+	batch := &core.OrderBatch{}
+	for _, id := range qry.IDs {
+		if o, ok := f.orders[id]; ok {
+			batch.Orders = append(batch.Orders, o)
+		} else {
+			batch.Missing = append(batch.Missing, id)
+		}
+	}
+	return batch, nil
+}
+
+type nopTracker struct {
+	ports.CommandTracker
+	resolved []*core.CommandResult
+}
+
+func (t *nopTracker) Resolve(ctx context.Context, result *core.CommandResult) error {
+	t.resolved = append(t.resolved, result)
+	return nil
+}
+
+var (
+	pendingID   = uuid.MustParse("11111111-1111-1111-1111-111111111111")
+	cancelledID = uuid.MustParse("22222222-2222-2222-2222-222222222222")
+	unknownID   = uuid.MustParse("aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaaa")
+)
+
+func newTestReader(t *testing.T) (*OrderReader, *CommandTracker, *countingReader) {
+	t.Helper()
+	logger.BaseLogger = logger.NopLogger{}
+	next := &countingReader{orders: map[uuid.UUID]*core.Order{
+		pendingID:   {ID: pendingID, Status: core.StatusPending},
+		cancelledID: {ID: cancelledID, Status: core.StatusCancelled, Items: map[string]int{"sku_1": 1}},
+	}}
+	metrics, err := NewCacheMetrics(noop.NewMeterProvider().Meter("test"))
+	require.NoError(t, err)
+	cache := ordercache.NewCache(10)
+	ttls := TTLs{
+		Default:  time.Hour,
+		ByStatus: map[core.Status]time.Duration{core.StatusPending: 0},
+	}
+	return NewOrderReader(next, cache, ttls, metrics), NewCommandTracker(&nopTracker{}, cache), next
+}
+
+func TestOrderReader_GetByID(t *testing.T) {
+	tests := []struct {
+		name     string
+		id       uuid.UUID
+		wantGets int
+	}{
+		{
+			name:     "terminal order is served from cache",
+			id:       cancelledID,
+			wantGets: 1,
+		},
+		{
+			name:     "status with zero ttl is never cached",
+			id:       pendingID,
+			wantGets: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reader, _, next := newTestReader(t)
+			ctx := context.Background()
+			for range 2 {
+				order, err := reader.GetByID(ctx, &core.GetOrderQry{ID: tt.id})
+				require.NoError(t, err)
+				assert.Equal(t, tt.id, order.ID)
+			}
+			assert.Equal(t, tt.wantGets, next.gets)
+		})
+	}
+}
+
+func TestOrderReader_GetByIDs(t *testing.T) {
+	reader, _, next := newTestReader(t)
+	ctx := context.Background()
+	_, err := reader.GetByID(ctx, &core.GetOrderQry{ID: cancelledID})
+	require.NoError(t, err)
+
+	batch, err := reader.GetByIDs(ctx, &core.GetOrdersByIDsQry{IDs: []uuid.UUID{cancelledID, unknownID, pendingID}})
+	require.NoError(t, err)
+
+	require.Len(t, next.batched, 1)
+	assert.Equal(t, []uuid.UUID{unknownID, pendingID}, next.batched[0])
+	require.Len(t, batch.Orders, 2)
+	assert.Equal(t, cancelledID, batch.Orders[0].ID)
+	assert.Equal(t, pendingID, batch.Orders[1].ID)
+	assert.Equal(t, []uuid.UUID{unknownID}, batch.Missing)
+}
+
+func TestCommandTracker_InvalidatesOnResult(t *testing.T) {
+	reader, tracker, next := newTestReader(t)
+	ctx := context.Background()
+	_, err := reader.GetByID(ctx, &core.GetOrderQry{ID: cancelledID})
+	require.NoError(t, err)
+
+	result := &core.CommandResult{OrderID: cancelledID, State: core.CommandApplied}
+	require.NoError(t, tracker.Resolve(ctx, result))
+	_, err = reader.GetByID(ctx, &core.GetOrderQry{ID: cancelledID})
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, next.gets)
+	assert.Equal(t, []*core.CommandResult{result}, tracker.CommandTracker.(*nopTracker).resolved)
+}
+
+func TestOrderReader_CachedItemsAreCopies(t *testing.T) {
+	reader, _, _ := newTestReader(t)
+	ctx := context.Background()
+	order, err := reader.GetByID(ctx, &core.GetOrderQry{ID: cancelledID})
+	require.NoError(t, err)
+	order.Items["sku_1"] = 99
+
+	cached, err := reader.GetByID(ctx, &core.GetOrderQry{ID: cancelledID})
+	require.NoError(t, err)
+	assert.Equal(t, 1, cached.Items["sku_1"])
+	cached.Items["sku_1"] = 98
+
+	again, err := reader.GetByID(ctx, &core.GetOrderQry{ID: cancelledID})
+	require.NoError(t, err)
+	assert.Equal(t, 1, again.Items["sku_1"])
+}
@@ -0,0 +1,36 @@
+package cachedreader
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/Anacardo89/order_svc_hex/order_api/internal/adapters/infra/log/loki/logger"
+	"github.com/Anacardo89/order_svc_hex/order_api/internal/core"
+	"github.com/Anacardo89/order_svc_hex/order_api/internal/ports"
+)
+
+// Drops a cached order once order_svc reports a command on it as settled.
+// Dropping it when the command is published would let a read in between cache the old status again
+type CommandTracker struct {
+	ports.CommandTracker
+	cache ports.OrderCache
+}
+
+func NewCommandTracker(next ports.CommandTracker, cache ports.OrderCache) *CommandTracker {
+	return &CommandTracker{
+		CommandTracker: next,
+		cache:          cache,
+	}
+}
+
+// Invalidates before resolving, so a caller waiting on the command reads the new status
+func (t *CommandTracker) Resolve(ctx context.Context, result *core.CommandResult) error {
+	if result.OrderID != uuid.Nil {
+		if err := t.cache.Delete(ctx, result.OrderID); err != nil {
+			log := logger.LogFromCtx(ctx, logger.BaseLogger)
+			log.Warn(ctx, "failed to invalidate cached order", ports.Field{Key: "order_id", Value: result.OrderID}, ports.Field{Key: "error", Value: err})
+		}
+	}
+	return t.CommandTracker.Resolve(ctx, result)
+}
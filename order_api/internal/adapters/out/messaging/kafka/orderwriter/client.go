@@ -13,12 +13,20 @@ type OrderWriterClient struct {
 	producerStatusUpdate *Producer
 }
 
-func NewOrderWriterClient(kc *events.KafkaConnection, topics map[string]string, meter metric.Meter, m *ProducerMetrics) (*OrderWriterClient, error) {
+func NewOrderWriterClient(kc *events.KafkaConnection, topics, contentTypes map[string]string, serializer *events.Serializer, meter metric.Meter, m *ProducerMetrics) (*OrderWriterClient, error) {
+	replyTopic, ok := topics[string(TopicOrderCommandResult)]
+	if !ok {
+		return nil, fmt.Errorf("missing topic: %s", TopicOrderCommandResult)
+	}
 	createdTopic, ok := topics[string(TopicOrderCreated)]
 	if !ok {
 		return nil, fmt.Errorf("missing topic: %s", TopicOrderCreated)
 	}
-	pc, err := NewProducer(kc, createdTopic, meter, m)
+	createdType, err := events.MapStrToContentType(contentTypes[string(TopicOrderCreated)])
+	if err != nil {
+		return nil, err
+	}
+	pc, err := NewProducer(kc, createdTopic, replyTopic, createdType, serializer, meter, m)
 	if err != nil {
 		return nil, err
 	}
@@ -26,7 +34,11 @@ func NewOrderWriterClient(kc *events.KafkaConnection, topics map[string]string,
 	if !ok {
 		return nil, fmt.Errorf("missing topic: %s", TopicOrderStatusUpdated)
 	}
-	ps, err := NewProducer(kc, updatedTopic, meter, m)
+	updatedType, err := events.MapStrToContentType(contentTypes[string(TopicOrderStatusUpdated)])
+	if err != nil {
+		return nil, err
+	}
+	ps, err := NewProducer(kc, updatedTopic, replyTopic, updatedType, serializer, meter, m)
 	if err != nil {
 		return nil, err
 	}
@@ -2,16 +2,24 @@ package orderwriter
 
 import (
 	"context"
+	"time"
 
 	"github.com/Anacardo89/order_svc_hex/order_api/internal/core"
+	"github.com/Anacardo89/order_svc_hex/order_api/pkg/events"
 )
 
 func (c *OrderWriterClient) PublishCreate(ctx context.Context, cmd *core.CreateOrderCmd) error {
+	id := cmd.ID.String()
 	event := OrderCreatedEvent{
+		ID:     id,
 		Items:  cmd.Items,
 		Status: string(cmd.Status),
 	}
-	return c.producerCreated.publish(ctx, "", event)
+	env, err := events.NewEnvelope(cmd.CommandID.String(), events.EventTypeOrderCreated, OrderCreatedVersion, producerName, time.Now(), event)
+	if err != nil {
+		return err
+	}
+	return c.producerCreated.publish(ctx, id, cmd.CommandID.String(), env)
 }
 
 func (c *OrderWriterClient) PublishStatusUpdate(ctx context.Context, cmd *core.UpdateOrderStatusCmd) error {
@@ -19,5 +27,9 @@ func (c *OrderWriterClient) PublishStatusUpdate(ctx context.Context, cmd *core.U
 		ID:     cmd.ID,
 		Status: string(cmd.Status),
 	}
-	return c.producerStatusUpdate.publish(ctx, cmd.ID, event)
+	env, err := events.NewEnvelope(cmd.CommandID.String(), events.EventTypeOrderStatusUpdated, OrderStatusUpdatedVersion, producerName, time.Now(), event)
+	if err != nil {
+		return err
+	}
+	return c.producerStatusUpdate.publish(ctx, cmd.ID, cmd.CommandID.String(), env)
 }
@@ -5,9 +5,20 @@ type TopicKey string
 const (
 	TopicOrderCreated       TopicKey = "OrderCreated"
 	TopicOrderStatusUpdated TopicKey = "OrderStatusUpdated"
+	TopicOrderCommandResult TopicKey = "OrderCommandResult"
+)
+
+// Set as producer in the envelope
+const producerName = "order_api"
+
+// Schema versions of the data published, order_svc upcasts anything older
+const (
+	OrderCreatedVersion       = 1
+	OrderStatusUpdatedVersion = 1
 )
 
 type OrderCreatedEvent struct {
+	ID     string         `json:"id"`
 	Items  map[string]int `json:"items"`
 	Status string         `json:"status"`
 }
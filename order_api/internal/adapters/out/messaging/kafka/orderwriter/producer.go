@@ -2,7 +2,6 @@ package orderwriter
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"time"
 
@@ -20,13 +19,17 @@ import (
 )
 
 type Producer struct {
-	producer     *kafka.Producer
-	metrics      *ProducerMetrics
-	topic        string
+	producer    *kafka.Producer
+	metrics     *ProducerMetrics
+	topic       string
+	replyTo     string
+	contentType string
+	// Frames values for the schema registry when set
+	serializer   *events.Serializer
 	registration metric.Registration
 }
 
-func NewProducer(kc *events.KafkaConnection, topic string, meter metric.Meter, m *ProducerMetrics) (*Producer, error) {
+func NewProducer(kc *events.KafkaConnection, topic, replyTo, contentType string, serializer *events.Serializer, meter metric.Meter, m *ProducerMetrics) (*Producer, error) {
 	p, err := kc.MakeProducer()
 	if err != nil {
 		return nil, err
@@ -38,9 +41,12 @@ func NewProducer(kc *events.KafkaConnection, topic string, meter metric.Meter, m
 		return nil, err
 	}
 	producer := &Producer{
-		producer: p,
-		metrics:  m,
-		topic:    topic,
+		producer:    p,
+		metrics:     m,
+		topic:       topic,
+		replyTo:     replyTo,
+		contentType: contentType,
+		serializer:  serializer,
 	}
 	reg, err := meter.RegisterCallback(func(ctx context.Context, obs metric.Observer) error {
 		obs.ObserveInt64(gauge, int64(p.Len()), metric.WithAttributes(
@@ -55,7 +61,7 @@ func NewProducer(kc *events.KafkaConnection, topic string, meter metric.Meter, m
 	return producer, nil
 }
 
-func (p *Producer) publish(ctx context.Context, key string, payload any) error {
+func (p *Producer) publish(ctx context.Context, key, correlationID string, env *events.Envelope) error {
 	// Error handling
 	fail := func(msg string, span trace.Span, metricAttrs metric.MeasurementOption, err error) {
 		p.metrics.failed.Add(ctx, 1, metricAttrs)
@@ -80,13 +86,27 @@ func (p *Producer) publish(ctx context.Context, key string, payload any) error {
 	defer span.End()
 
 	// Execution
-	headers := injectTraceHeaders(msgCtx)
-	value, err := json.Marshal(payload)
+	// order_svc answers on the reply topic with the same correlation ID.
+	// The command ID doubles as message ID, so order_svc skips a command it already applied
+	headers := append(injectTraceHeaders(msgCtx),
+		kafka.Header{Key: events.HeaderCorrelationID, Value: []byte(correlationID)},
+		kafka.Header{Key: events.HeaderReplyTo, Value: []byte(p.replyTo)},
+		kafka.Header{Key: events.HeaderMessageID, Value: []byte(correlationID)},
+		kafka.Header{Key: events.HeaderContentType, Value: []byte(p.contentType)},
+	)
+	value, err := events.EncodeCommand(p.contentType, env)
 	if err != nil {
 		log.Error(msgCtx, "failed to marshal message", ports.Field{Key: "error", Value: err})
 		fail("marshal failed", span, metricAttrs, err)
 		return err
 	}
+	if p.serializer != nil {
+		if value, err = p.serialize(msgCtx, env.EventType, value); err != nil {
+			log.Error(msgCtx, "failed to serialize message", ports.Field{Key: "error", Value: err})
+			fail("serialize failed", span, metricAttrs, err)
+			return err
+		}
+	}
 	deliveryChan := make(chan kafka.Event, 1)
 	msg := &kafka.Message{
 		TopicPartition: kafka.TopicPartition{
@@ -120,6 +140,14 @@ func (p *Producer) publish(ctx context.Context, key string, payload any) error {
 	return nil
 }
 
+func (p *Producer) serialize(ctx context.Context, eventType string, value []byte) ([]byte, error) {
+	schema, err := events.CommandSchema(eventType, p.contentType)
+	if err != nil {
+		return nil, err
+	}
+	return p.serializer.Serialize(ctx, p.topic, schema, value)
+}
+
 func injectTraceHeaders(ctx context.Context) []kafka.Header {
 	headers := []kafka.Header{}
 	propagator := otel.GetTextMapPropagator()
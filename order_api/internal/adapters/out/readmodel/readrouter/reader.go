@@ -0,0 +1,135 @@
+package readrouter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Anacardo89/order_svc_hex/order_api/internal/adapters/infra/log/loki/logger"
+	"github.com/Anacardo89/order_svc_hex/order_api/internal/core"
+	"github.com/Anacardo89/order_svc_hex/order_api/internal/ports"
+)
+
+type Mode string
+
+const (
+	// Reads are served by the local read model once it caught up, watches still go to order_svc
+	ModePrimary Mode = "primary"
+	// Reads go to order_svc and only fall back to the local read model when it's unreachable
+	ModeFallback Mode = "fallback"
+)
+
+func MapStrToMode(s string) (Mode, error) {
+	switch s {
+	case "", string(ModeFallback):
+		return ModeFallback, nil
+	case string(ModePrimary):
+		return ModePrimary, nil
+	default:
+		return "", fmt.Errorf("unknown read model mode: %s", s)
+	}
+}
+
+type OrderReader struct {
+	remote ports.OrderReader
+	local  ports.OrderReader
+	mode   Mode
+}
+
+func NewOrderReader(remote, local ports.OrderReader, mode Mode) *OrderReader {
+	return &OrderReader{
+		remote: remote,
+		local:  local,
+		mode:   mode,
+	}
+}
+
+func (r *OrderReader) GetByID(ctx context.Context, qry *core.GetOrderQry) (*core.Order, error) {
+	if r.mode == ModePrimary {
+		order, err := r.local.GetByID(ctx, qry)
+		if r.catchingUp(ctx, err) {
+			return r.remote.GetByID(ctx, qry)
+		}
+		return order, err
+	}
+	order, err := r.remote.GetByID(ctx, qry)
+	if r.fallBack(ctx, err) {
+		return r.local.GetByID(ctx, qry)
+	}
+	return order, err
+}
+
+func (r *OrderReader) GetByIDs(ctx context.Context, qry *core.GetOrdersByIDsQry) (*core.OrderBatch, error) {
+	if r.mode == ModePrimary {
+		batch, err := r.local.GetByIDs(ctx, qry)
+		if r.catchingUp(ctx, err) {
+			return r.remote.GetByIDs(ctx, qry)
+		}
+		return batch, err
+	}
+	batch, err := r.remote.GetByIDs(ctx, qry)
+	if r.fallBack(ctx, err) {
+		return r.local.GetByIDs(ctx, qry)
+	}
+	return batch, err
+}
+
+func (r *OrderReader) List(ctx context.Context, qry *core.ListOrdersQry) (*core.OrderPage, error) {
+	if r.mode == ModePrimary {
+		page, err := r.local.List(ctx, qry)
+		if r.catchingUp(ctx, err) {
+			return r.remote.List(ctx, qry)
+		}
+		return page, err
+	}
+	page, err := r.remote.List(ctx, qry)
+	if r.fallBack(ctx, err) {
+		return r.local.List(ctx, qry)
+	}
+	return page, err
+}
+
+// Falls back only if nothing was sent yet, a stream can't be resumed halfway on the other side.
+// The read model refuses a stream before sending anything, so that switch is always safe
+func (r *OrderReader) Stream(ctx context.Context, qry *core.ListOrdersQry, send func(*core.Order) error) (string, error) {
+	if r.mode == ModePrimary {
+		next, err := r.local.Stream(ctx, qry, send)
+		if r.catchingUp(ctx, err) {
+			return r.remote.Stream(ctx, qry, send)
+		}
+		return next, err
+	}
+	var sent bool
+	next, err := r.remote.Stream(ctx, qry, func(o *core.Order) error {
+		sent = true
+		return send(o)
+	})
+	if !sent && r.fallBack(ctx, err) {
+		return r.local.Stream(ctx, qry, send)
+	}
+	return next, err
+}
+
+// The read model has no change feed, watching always needs order_svc
+func (r *OrderReader) Watch(ctx context.Context, qry *core.WatchOrderQry, send func(*core.OrderChange) error) error {
+	return r.remote.Watch(ctx, qry, send)
+}
+
+func (r *OrderReader) fallBack(ctx context.Context, err error) bool {
+	if !errors.Is(err, core.ErrReaderUnavailable) {
+		return false
+	}
+	log := logger.LogFromCtx(ctx, logger.BaseLogger)
+	log.Warn(ctx, "order_svc unavailable, reading from local read model", ports.Field{Key: "error", Value: err})
+	return true
+}
+
+// The read model refuses reads until it caught up, order_svc answers in the meantime
+func (r *OrderReader) catchingUp(ctx context.Context, err error) bool {
+	if !errors.Is(err, core.ErrReaderUnavailable) {
+		return false
+	}
+	log := logger.LogFromCtx(ctx, logger.BaseLogger)
+	log.Debug(ctx, "local read model unavailable, reading from order_svc", ports.Field{Key: "error", Value: err})
+	return true
+}
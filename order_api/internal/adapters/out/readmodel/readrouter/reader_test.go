@@ -0,0 +1,92 @@
+package readrouter
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Anacardo89/order_svc_hex/order_api/internal/adapters/infra/log/loki/logger"
+	"github.com/Anacardo89/order_svc_hex/order_api/internal/core"
+	"github.com/Anacardo89/order_svc_hex/order_api/internal/ports"
+)
+
+type fakeReader struct {
+	ports.OrderReader
+	name  string
+	err   error
+	calls int
+}
+
+func (f *fakeReader) GetByID(ctx context.Context, qry *core.GetOrderQry) (*core.Order, error) {
+	f.calls++
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &core.Order{ID: qry.ID, Status: core.Status(f.name)}, nil
+}
+
+func TestOrderReader_GetByID(t *testing.T) {
+	logger.BaseLogger = logger.NopLogger{}
+	id := uuid.MustParse("11111111-1111-1111-1111-111111111111")
+
+	tests := []struct {
+		name      string
+		mode      Mode
+		remoteErr error
+		localErr  error
+		wantFrom  string
+		wantErr   bool
+	}{
+		{
+			name:     "fallback mode reads order_svc",
+			mode:     ModeFallback,
+			wantFrom: "remote",
+		},
+		{
+			name:      "fallback mode uses read model when order_svc is down",
+			mode:      ModeFallback,
+			remoteErr: core.ErrReaderUnavailable,
+			wantFrom:  "local",
+		},
+		{
+			name:      "other order_svc errors are returned",
+			mode:      ModeFallback,
+			remoteErr: errors.New("boom"),
+			wantErr:   true,
+		},
+		{
+			name:     "primary mode never calls order_svc",
+			mode:     ModePrimary,
+			wantFrom: "local",
+		},
+		{
+			name:     "primary mode reads order_svc until the read model caught up",
+			mode:     ModePrimary,
+			localErr: core.ErrReaderUnavailable,
+			wantFrom: "remote",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			remote := &fakeReader{name: "remote", err: tt.remoteErr}
+			local := &fakeReader{name: "local", err: tt.localErr}
+			r := NewOrderReader(remote, local, tt.mode)
+
+			order, err := r.GetByID(context.Background(), &core.GetOrderQry{ID: id})
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Zero(t, local.calls)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, core.Status(tt.wantFrom), order.Status)
+			if tt.mode == ModePrimary && tt.localErr == nil {
+				assert.Zero(t, remote.calls)
+			}
+		})
+	}
+}
@@ -0,0 +1,36 @@
+package deadletteradmin
+
+import (
+	"fmt"
+
+	"github.com/Anacardo89/order_svc_hex/order_api/config"
+	"github.com/Anacardo89/order_svc_hex/order_api/internal/adapters/out/rpc/grpc/orderreader"
+	pb "github.com/Anacardo89/order_svc_hex/order_api/proto/orderpb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+type DeadLetterAdminClient struct {
+	client pb.DeadLetterAdminServiceClient
+	conn   *grpc.ClientConn
+}
+
+func NewDeadLetterAdminClient(cfg config.GRPC) (*DeadLetterAdminClient, error) {
+	conn, err := grpc.NewClient(
+		fmt.Sprintf("%s:%s", cfg.Host, cfg.Port),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithUnaryInterceptor(orderreader.UnaryTraceInterceptor()),
+	)
+	if err != nil {
+		return nil, err
+	}
+	c := pb.NewDeadLetterAdminServiceClient(conn)
+	return &DeadLetterAdminClient{
+		client: c,
+		conn:   conn,
+	}, nil
+}
+
+func (c *DeadLetterAdminClient) Close() error {
+	return c.conn.Close()
+}
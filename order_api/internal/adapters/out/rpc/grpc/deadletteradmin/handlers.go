@@ -0,0 +1,97 @@
+package deadletteradmin
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/Anacardo89/order_svc_hex/order_api/internal/core"
+	pb "github.com/Anacardo89/order_svc_hex/order_api/proto/orderpb"
+)
+
+func (c *DeadLetterAdminClient) ListDeadLetters(ctx context.Context, qry *core.ListDeadLettersQry) (*core.DeadLetterPage, error) {
+	resp, err := c.client.ListDeadLetters(ctx, &pb.ListDeadLettersRequest{
+		Filter:    toProtoFilter(qry.Filter),
+		Limit:     int32(qry.Limit),
+		PageToken: qry.Cursor,
+	})
+	if err != nil {
+		return nil, mapError(err)
+	}
+	page := &core.DeadLetterPage{
+		DeadLetters: make([]*core.DeadLetter, 0, len(resp.DeadLetters)),
+		NextCursor:  resp.NextPageToken,
+	}
+	for _, l := range resp.DeadLetters {
+		page.DeadLetters = append(page.DeadLetters, fromProtoDeadLetter(l))
+	}
+	return page, nil
+}
+
+func (c *DeadLetterAdminClient) GetDeadLetter(ctx context.Context, id int64) (*core.DeadLetter, error) {
+	resp, err := c.client.GetDeadLetter(ctx, &pb.GetDeadLetterRequest{Id: id})
+	if err != nil {
+		return nil, mapError(err)
+	}
+	return fromProtoDeadLetter(resp), nil
+}
+
+func (c *DeadLetterAdminClient) ResolveDeadLetter(ctx context.Context, cmd *core.ResolveDeadLetterCmd) (*core.DeadLetter, error) {
+	resp, err := c.client.ResolveDeadLetter(ctx, &pb.ResolveDeadLetterRequest{
+		Id:   cmd.ID,
+		Note: cmd.Note,
+	})
+	if err != nil {
+		return nil, mapError(err)
+	}
+	return fromProtoDeadLetter(resp), nil
+}
+
+func (c *DeadLetterAdminClient) ReplayDeadLetter(ctx context.Context, id int64) (*core.DeadLetter, error) {
+	resp, err := c.client.ReplayDeadLetter(ctx, &pb.ReplayDeadLetterRequest{Id: id})
+	if err != nil {
+		return nil, mapError(err)
+	}
+	return fromProtoDeadLetter(resp), nil
+}
+
+func (c *DeadLetterAdminClient) PurgeDeadLetters(ctx context.Context, filter core.DeadLetterFilter) (int64, error) {
+	resp, err := c.client.PurgeDeadLetters(ctx, &pb.PurgeDeadLettersRequest{Filter: toProtoFilter(filter)})
+	if err != nil {
+		return 0, mapError(err)
+	}
+	return resp.Deleted, nil
+}
+
+func (c *DeadLetterAdminClient) CountDeadLetters(ctx context.Context, filter core.DeadLetterFilter) ([]*core.DeadLetterCount, error) {
+	resp, err := c.client.CountDeadLetters(ctx, &pb.CountDeadLettersRequest{Filter: toProtoFilter(filter)})
+	if err != nil {
+		return nil, mapError(err)
+	}
+	counts := make([]*core.DeadLetterCount, 0, len(resp.Counts))
+	for _, c := range resp.Counts {
+		counts = append(counts, &core.DeadLetterCount{
+			Reason:   c.Reason,
+			Open:     c.Open,
+			Resolved: c.Resolved,
+			Replayed: c.Replayed,
+			Total:    c.Total,
+		})
+	}
+	return counts, nil
+}
+
+func mapError(err error) error {
+	switch status.Code(err) {
+	case codes.NotFound:
+		return fmt.Errorf("%w: %s", core.ErrDeadLetterNotFound, status.Convert(err).Message())
+	case codes.FailedPrecondition:
+		return fmt.Errorf("%w: %s", core.ErrDeadLetterNotOpen, status.Convert(err).Message())
+	case codes.InvalidArgument:
+		return fmt.Errorf("%w: %s", core.ErrInvalidDeadLetterQry, status.Convert(err).Message())
+	default:
+		return err
+	}
+}
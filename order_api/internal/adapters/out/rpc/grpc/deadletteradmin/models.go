@@ -0,0 +1,75 @@
+package deadletteradmin
+
+import (
+	"github.com/Anacardo89/order_svc_hex/order_api/internal/core"
+	"github.com/Anacardo89/order_svc_hex/order_api/pkg/ptr"
+	pb "github.com/Anacardo89/order_svc_hex/order_api/proto/orderpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func mapStatusToProto(s core.DeadLetterStatus) pb.DeadLetterStatus {
+	switch s {
+	case core.DeadLetterResolved:
+		return pb.DeadLetterStatus_DEAD_LETTER_RESOLVED
+	case core.DeadLetterReplayed:
+		return pb.DeadLetterStatus_DEAD_LETTER_REPLAYED
+	default:
+		return pb.DeadLetterStatus_DEAD_LETTER_OPEN
+	}
+}
+
+func mapStatusToCore(s pb.DeadLetterStatus) core.DeadLetterStatus {
+	switch s {
+	case pb.DeadLetterStatus_DEAD_LETTER_RESOLVED:
+		return core.DeadLetterResolved
+	case pb.DeadLetterStatus_DEAD_LETTER_REPLAYED:
+		return core.DeadLetterReplayed
+	default:
+		return core.DeadLetterOpen
+	}
+}
+
+func toProtoFilter(f core.DeadLetterFilter) *pb.DeadLetterFilter {
+	filter := &pb.DeadLetterFilter{
+		Reasons:        f.Reasons,
+		OriginalTopics: f.OriginalTopics,
+		ErrorContains:  f.ErrorContains,
+	}
+	for _, s := range f.Statuses {
+		filter.Statuses = append(filter.Statuses, mapStatusToProto(s))
+	}
+	if f.FailedAfter != nil {
+		filter.FailedAfter = timestamppb.New(*f.FailedAfter)
+	}
+	if f.FailedBefore != nil {
+		filter.FailedBefore = timestamppb.New(*f.FailedBefore)
+	}
+	return filter
+}
+
+func fromProtoDeadLetter(l *pb.DeadLetter) *core.DeadLetter {
+	letter := &core.DeadLetter{
+		ID:                l.Id,
+		FailedAt:          l.FailedAt.AsTime(),
+		Reason:            l.Reason,
+		Error:             l.Error,
+		OriginalTopic:     l.OriginalTopic,
+		OriginalKey:       l.OriginalKey,
+		OriginalValue:     l.OriginalValue,
+		OriginalHeaders:   l.OriginalHeaders,
+		OriginalPartition: l.OriginalPartition,
+		OriginalOffset:    l.OriginalOffset,
+		Attempts:          int(l.Attempts),
+		TraceID:           l.TraceId,
+		SpanID:            l.SpanId,
+		Status:            mapStatusToCore(l.Status),
+		Note:              l.Note,
+	}
+	if l.ResolvedAt != nil {
+		letter.ResolvedAt = ptr.Ptr(l.ResolvedAt.AsTime())
+	}
+	if l.ReplayedAt != nil {
+		letter.ReplayedAt = ptr.Ptr(l.ReplayedAt.AsTime())
+	}
+	return letter
+}
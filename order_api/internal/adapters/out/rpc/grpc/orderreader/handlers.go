@@ -2,8 +2,13 @@ package orderreader
 
 import (
 	"context"
+	"fmt"
 	"io"
 
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
 	"github.com/Anacardo89/order_svc_hex/order_api/internal/core"
 	"github.com/Anacardo89/order_svc_hex/order_api/proto/orderpb"
 )
@@ -11,26 +16,152 @@ import (
 func (c *OrderReaderClient) GetByID(ctx context.Context, qry *core.GetOrderQry) (*core.Order, error) {
 	resp, err := c.client.GetOrderByID(ctx, &orderpb.GetOrderByIDRequest{Id: qry.ID.String()})
 	if err != nil {
-		return nil, err
+		return nil, mapUnavailable(err)
+	}
+	return fromProtoOrder(resp)
+}
+
+func (c *OrderReaderClient) GetByIDs(ctx context.Context, qry *core.GetOrdersByIDsQry) (*core.OrderBatch, error) {
+	resp, err := c.client.GetOrdersByIDs(ctx, toProtoBatchRequest(qry))
+	if err != nil {
+		if status.Code(err) == codes.InvalidArgument {
+			return nil, fmt.Errorf("%w: %s", core.ErrInvalidBatch, status.Convert(err).Message())
+		}
+		return nil, mapUnavailable(err)
 	}
-	return fromProtoOrder(resp), nil
+	return fromProtoBatch(resp)
 }
 
-func (c *OrderReaderClient) ListByStatus(ctx context.Context, qry *core.ListOrdersByStatusQry) ([]*core.Order, error) {
-	stream, err := c.client.ListOrdersByStatus(ctx, &orderpb.ListOrdersByStatusRequest{Status: mapStatusToProto(qry.Status)})
+func (c *OrderReaderClient) List(ctx context.Context, qry *core.ListOrdersQry) (*core.OrderPage, error) {
+	page := &core.OrderPage{}
+	next, err := c.Stream(ctx, qry, func(o *core.Order) error {
+		page.Orders = append(page.Orders, o)
+		return nil
+	})
 	if err != nil {
 		return nil, err
 	}
-	var orders []*core.Order
+	page.NextCursor = next
+	return page, nil
+}
+
+func (c *OrderReaderClient) Stream(ctx context.Context, qry *core.ListOrdersQry, send func(*core.Order) error) (string, error) {
+	// Stops the server side too when send gives up early
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	stream, err := c.client.ListOrders(ctx, toProtoListRequest(qry))
+	if err != nil {
+		return "", mapUnavailable(err)
+	}
+	var (
+		next     string
+		received bool
+	)
 	for {
-		o, err := stream.Recv()
+		resp, err := stream.Recv()
 		if err != nil {
 			if err == io.EOF {
 				break
 			}
-			return nil, err
+			// order_svc from before ListOrders, only during a rolling deploy
+			if status.Code(err) == codes.Unimplemented && !received {
+				if req, ok := toLegacyListRequest(qry); ok {
+					return "", c.streamByStatus(ctx, req, qry.Limit, send)
+				}
+			}
+			if status.Code(err) == codes.InvalidArgument {
+				return "", mapInvalidList(err)
+			}
+			return "", mapUnavailable(err)
+		}
+		received = true
+		// The trailing message only carries the token
+		if resp.Order == nil {
+			next = resp.NextPageToken
+			continue
+		}
+		order, err := fromProtoOrder(resp.Order)
+		if err != nil {
+			return "", err
+		}
+		if err := send(order); err != nil {
+			return "", err
+		}
+	}
+	return next, nil
+}
+
+// The legacy RPC has no paging, so only the first limit orders come back and no next page.
+// The caller's cancel stops the server once enough arrived
+func (c *OrderReaderClient) streamByStatus(ctx context.Context, req *orderpb.ListOrdersByStatusRequest, limit int, send func(*core.Order) error) error {
+	stream, err := c.client.ListOrdersByStatus(ctx, req)
+	if err != nil {
+		return mapUnavailable(err)
+	}
+	for sent := 0; limit <= 0 || sent < limit; sent++ {
+		resp, err := stream.Recv()
+		if err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return mapUnavailable(err)
+		}
+		order, err := fromProtoOrder(resp)
+		if err != nil {
+			return err
+		}
+		if err := send(order); err != nil {
+			return err
 		}
-		orders = append(orders, fromProtoOrder(o))
 	}
-	return orders, nil
+	return nil
+}
+
+func (c *OrderReaderClient) Watch(ctx context.Context, qry *core.WatchOrderQry, send func(*core.OrderChange) error) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	stream, err := c.client.WatchOrders(ctx, toProtoWatchRequest(qry))
+	if err != nil {
+		return mapUnavailable(err)
+	}
+	for {
+		resp, err := stream.Recv()
+		if err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			if status.Code(err) == codes.ResourceExhausted {
+				return fmt.Errorf("%w: %s", core.ErrWatchLagged, status.Convert(err).Message())
+			}
+			return mapUnavailable(err)
+		}
+		change, err := fromProtoChange(resp)
+		if err != nil {
+			return err
+		}
+		if err := send(change); err != nil {
+			return err
+		}
+	}
+}
+
+// Only the page token reason is a bad cursor, any other rejection is about the filters
+func mapInvalidList(err error) error {
+	st := status.Convert(err)
+	for _, d := range st.Details() {
+		if info, ok := d.(*errdetails.ErrorInfo); ok && info.Reason == reasonInvalidPageToken {
+			return fmt.Errorf("%w: %s", core.ErrInvalidCursor, st.Message())
+		}
+	}
+	return fmt.Errorf("%w: %s", core.ErrInvalidListQry, st.Message())
+}
+
+// Lets a fallback reader take over when order_svc can't be reached
+func mapUnavailable(err error) error {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded:
+		return fmt.Errorf("%w: %s", core.ErrReaderUnavailable, status.Convert(err).Message())
+	default:
+		return err
+	}
 }
@@ -0,0 +1,91 @@
+package orderreader
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/Anacardo89/order_svc_hex/order_api/internal/core"
+	pb "github.com/Anacardo89/order_svc_hex/order_api/proto/orderpb"
+)
+
+func TestMapInvalidList(t *testing.T) {
+	st, err := status.New(codes.InvalidArgument, "invalid page_token").WithDetails(&errdetails.ErrorInfo{
+		Reason: reasonInvalidPageToken,
+		Domain: "order_svc",
+	})
+	require.NoError(t, err)
+	assert.ErrorIs(t, mapInvalidList(st.Err()), core.ErrInvalidCursor)
+
+	// Anything without the reason is about the filters, not the cursor
+	err = mapInvalidList(status.Error(codes.InvalidArgument, "limit too large"))
+	assert.ErrorIs(t, err, core.ErrInvalidListQry)
+	assert.NotErrorIs(t, err, core.ErrInvalidCursor)
+}
+
+func TestFromProtoBatch_BadID(t *testing.T) {
+	_, err := fromProtoBatch(&pb.GetOrdersByIDsResponse{
+		Orders: []*pb.Order{{Id: "not-a-uuid"}},
+	})
+	assert.ErrorContains(t, err, "not-a-uuid")
+
+	_, err = fromProtoBatch(&pb.GetOrdersByIDsResponse{MissingIds: []string{"nope"}})
+	assert.ErrorContains(t, err, "nope")
+}
+
+// Answers like an order_svc from before ListOrders
+type legacyServer struct {
+	pb.OrderServiceClient
+	orders []*pb.Order
+}
+
+func (f *legacyServer) ListOrders(ctx context.Context, in *pb.ListOrdersRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[pb.ListOrdersResponse], error) {
+	return &fakeStream[pb.ListOrdersResponse]{err: status.Error(codes.Unimplemented, "unknown method ListOrders")}, nil
+}
+
+func (f *legacyServer) ListOrdersByStatus(ctx context.Context, in *pb.ListOrdersByStatusRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[pb.Order], error) {
+	return &fakeStream[pb.Order]{msgs: f.orders}, nil
+}
+
+type fakeStream[T any] struct {
+	grpc.ClientStream
+	msgs []*T
+	err  error
+}
+
+func (f *fakeStream[T]) Recv() (*T, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	if len(f.msgs) == 0 {
+		return nil, io.EOF
+	}
+	msg := f.msgs[0]
+	f.msgs = f.msgs[1:]
+	return msg, nil
+}
+
+func TestOrderReaderClient_List_LegacyFallback(t *testing.T) {
+	server := &legacyServer{}
+	for range 5 {
+		server.orders = append(server.orders, &pb.Order{Id: uuid.NewString(), Status: pb.OrderStatus_STATUS_CONFIRMED})
+	}
+	c := &OrderReaderClient{client: server}
+
+	page, err := c.List(context.Background(), &core.ListOrdersQry{
+		Filter: core.OrderFilter{Statuses: []core.Status{core.StatusConfirmed}},
+		Limit:  2,
+	})
+	require.NoError(t, err)
+	require.Len(t, page.Orders, 2)
+	assert.Equal(t, server.orders[1].Id, page.Orders[1].ID.String())
+	assert.Empty(t, page.NextCursor)
+}
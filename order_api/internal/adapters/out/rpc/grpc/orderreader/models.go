@@ -1,11 +1,17 @@
 package orderreader
 
 import (
+	"fmt"
+
 	"github.com/Anacardo89/order_svc_hex/order_api/internal/core"
 	pb "github.com/Anacardo89/order_svc_hex/order_api/proto/orderpb"
 	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
+// ErrorInfo reason order_svc sets on a rejected page token
+const reasonInvalidPageToken = "INVALID_PAGE_TOKEN"
+
 func mapStatusToProto(status core.Status) pb.OrderStatus {
 	switch status {
 	case core.StatusPending:
@@ -14,6 +20,14 @@ func mapStatusToProto(status core.Status) pb.OrderStatus {
 		return pb.OrderStatus_STATUS_CONFIRMED
 	case core.StatusFailed:
 		return pb.OrderStatus_STATUS_FAILED
+	case core.StatusCancelled:
+		return pb.OrderStatus_STATUS_CANCELLED
+	case core.StatusShipped:
+		return pb.OrderStatus_STATUS_SHIPPED
+	case core.StatusDelivered:
+		return pb.OrderStatus_STATUS_DELIVERED
+	case core.StatusRefunded:
+		return pb.OrderStatus_STATUS_REFUNDED
 	default:
 		return pb.OrderStatus_STATUS_PENDING
 	}
@@ -27,21 +41,136 @@ func mapStatusToCore(s pb.OrderStatus) core.Status {
 		return core.StatusConfirmed
 	case pb.OrderStatus_STATUS_FAILED:
 		return core.StatusFailed
+	case pb.OrderStatus_STATUS_CANCELLED:
+		return core.StatusCancelled
+	case pb.OrderStatus_STATUS_SHIPPED:
+		return core.StatusShipped
+	case pb.OrderStatus_STATUS_DELIVERED:
+		return core.StatusDelivered
+	case pb.OrderStatus_STATUS_REFUNDED:
+		return core.StatusRefunded
 	default:
 		return ""
 	}
 }
 
-func fromProtoOrder(o *pb.Order) *core.Order {
+func fromProtoOrder(o *pb.Order) (*core.Order, error) {
+	id, err := uuid.Parse(o.Id)
+	if err != nil {
+		return nil, fmt.Errorf("parse order id %q: %w", o.Id, err)
+	}
 	items := make(map[string]int, len(o.Items))
 	for k, v := range o.Items {
 		items[k] = int(v)
 	}
 	return &core.Order{
-		ID:        uuid.MustParse(o.Id),
+		ID:        id,
 		Items:     items,
 		Status:    mapStatusToCore(o.Status),
 		CreatedAt: o.CreatedAt.AsTime(),
 		UpdatedAt: o.UpdatedAt.AsTime(),
+	}, nil
+}
+
+func toProtoBatchRequest(qry *core.GetOrdersByIDsQry) *pb.GetOrdersByIDsRequest {
+	ids := make([]string, 0, len(qry.IDs))
+	for _, id := range qry.IDs {
+		ids = append(ids, id.String())
+	}
+	return &pb.GetOrdersByIDsRequest{Ids: ids}
+}
+
+func fromProtoBatch(resp *pb.GetOrdersByIDsResponse) (*core.OrderBatch, error) {
+	batch := &core.OrderBatch{
+		Orders:  make([]*core.Order, 0, len(resp.Orders)),
+		Missing: make([]uuid.UUID, 0, len(resp.MissingIds)),
+	}
+	for _, o := range resp.Orders {
+		order, err := fromProtoOrder(o)
+		if err != nil {
+			return nil, err
+		}
+		batch.Orders = append(batch.Orders, order)
+	}
+	for _, missing := range resp.MissingIds {
+		id, err := uuid.Parse(missing)
+		if err != nil {
+			return nil, fmt.Errorf("parse missing id %q: %w", missing, err)
+		}
+		batch.Missing = append(batch.Missing, id)
+	}
+	return batch, nil
+}
+
+func mapSortToProto(sort core.OrderSort) pb.OrderSort {
+	switch sort {
+	case core.SortCreatedAtDesc:
+		return pb.OrderSort_SORT_CREATED_AT_DESC
+	case core.SortUpdatedAt:
+		return pb.OrderSort_SORT_UPDATED_AT
+	case core.SortUpdatedAtDesc:
+		return pb.OrderSort_SORT_UPDATED_AT_DESC
+	default:
+		return pb.OrderSort_SORT_CREATED_AT
+	}
+}
+
+func toProtoListRequest(qry *core.ListOrdersQry) *pb.ListOrdersRequest {
+	req := &pb.ListOrdersRequest{
+		Skus:      qry.Filter.SKUs,
+		Sort:      mapSortToProto(qry.Sort),
+		Limit:     int32(qry.Limit),
+		PageToken: qry.Cursor,
+	}
+	for _, s := range qry.Filter.Statuses {
+		req.Statuses = append(req.Statuses, mapStatusToProto(s))
+	}
+	if qry.Filter.CreatedAfter != nil {
+		req.CreatedAfter = timestamppb.New(*qry.Filter.CreatedAfter)
+	}
+	if qry.Filter.CreatedBefore != nil {
+		req.CreatedBefore = timestamppb.New(*qry.Filter.CreatedBefore)
+	}
+	if qry.Filter.UpdatedSince != nil {
+		req.UpdatedSince = timestamppb.New(*qry.Filter.UpdatedSince)
+	}
+	return req
+}
+
+// Only a first page filtered by one status in creation order means the same to ListOrdersByStatus
+func toLegacyListRequest(qry *core.ListOrdersQry) (*pb.ListOrdersByStatusRequest, bool) {
+	f := qry.Filter
+	if len(f.Statuses) != 1 || f.CreatedAfter != nil || f.CreatedBefore != nil || f.UpdatedSince != nil || len(f.SKUs) > 0 {
+		return nil, false
+	}
+	if qry.Cursor != "" || mapSortToProto(qry.Sort) != pb.OrderSort_SORT_CREATED_AT {
+		return nil, false
+	}
+	return &pb.ListOrdersByStatusRequest{Status: mapStatusToProto(f.Statuses[0])}, true
+}
+
+func toProtoWatchRequest(qry *core.WatchOrderQry) *pb.WatchOrdersRequest {
+	req := &pb.WatchOrdersRequest{
+		Ids: []string{qry.ID.String()},
+	}
+	if qry.Since != nil {
+		req.Since = timestamppb.New(*qry.Since)
+	}
+	return req
+}
+
+func fromProtoChange(c *pb.OrderChange) (*core.OrderChange, error) {
+	order, err := fromProtoOrder(c.Order)
+	if err != nil {
+		return nil, err
+	}
+	changeType := core.ChangeUpdated
+	if c.Type == pb.ChangeType_CHANGE_CREATED {
+		changeType = core.ChangeCreated
 	}
+	return &core.OrderChange{
+		Type:      changeType,
+		Order:     order,
+		ChangedAt: c.ChangedAt.AsTime(),
+	}, nil
 }
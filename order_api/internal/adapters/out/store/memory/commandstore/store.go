@@ -0,0 +1,162 @@
+package commandstore
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/Anacardo89/order_svc_hex/order_api/internal/core"
+)
+
+type entry struct {
+	status    core.CommandStatus
+	expiresAt time.Time
+}
+
+type CommandStore struct {
+	mu       sync.Mutex
+	commands map[uuid.UUID]*entry
+	waiters  map[uuid.UUID][]chan struct{}
+	ttl      time.Duration
+	done     chan struct{}
+}
+
+func NewStore(ttl time.Duration) *CommandStore {
+	s := &CommandStore{
+		commands: make(map[uuid.UUID]*entry),
+		waiters:  make(map[uuid.UUID][]chan struct{}),
+		ttl:      ttl,
+		done:     make(chan struct{}),
+	}
+
+	go s.sweepWorker()
+
+	return s
+}
+
+func (s *CommandStore) Track(ctx context.Context, status *core.CommandStatus) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	e, ok := s.commands[status.ID]
+	if !ok {
+		s.commands[status.ID] = &entry{
+			status:    *status,
+			expiresAt: time.Now().Add(s.ttl),
+		}
+		return nil
+	}
+	// Never overwrite an outcome that is already known
+	e.status.Type = status.Type
+	e.status.AcceptedAt = status.AcceptedAt
+	return nil
+}
+
+func (s *CommandStore) Resolve(ctx context.Context, result *core.CommandResult) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	e, ok := s.commands[result.CommandID]
+	if !ok {
+		e = &entry{
+			status: core.CommandStatus{
+				ID: result.CommandID,
+			},
+		}
+		s.commands[result.CommandID] = e
+	}
+	completedAt := result.ProcessedAt
+	if result.OrderID != uuid.Nil {
+		e.status.OrderID = result.OrderID
+	}
+	e.status.State = result.State
+	e.status.Reason = result.Reason
+	e.status.Error = result.Error
+	e.status.CompletedAt = &completedAt
+	e.expiresAt = time.Now().Add(s.ttl)
+	for _, ch := range s.waiters[result.CommandID] {
+		close(ch)
+	}
+	delete(s.waiters, result.CommandID)
+	return nil
+}
+
+func (s *CommandStore) Get(ctx context.Context, qry *core.GetCommandQry) (*core.CommandStatus, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	e, ok := s.commands[qry.ID]
+	if !ok || time.Now().After(e.expiresAt) {
+		return nil, core.ErrCommandNotFound
+	}
+	status := e.status
+	return &status, nil
+}
+
+func (s *CommandStore) Wait(ctx context.Context, qry *core.GetCommandQry) (*core.CommandStatus, error) {
+	s.mu.Lock()
+	e, ok := s.commands[qry.ID]
+	if !ok || time.Now().After(e.expiresAt) {
+		s.mu.Unlock()
+		return nil, core.ErrCommandNotFound
+	}
+	if e.status.State != core.CommandPending {
+		status := e.status
+		s.mu.Unlock()
+		return &status, nil
+	}
+	ch := make(chan struct{})
+	s.waiters[qry.ID] = append(s.waiters[qry.ID], ch)
+	s.mu.Unlock()
+
+	select {
+	case <-ch:
+	case <-ctx.Done():
+		s.removeWaiter(qry.ID, ch)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	status := e.status
+	return &status, nil
+}
+
+func (s *CommandStore) removeWaiter(id uuid.UUID, ch chan struct{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	waiters := s.waiters[id]
+	for i, w := range waiters {
+		if w == ch {
+			waiters = append(waiters[:i], waiters[i+1:]...)
+			break
+		}
+	}
+	if len(waiters) == 0 {
+		delete(s.waiters, id)
+		return
+	}
+	s.waiters[id] = waiters
+}
+
+func (s *CommandStore) Close() {
+	close(s.done)
+}
+
+// background worker
+func (s *CommandStore) sweepWorker() {
+	ticker := time.NewTicker(time.Minute)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-s.done:
+			return
+		case <-ticker.C:
+			now := time.Now()
+			s.mu.Lock()
+			for id, e := range s.commands {
+				if now.After(e.expiresAt) {
+					delete(s.commands, id)
+				}
+			}
+			s.mu.Unlock()
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package idempotencystore
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/Anacardo89/order_svc_hex/order_api/internal/core"
+)
+
+type IdempotencyStore struct {
+	mu      sync.Mutex
+	records map[string]*core.IdempotencyRecord
+	ttl     time.Duration
+	done    chan struct{}
+}
+
+func NewStore(ttl time.Duration) *IdempotencyStore {
+	s := &IdempotencyStore{
+		records: make(map[string]*core.IdempotencyRecord),
+		ttl:     ttl,
+		done:    make(chan struct{}),
+	}
+
+	go s.sweepWorker()
+
+	return s
+}
+
+func (s *IdempotencyStore) Reserve(ctx context.Context, key, requestHash string) (*core.IdempotencyRecord, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := time.Now().UTC()
+	if rec, ok := s.records[key]; ok && now.Before(rec.ExpiresAt) {
+		existing := *rec
+		return &existing, nil
+	}
+	s.records[key] = &core.IdempotencyRecord{
+		Key:         key,
+		RequestHash: requestHash,
+		CreatedAt:   now,
+		ExpiresAt:   now.Add(s.ttl),
+	}
+	return nil, nil
+}
+
+func (s *IdempotencyStore) Complete(ctx context.Context, rec *core.IdempotencyRecord) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	stored, ok := s.records[rec.Key]
+	if !ok {
+		return nil
+	}
+	stored.StatusCode = rec.StatusCode
+	stored.Headers = rec.Headers
+	stored.Body = rec.Body
+	stored.Completed = true
+	return nil
+}
+
+// Only frees a key still in progress, a completed one keeps answering replays
+func (s *IdempotencyStore) Release(ctx context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if rec, ok := s.records[key]; ok && !rec.Completed {
+		delete(s.records, key)
+	}
+	return nil
+}
+
+func (s *IdempotencyStore) Close() {
+	close(s.done)
+}
+
+// background worker
+func (s *IdempotencyStore) sweepWorker() {
+	ticker := time.NewTicker(time.Minute)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-s.done:
+			return
+		case <-ticker.C:
+			now := time.Now().UTC()
+			s.mu.Lock()
+			for k, rec := range s.records {
+				if !now.Before(rec.ExpiresAt) {
+					delete(s.records, k)
+				}
+			}
+			s.mu.Unlock()
+		}
+	}
+}
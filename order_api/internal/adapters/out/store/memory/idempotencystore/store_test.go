@@ -0,0 +1,90 @@
+package idempotencystore
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Anacardo89/order_svc_hex/order_api/internal/core"
+)
+
+func TestIdempotencyStore(t *testing.T) {
+	ctx := context.Background()
+	completed := &core.IdempotencyRecord{
+		Key:        "key-1",
+		StatusCode: 202,
+		Headers:    map[string]string{"Location": "/orders/1"},
+		Body:       []byte(`{"id": "1"}`),
+	}
+
+	tests := []struct {
+		name          string
+		steps         func(s *IdempotencyStore)
+		wantReplay    bool
+		wantCompleted bool
+	}{
+		{
+			name:  "free key is reserved",
+			steps: func(s *IdempotencyStore) {},
+		},
+		{
+			name: "key in progress is replayed unfinished",
+			steps: func(s *IdempotencyStore) {
+				_, _ = s.Reserve(ctx, "key-1", "hash")
+			},
+			wantReplay: true,
+		},
+		{
+			name: "completed key replays the response",
+			steps: func(s *IdempotencyStore) {
+				_, _ = s.Reserve(ctx, "key-1", "hash")
+				require.NoError(t, s.Complete(ctx, completed))
+			},
+			wantReplay:    true,
+			wantCompleted: true,
+		},
+		{
+			name: "released key is reserved again",
+			steps: func(s *IdempotencyStore) {
+				_, _ = s.Reserve(ctx, "key-1", "hash")
+				require.NoError(t, s.Release(ctx, "key-1"))
+			},
+		},
+		{
+			name: "release keeps a completed key",
+			steps: func(s *IdempotencyStore) {
+				_, _ = s.Reserve(ctx, "key-1", "hash")
+				require.NoError(t, s.Complete(ctx, completed))
+				require.NoError(t, s.Release(ctx, "key-1"))
+			},
+			wantReplay:    true,
+			wantCompleted: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewStore(time.Hour)
+			defer s.Close()
+			tt.steps(s)
+
+			rec, err := s.Reserve(ctx, "key-1", "hash")
+			require.NoError(t, err)
+			if !tt.wantReplay {
+				assert.Nil(t, rec)
+				return
+			}
+			require.NotNil(t, rec)
+			assert.Equal(t, "hash", rec.RequestHash)
+			assert.Equal(t, tt.wantCompleted, rec.Completed)
+			if tt.wantCompleted {
+				assert.Equal(t, completed.StatusCode, rec.StatusCode)
+				assert.Equal(t, completed.Headers, rec.Headers)
+				assert.Equal(t, completed.Body, rec.Body)
+			}
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package ordercache
+
+import (
+	"container/list"
+	"context"
+	"maps"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/Anacardo89/order_svc_hex/order_api/internal/core"
+)
+
+const defaultCapacity = 10000
+
+type entry struct {
+	order     core.Order
+	expiresAt time.Time
+}
+
+// LRU bounded by capacity, expired entries are dropped when read or evicted
+type OrderCache struct {
+	mu       sync.Mutex
+	entries  map[uuid.UUID]*list.Element
+	lru      *list.List
+	capacity int
+}
+
+func NewCache(capacity int) *OrderCache {
+	if capacity <= 0 {
+		capacity = defaultCapacity
+	}
+	return &OrderCache{
+		entries:  make(map[uuid.UUID]*list.Element),
+		lru:      list.New(),
+		capacity: capacity,
+	}
+}
+
+func (c *OrderCache) Get(ctx context.Context, id uuid.UUID) (*core.Order, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	el, ok := c.entries[id]
+	if !ok {
+		return nil, nil
+	}
+	e := el.Value.(*entry)
+	if !time.Now().Before(e.expiresAt) {
+		c.remove(el)
+		return nil, nil
+	}
+	c.lru.MoveToFront(el)
+	return copyOrder(&e.order), nil
+}
+
+func (c *OrderCache) Set(ctx context.Context, order *core.Order, ttl time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e := &entry{
+		order:     *copyOrder(order),
+		expiresAt: time.Now().Add(ttl),
+	}
+	if el, ok := c.entries[order.ID]; ok {
+		el.Value = e
+		c.lru.MoveToFront(el)
+		return nil
+	}
+	c.entries[order.ID] = c.lru.PushFront(e)
+	if c.lru.Len() > c.capacity {
+		c.remove(c.lru.Back())
+	}
+	return nil
+}
+
+func (c *OrderCache) Delete(ctx context.Context, id uuid.UUID) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if el, ok := c.entries[id]; ok {
+		c.remove(el)
+	}
+	return nil
+}
+
+// Items is a map, sharing it would let callers change what is cached
+func copyOrder(o *core.Order) *core.Order {
+	order := *o
+	order.Items = maps.Clone(o.Items)
+	return &order
+}
+
+func (c *OrderCache) remove(el *list.Element) {
+	e := c.lru.Remove(el).(*entry)
+	delete(c.entries, e.order.ID)
+}
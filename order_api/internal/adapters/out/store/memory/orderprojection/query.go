@@ -0,0 +1,152 @@
+package orderprojection
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/Anacardo89/order_svc_hex/order_api/internal/core"
+)
+
+// Same bounds order_svc applies
+const (
+	defaultPageSize = 50
+	maxPageSize     = 500
+)
+
+type pageCursor struct {
+	sortKey time.Time
+	id      uuid.UUID
+}
+
+func pageSize(limit int) int {
+	switch {
+	case limit <= 0:
+		return defaultPageSize
+	case limit > maxPageSize:
+		return maxPageSize
+	default:
+		return limit
+	}
+}
+
+func sortKey(o *core.Order, sort core.OrderSort) (time.Time, bool) {
+	switch sort {
+	case core.SortCreatedAtDesc:
+		return o.CreatedAt, true
+	case core.SortUpdatedAt:
+		return o.UpdatedAt, false
+	case core.SortUpdatedAtDesc:
+		return o.UpdatedAt, true
+	default:
+		return o.CreatedAt, false
+	}
+}
+
+// Orders by (sort key, id) like the keyset query in order_svc
+func compareOrders(a, b *core.Order, sort core.OrderSort) int {
+	ka, desc := sortKey(a, sort)
+	kb, _ := sortKey(b, sort)
+	c := ka.Compare(kb)
+	if c == 0 {
+		c = bytes.Compare(a.ID[:], b.ID[:])
+	}
+	if desc {
+		return -c
+	}
+	return c
+}
+
+func matches(o *core.Order, f core.OrderFilter) bool {
+	if len(f.Statuses) > 0 && !slices.Contains(f.Statuses, o.Status) {
+		return false
+	}
+	if f.CreatedAfter != nil && !o.CreatedAt.After(*f.CreatedAfter) {
+		return false
+	}
+	if f.CreatedBefore != nil && !o.CreatedAt.Before(*f.CreatedBefore) {
+		return false
+	}
+	if f.UpdatedSince != nil && o.UpdatedAt.Before(*f.UpdatedSince) {
+		return false
+	}
+	for _, sku := range f.SKUs {
+		if _, ok := o.Items[sku]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// Copies of the matching orders past the cursor, and whether more follow
+func selectPage(orders map[uuid.UUID]*core.Order, qry *core.ListOrdersQry, after *pageCursor) ([]*core.Order, bool) {
+	var cursor *core.Order
+	if after != nil {
+		cursor = &core.Order{ID: after.id, CreatedAt: after.sortKey, UpdatedAt: after.sortKey}
+	}
+	var selected []*core.Order
+	for _, o := range orders {
+		if !matches(o, qry.Filter) {
+			continue
+		}
+		if cursor != nil && compareOrders(o, cursor, qry.Sort) <= 0 {
+			continue
+		}
+		found := *o
+		selected = append(selected, &found)
+	}
+	slices.SortFunc(selected, func(a, b *core.Order) int {
+		return compareOrders(a, b, qry.Sort)
+	})
+	limit := pageSize(qry.Limit)
+	if len(selected) > limit {
+		return selected[:limit], true
+	}
+	return selected, false
+}
+
+// base64 of "<sort>|<sort key>|<id>", as issued by order_svc.
+// contracts/orders/testdata/page_tokens.json holds the tokens both sides are tested against
+func encodePageToken(last *core.Order, sort core.OrderSort) string {
+	key, _ := sortKey(last, sort)
+	raw := strings.Join([]string{
+		string(sort),
+		key.UTC().Format(time.RFC3339Nano),
+		last.ID.String(),
+	}, "|")
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func decodePageToken(token string, sort core.OrderSort) (*pageCursor, error) {
+	if token == "" {
+		return nil, nil
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, err
+	}
+	parts := strings.Split(string(raw), "|")
+	if len(parts) != 3 {
+		return nil, errors.New("malformed page token")
+	}
+	if core.OrderSort(parts[0]) != sort {
+		return nil, errors.New("page token issued for a different sort")
+	}
+	key, err := time.Parse(time.RFC3339Nano, parts[1])
+	if err != nil {
+		return nil, err
+	}
+	id, err := uuid.Parse(parts[2])
+	if err != nil {
+		return nil, err
+	}
+	return &pageCursor{
+		sortKey: key,
+		id:      id,
+	}, nil
+}
@@ -0,0 +1,42 @@
+package orderprojection
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Anacardo89/order_svc_hex/order_api/internal/core"
+)
+
+// Shared with order_svc's page token test, both sides have to keep reading each other's tokens
+const pageTokensFixture = "../../../../../../../contracts/orders/testdata/page_tokens.json"
+
+func TestPageToken_MatchesOrderSvc(t *testing.T) {
+	raw, err := os.ReadFile(pageTokensFixture)
+	require.NoError(t, err)
+	var fixtures []struct {
+		Sort    core.OrderSort `json:"sort"`
+		SortKey time.Time      `json:"sort_key"`
+		ID      uuid.UUID      `json:"id"`
+		Token   string         `json:"token"`
+	}
+	require.NoError(t, json.Unmarshal(raw, &fixtures))
+	require.NotEmpty(t, fixtures)
+
+	for _, f := range fixtures {
+		t.Run(string(f.Sort), func(t *testing.T) {
+			last := &core.Order{ID: f.ID, CreatedAt: f.SortKey, UpdatedAt: f.SortKey}
+			assert.Equal(t, f.Token, encodePageToken(last, f.Sort))
+
+			cursor, err := decodePageToken(f.Token, f.Sort)
+			require.NoError(t, err)
+			assert.True(t, f.SortKey.Equal(cursor.sortKey))
+			assert.Equal(t, f.ID, cursor.id)
+		})
+	}
+}
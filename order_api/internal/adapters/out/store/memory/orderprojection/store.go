@@ -0,0 +1,124 @@
+package orderprojection
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+
+	"github.com/google/uuid"
+
+	"github.com/Anacardo89/order_svc_hex/order_api/internal/core"
+)
+
+var errCatchingUp = fmt.Errorf("%w: local read model is still catching up", core.ErrReaderUnavailable)
+
+// In-memory read model, rebuilt from the events topic on every start.
+// Reads fail until the consumer reports it caught up, a half loaded model would answer with stale orders
+type OrderProjection struct {
+	mu       sync.RWMutex
+	orders   map[uuid.UUID]*core.Order
+	caughtUp atomic.Bool
+}
+
+func NewProjection() *OrderProjection {
+	return &OrderProjection{
+		orders: make(map[uuid.UUID]*core.Order),
+	}
+}
+
+func (p *OrderProjection) Apply(ctx context.Context, order *core.Order) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if current, ok := p.orders[order.ID]; ok && order.UpdatedAt.Before(current.UpdatedAt) {
+		return nil
+	}
+	stored := *order
+	p.orders[order.ID] = &stored
+	return nil
+}
+
+func (p *OrderProjection) SetCaughtUp(caughtUp bool) {
+	p.caughtUp.Store(caughtUp)
+}
+
+func (p *OrderProjection) GetByID(ctx context.Context, qry *core.GetOrderQry) (*core.Order, error) {
+	if !p.caughtUp.Load() {
+		return nil, errCatchingUp
+	}
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	order, ok := p.orders[qry.ID]
+	if !ok {
+		return nil, core.ErrOrderNotFound
+	}
+	found := *order
+	return &found, nil
+}
+
+func (p *OrderProjection) GetByIDs(ctx context.Context, qry *core.GetOrdersByIDsQry) (*core.OrderBatch, error) {
+	if !p.caughtUp.Load() {
+		return nil, errCatchingUp
+	}
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	batch := &core.OrderBatch{
+		Orders: make([]*core.Order, 0, len(qry.IDs)),
+	}
+	seen := make(map[uuid.UUID]struct{}, len(qry.IDs))
+	for _, id := range qry.IDs {
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+		if order, ok := p.orders[id]; ok {
+			found := *order
+			batch.Orders = append(batch.Orders, &found)
+		} else {
+			batch.Missing = append(batch.Missing, id)
+		}
+	}
+	return batch, nil
+}
+
+func (p *OrderProjection) List(ctx context.Context, qry *core.ListOrdersQry) (*core.OrderPage, error) {
+	page := &core.OrderPage{}
+	next, err := p.Stream(ctx, qry, func(o *core.Order) error {
+		page.Orders = append(page.Orders, o)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	page.NextCursor = next
+	return page, nil
+}
+
+// Page tokens use the same format as order_svc, so paging can continue across read paths
+func (p *OrderProjection) Stream(ctx context.Context, qry *core.ListOrdersQry, send func(*core.Order) error) (string, error) {
+	if !p.caughtUp.Load() {
+		return "", errCatchingUp
+	}
+	after, err := decodePageToken(qry.Cursor, qry.Sort)
+	if err != nil {
+		return "", fmt.Errorf("%w: %s", core.ErrInvalidCursor, err)
+	}
+	p.mu.RLock()
+	orders, more := selectPage(p.orders, qry, after)
+	p.mu.RUnlock()
+	for _, o := range orders {
+		if err := send(o); err != nil {
+			return "", err
+		}
+	}
+	if !more || len(orders) == 0 {
+		return "", nil
+	}
+	return encodePageToken(orders[len(orders)-1], qry.Sort), nil
+}
+
+// Live changes only come from order_svc
+func (p *OrderProjection) Watch(ctx context.Context, qry *core.WatchOrderQry, send func(*core.OrderChange) error) error {
+	return fmt.Errorf("watch on the local read model: %w", errors.ErrUnsupported)
+}
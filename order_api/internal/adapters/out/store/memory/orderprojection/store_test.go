@@ -0,0 +1,107 @@
+package orderprojection
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Anacardo89/order_svc_hex/order_api/internal/core"
+)
+
+func TestOrderProjection_Apply(t *testing.T) {
+	ctx := context.Background()
+	id := uuid.MustParse("11111111-1111-1111-1111-111111111111")
+	t0 := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	p := NewProjection()
+
+	require.NoError(t, p.Apply(ctx, &core.Order{ID: id, Status: core.StatusConfirmed, UpdatedAt: t0.Add(time.Second)}))
+	// Redelivered older event must not roll the order back
+	require.NoError(t, p.Apply(ctx, &core.Order{ID: id, Status: core.StatusPending, UpdatedAt: t0}))
+
+	// Nothing is served before the consumer caught up
+	_, err := p.GetByID(ctx, &core.GetOrderQry{ID: id})
+	assert.ErrorIs(t, err, core.ErrReaderUnavailable)
+	_, err = p.List(ctx, &core.ListOrdersQry{})
+	assert.ErrorIs(t, err, core.ErrReaderUnavailable)
+
+	p.SetCaughtUp(true)
+	order, err := p.GetByID(ctx, &core.GetOrderQry{ID: id})
+	require.NoError(t, err)
+	assert.Equal(t, core.StatusConfirmed, order.Status)
+
+	_, err = p.GetByID(ctx, &core.GetOrderQry{ID: uuid.MustParse("aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaaa")})
+	assert.ErrorIs(t, err, core.ErrOrderNotFound)
+}
+
+func TestOrderProjection_List(t *testing.T) {
+	ctx := context.Background()
+	t0 := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	p := NewProjection()
+	ids := []uuid.UUID{
+		uuid.MustParse("11111111-1111-1111-1111-111111111111"),
+		uuid.MustParse("22222222-2222-2222-2222-222222222222"),
+		uuid.MustParse("33333333-3333-3333-3333-333333333333"),
+		uuid.MustParse("44444444-4444-4444-4444-444444444444"),
+	}
+	for i, id := range ids {
+		status := core.StatusPending
+		if i == 2 {
+			status = core.StatusFailed
+		}
+		created := t0.Add(time.Duration(i) * time.Minute)
+		require.NoError(t, p.Apply(ctx, &core.Order{
+			ID:        id,
+			Items:     map[string]int{"sku_1": 1},
+			Status:    status,
+			CreatedAt: created,
+			UpdatedAt: created,
+		}))
+	}
+	p.SetCaughtUp(true)
+
+	tests := []struct {
+		name  string
+		qry   core.ListOrdersQry
+		pages [][]uuid.UUID
+	}{
+		{
+			name:  "pages in creation order",
+			qry:   core.ListOrdersQry{Sort: core.SortCreatedAt, Limit: 3},
+			pages: [][]uuid.UUID{{ids[0], ids[1], ids[2]}, {ids[3]}},
+		},
+		{
+			name: "filtered newest first",
+			qry: core.ListOrdersQry{
+				Filter: core.OrderFilter{Statuses: []core.Status{core.StatusPending}},
+				Sort:   core.SortCreatedAtDesc,
+				Limit:  2,
+			},
+			pages: [][]uuid.UUID{{ids[3], ids[1]}, {ids[0]}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			qry := tt.qry
+			for i, want := range tt.pages {
+				page, err := p.List(ctx, &qry)
+				require.NoError(t, err)
+				got := make([]uuid.UUID, 0, len(page.Orders))
+				for _, o := range page.Orders {
+					got = append(got, o.ID)
+				}
+				assert.Equal(t, want, got)
+				if i == len(tt.pages)-1 {
+					assert.Empty(t, page.NextCursor)
+				} else {
+					require.NotEmpty(t, page.NextCursor)
+				}
+				qry.Cursor = page.NextCursor
+			}
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package idempotencystore
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Anacardo89/order_svc_hex/order_api/internal/adapters/infra/log/loki/logger"
+	"github.com/Anacardo89/order_svc_hex/order_api/pkg/db"
+	"github.com/Anacardo89/order_svc_hex/order_api/pkg/testutils"
+)
+
+var store *IdempotencyStore
+
+func TestMain(m *testing.M) {
+	ctx := context.Background()
+	logger.BaseLogger = logger.NopLogger{}
+	dsn, closeDB, err := testutils.StartPostgresContainer(ctx)
+	if err != nil {
+		fmt.Println("Failed to start test environment:", err)
+		os.Exit(1)
+	}
+	code := func() int {
+		defer closeDB()
+		migratePath, err := testutils.BuildPath(filepath.Join("db", "migrations"))
+		if err != nil {
+			fmt.Println("Failed to find migrations:", err)
+			return 1
+		}
+		if err := db.Migrate(dsn, migratePath, db.MigrateUp); err != nil {
+			fmt.Println("Failed to migrate:", err)
+			return 1
+		}
+		pool, err := db.Connect(dsn, 5, 1, 2*time.Minute, 2*time.Minute)
+		if err != nil {
+			fmt.Println("Failed to connect:", err)
+			return 1
+		}
+		store = NewStore(pool, time.Hour)
+		defer store.Close()
+		return m.Run()
+	}()
+	os.Exit(code)
+}
@@ -0,0 +1,23 @@
+package idempotencystore
+
+import (
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type IdempotencyStore struct {
+	pool *pgxpool.Pool
+	ttl  time.Duration
+}
+
+func NewStore(pool *pgxpool.Pool, ttl time.Duration) *IdempotencyStore {
+	return &IdempotencyStore{
+		pool: pool,
+		ttl:  ttl,
+	}
+}
+
+func (s *IdempotencyStore) Close() {
+	s.pool.Close()
+}
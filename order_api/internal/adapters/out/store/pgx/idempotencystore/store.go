@@ -0,0 +1,205 @@
+package idempotencystore
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/Anacardo89/order_svc_hex/order_api/internal/adapters/infra/log/loki/logger"
+	"github.com/Anacardo89/order_svc_hex/order_api/internal/core"
+	"github.com/Anacardo89/order_svc_hex/order_api/internal/ports"
+)
+
+var (
+	tracer = otel.Tracer("order_api.postgres")
+)
+
+// A key released between the insert and the read is reserved again, bounded in case it keeps flapping
+const maxReserveAttempts = 3
+
+var errReserveContended = errors.New("idempotency key kept changing while reserving")
+
+func (s *IdempotencyStore) Reserve(ctx context.Context, key, requestHash string) (*core.IdempotencyRecord, error) {
+	// Observability
+	ctx, span := tracer.Start(ctx, "db.idempotency_keys.reserve",
+		trace.WithAttributes(
+			attribute.String("db.system", "postgresql"),
+			attribute.String("db.operation", "INSERT"),
+			attribute.String("db.sql.table", "idempotency_keys"),
+		),
+	)
+	log := logger.BaseLogger
+	defer span.End()
+
+	// Execution
+	// Expired keys are taken over in place
+	query := `
+		INSERT INTO idempotency_keys (
+			key,
+			request_hash,
+			expires_at
+		)
+		VALUES (
+			$1,
+			$2,
+			$3
+		)
+		ON CONFLICT (key) DO UPDATE
+		SET
+			request_hash = EXCLUDED.request_hash,
+			status_code  = NULL,
+			headers      = NULL,
+			body         = NULL,
+			completed    = FALSE,
+			created_at   = NOW(),
+			expires_at   = EXCLUDED.expires_at
+		WHERE idempotency_keys.expires_at < NOW()
+		RETURNING key
+	;`
+	for range maxReserveAttempts {
+		var reserved string
+		err := s.pool.QueryRow(ctx, query, key, requestHash, time.Now().UTC().Add(s.ttl)).Scan(&reserved)
+		if err == nil {
+			return nil, nil
+		}
+		if !errors.Is(err, pgx.ErrNoRows) {
+			log.Error(ctx, "query failed", ports.Field{Key: "error", Value: err})
+			return failQueryRow[core.IdempotencyRecord](span, "query failed", err)
+		}
+		rec, err := s.get(ctx, key)
+		if err != nil {
+			return nil, err
+		}
+		if rec != nil {
+			span.SetAttributes(attribute.Bool("idempotency.replay", true))
+			return rec, nil
+		}
+	}
+	log.Error(ctx, "reserve contended", ports.Field{Key: "key", Value: key})
+	return failQueryRow[core.IdempotencyRecord](span, "reserve contended", errReserveContended)
+}
+
+// Nil when the key is gone, released since the caller saw it taken
+func (s *IdempotencyStore) get(ctx context.Context, key string) (*core.IdempotencyRecord, error) {
+	// Observability
+	ctx, span := tracer.Start(ctx, "db.idempotency_keys.get",
+		trace.WithAttributes(
+			attribute.String("db.system", "postgresql"),
+			attribute.String("db.operation", "SELECT"),
+			attribute.String("db.sql.table", "idempotency_keys"),
+		),
+	)
+	log := logger.BaseLogger
+	defer span.End()
+
+	// Execution
+	query := `
+		SELECT
+			key,
+			request_hash,
+			COALESCE(status_code, 0),
+			headers,
+			body,
+			completed,
+			created_at,
+			expires_at
+		FROM idempotency_keys
+		WHERE key = $1
+	;`
+	var (
+		rec     core.IdempotencyRecord
+		headers []byte
+	)
+	if err := s.pool.QueryRow(ctx, query, key).Scan(
+		&rec.Key,
+		&rec.RequestHash,
+		&rec.StatusCode,
+		&headers,
+		&rec.Body,
+		&rec.Completed,
+		&rec.CreatedAt,
+		&rec.ExpiresAt,
+	); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		log.Error(ctx, "scan failed", ports.Field{Key: "error", Value: err})
+		return failQueryRow[core.IdempotencyRecord](span, "scan failed", err)
+	}
+	if len(headers) > 0 {
+		if err := json.Unmarshal(headers, &rec.Headers); err != nil {
+			log.Error(ctx, "unmarshal headers failed", ports.Field{Key: "error", Value: err})
+			return failQueryRow[core.IdempotencyRecord](span, "unmarshal headers failed", err)
+		}
+	}
+	return &rec, nil
+}
+
+func (s *IdempotencyStore) Complete(ctx context.Context, rec *core.IdempotencyRecord) error {
+	// Observability
+	ctx, span := tracer.Start(ctx, "db.idempotency_keys.complete",
+		trace.WithAttributes(
+			attribute.String("db.system", "postgresql"),
+			attribute.String("db.operation", "UPDATE"),
+			attribute.String("db.sql.table", "idempotency_keys"),
+		),
+	)
+	log := logger.BaseLogger
+	defer span.End()
+
+	// Execution
+	query := `
+		UPDATE idempotency_keys
+		SET
+			status_code = $2,
+			headers     = $3,
+			body        = $4,
+			completed   = TRUE
+		WHERE key = $1
+	;`
+	headers, err := json.Marshal(rec.Headers)
+	if err != nil {
+		log.Error(ctx, "marshal headers failed", ports.Field{Key: "error", Value: err})
+		return failExec(span, "marshal headers failed", err)
+	}
+	tag, err := s.pool.Exec(ctx, query, rec.Key, rec.StatusCode, headers, rec.Body)
+	if err != nil {
+		log.Error(ctx, "query failed", ports.Field{Key: "error", Value: err})
+		return failExec(span, "query failed", err)
+	}
+	span.SetAttributes(attribute.Int64("db.rows_affected", tag.RowsAffected()))
+	return nil
+}
+
+func (s *IdempotencyStore) Release(ctx context.Context, key string) error {
+	// Observability
+	ctx, span := tracer.Start(ctx, "db.idempotency_keys.release",
+		trace.WithAttributes(
+			attribute.String("db.system", "postgresql"),
+			attribute.String("db.operation", "DELETE"),
+			attribute.String("db.sql.table", "idempotency_keys"),
+		),
+	)
+	log := logger.BaseLogger
+	defer span.End()
+
+	// Execution
+	query := `
+		DELETE FROM idempotency_keys
+		WHERE key = $1
+		AND completed = FALSE
+	;`
+	tag, err := s.pool.Exec(ctx, query, key)
+	if err != nil {
+		log.Error(ctx, "query failed", ports.Field{Key: "error", Value: err})
+		return failExec(span, "query failed", err)
+	}
+	span.SetAttributes(attribute.Int64("db.rows_affected", tag.RowsAffected()))
+	return nil
+}
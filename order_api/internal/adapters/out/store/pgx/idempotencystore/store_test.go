@@ -0,0 +1,101 @@
+package idempotencystore
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Anacardo89/order_svc_hex/order_api/internal/core"
+)
+
+func TestIdempotencyStore(t *testing.T) {
+	ctx := context.Background()
+	completed := &core.IdempotencyRecord{
+		StatusCode: 202,
+		Headers:    map[string]string{"Location": "/orders/1"},
+		Body:       []byte(`{"id": "1"}`),
+	}
+	completedAs := func(key string) *core.IdempotencyRecord {
+		rec := *completed
+		rec.Key = key
+		return &rec
+	}
+
+	tests := []struct {
+		name          string
+		steps         func(key string)
+		wantReplay    bool
+		wantCompleted bool
+	}{
+		{
+			name:  "free key is reserved",
+			steps: func(key string) {},
+		},
+		{
+			name: "key in progress is replayed unfinished",
+			steps: func(key string) {
+				_, _ = store.Reserve(ctx, key, "hash")
+			},
+			wantReplay: true,
+		},
+		{
+			name: "completed key replays the response",
+			steps: func(key string) {
+				_, _ = store.Reserve(ctx, key, "hash")
+				require.NoError(t, store.Complete(ctx, completedAs(key)))
+			},
+			wantReplay:    true,
+			wantCompleted: true,
+		},
+		{
+			name: "released key is reserved again",
+			steps: func(key string) {
+				_, _ = store.Reserve(ctx, key, "hash")
+				require.NoError(t, store.Release(ctx, key))
+			},
+		},
+		{
+			name: "release keeps a completed key",
+			steps: func(key string) {
+				_, _ = store.Reserve(ctx, key, "hash")
+				require.NoError(t, store.Complete(ctx, completedAs(key)))
+				require.NoError(t, store.Release(ctx, key))
+			},
+			wantReplay:    true,
+			wantCompleted: true,
+		},
+	}
+
+	// The database is shared, every case gets its own key
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key := fmt.Sprintf("key-%d", i)
+			tt.steps(key)
+
+			rec, err := store.Reserve(ctx, key, "hash")
+			require.NoError(t, err)
+			if !tt.wantReplay {
+				assert.Nil(t, rec)
+				return
+			}
+			require.NotNil(t, rec)
+			assert.Equal(t, "hash", rec.RequestHash)
+			assert.Equal(t, tt.wantCompleted, rec.Completed)
+			if tt.wantCompleted {
+				assert.Equal(t, completed.StatusCode, rec.StatusCode)
+				assert.Equal(t, completed.Headers, rec.Headers)
+				assert.Equal(t, completed.Body, rec.Body)
+			}
+		})
+	}
+}
+
+func TestIdempotencyStore_GetReleasedKey(t *testing.T) {
+	// Reserve takes a missing row after a conflict as released and tries again
+	rec, err := store.get(context.Background(), "never-reserved")
+	require.NoError(t, err)
+	assert.Nil(t, rec)
+}
@@ -1,11 +1,21 @@
 package core
 
 import (
+	"errors"
 	"time"
 
 	"github.com/google/uuid"
 )
 
+var (
+	ErrInvalidCursor  = errors.New("invalid cursor")
+	ErrInvalidListQry = errors.New("invalid list query")
+	ErrInvalidBatch   = errors.New("invalid batch")
+	ErrOrderNotFound  = errors.New("order not found")
+	// The read path can't be reached right now, another one may answer
+	ErrReaderUnavailable = errors.New("order reader unavailable")
+)
+
 type Order struct {
 	ID        uuid.UUID      `json:"id"`
 	Items     map[string]int `json:"items"`
@@ -16,13 +26,16 @@ type Order struct {
 
 // Commands
 type CreateOrderCmd struct {
-	Items  map[string]int `json:"items" validate:"required"`
-	Status Status         `json:"status"`
+	CommandID uuid.UUID      `json:"command_id"`
+	ID        uuid.UUID      `json:"id"`
+	Items     map[string]int `json:"items" validate:"required"`
+	Status    Status         `json:"status"`
 }
 
 type UpdateOrderStatusCmd struct {
-	ID     string `json:"id" validate:"required"`
-	Status Status `json:"status" validate:"required"`
+	CommandID uuid.UUID `json:"command_id"`
+	ID        string    `json:"id" validate:"required"`
+	Status    Status    `json:"status" validate:"required"`
 }
 
 // Queries
@@ -30,6 +43,57 @@ type GetOrderQry struct {
 	ID uuid.UUID
 }
 
-type ListOrdersByStatusQry struct {
-	Status Status
+type GetOrdersByIDsQry struct {
+	IDs []uuid.UUID
+}
+
+type ListOrdersQry struct {
+	Filter OrderFilter
+	Sort   OrderSort
+	Limit  int
+	Cursor string
+}
+
+// Results
+type OrderPage struct {
+	Orders     []*Order
+	NextCursor string
+}
+
+type OrderBatch struct {
+	Orders  []*Order
+	Missing []uuid.UUID
+}
+
+// Zero values mean no filtering on that field
+type OrderFilter struct {
+	Statuses      []Status
+	CreatedAfter  *time.Time
+	CreatedBefore *time.Time
+	UpdatedSince  *time.Time
+	SKUs          []string
+}
+
+type OrderSort string
+
+const (
+	SortCreatedAt     OrderSort = "created_at"
+	SortCreatedAtDesc OrderSort = "-created_at"
+	SortUpdatedAt     OrderSort = "updated_at"
+	SortUpdatedAtDesc OrderSort = "-updated_at"
+)
+
+func MapStrToSort(s string) (OrderSort, error) {
+	switch s {
+	case "", string(SortCreatedAt):
+		return SortCreatedAt, nil
+	case string(SortCreatedAtDesc):
+		return SortCreatedAtDesc, nil
+	case string(SortUpdatedAt):
+		return SortUpdatedAt, nil
+	case string(SortUpdatedAtDesc):
+		return SortUpdatedAtDesc, nil
+	default:
+		return "", errors.New("unknown sort")
+	}
 }
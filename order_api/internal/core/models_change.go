@@ -0,0 +1,31 @@
+package core
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// order_svc dropped the subscription, resume from the last change seen
+var ErrWatchLagged = errors.New("watch fell behind")
+
+type ChangeType string
+
+const (
+	ChangeCreated ChangeType = "created"
+	ChangeUpdated ChangeType = "updated"
+)
+
+type OrderChange struct {
+	Type      ChangeType `json:"type"`
+	Order     *Order     `json:"order"`
+	ChangedAt time.Time  `json:"changed_at"`
+}
+
+// Queries
+// Since replays changes from that point on, nil means live changes only
+type WatchOrderQry struct {
+	ID    uuid.UUID
+	Since *time.Time
+}
@@ -0,0 +1,56 @@
+package core
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var ErrCommandNotFound = errors.New("command not found")
+
+type CommandState string
+
+const (
+	CommandPending  CommandState = "pending"
+	CommandApplied  CommandState = "applied"
+	CommandRejected CommandState = "rejected"
+)
+
+type CommandType string
+
+const (
+	CommandCreateOrder       CommandType = "create_order"
+	CommandUpdateOrderStatus CommandType = "update_order_status"
+)
+
+type CommandStatus struct {
+	ID          uuid.UUID    `json:"id"`
+	Type        CommandType  `json:"type,omitempty"`
+	OrderID     uuid.UUID    `json:"order_id"`
+	State       CommandState `json:"state"`
+	Reason      string       `json:"reason,omitempty"`
+	Error       string       `json:"error,omitempty"`
+	AcceptedAt  *time.Time   `json:"accepted_at,omitempty"`
+	CompletedAt *time.Time   `json:"completed_at,omitempty"`
+}
+
+// Outcome reported back by order_svc
+type CommandResult struct {
+	CommandID   uuid.UUID
+	OrderID     uuid.UUID
+	State       CommandState
+	Reason      string
+	Error       string
+	ProcessedAt time.Time
+}
+
+// Queries
+type GetCommandQry struct {
+	ID uuid.UUID
+}
+
+type WaitCommandQry struct {
+	ID      uuid.UUID
+	Timeout time.Duration
+}
@@ -0,0 +1,87 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+var (
+	ErrDeadLetterNotFound   = errors.New("dead letter not found")
+	ErrDeadLetterNotOpen    = errors.New("dead letter is not open")
+	ErrInvalidDeadLetterQry = errors.New("invalid dead letter query")
+)
+
+type DeadLetterStatus string
+
+const (
+	DeadLetterOpen     DeadLetterStatus = "open"
+	DeadLetterResolved DeadLetterStatus = "resolved"
+	DeadLetterReplayed DeadLetterStatus = "replayed"
+)
+
+func MapStrToDeadLetterStatus(s string) (DeadLetterStatus, error) {
+	switch status := DeadLetterStatus(s); status {
+	case DeadLetterOpen, DeadLetterResolved, DeadLetterReplayed:
+		return status, nil
+	default:
+		return "", fmt.Errorf("invalid dead letter status: %s", s)
+	}
+}
+
+type DeadLetter struct {
+	ID                int64             `json:"id"`
+	FailedAt          time.Time         `json:"failed_at"`
+	Reason            string            `json:"reason"`
+	Error             string            `json:"error,omitempty"`
+	OriginalTopic     string            `json:"original_topic"`
+	OriginalKey       []byte            `json:"original_key,omitempty"`
+	OriginalValue     []byte            `json:"original_value,omitempty"`
+	OriginalHeaders   map[string]string `json:"original_headers,omitempty"`
+	OriginalPartition int32             `json:"original_partition"`
+	OriginalOffset    int64             `json:"original_offset"`
+	Attempts          int               `json:"attempts"`
+	TraceID           string            `json:"trace_id,omitempty"`
+	SpanID            string            `json:"span_id,omitempty"`
+	Status            DeadLetterStatus  `json:"status"`
+	Note              string            `json:"note,omitempty"`
+	ResolvedAt        *time.Time        `json:"resolved_at,omitempty"`
+	ReplayedAt        *time.Time        `json:"replayed_at,omitempty"`
+}
+
+// Empty fields match everything
+type DeadLetterFilter struct {
+	Reasons        []string
+	OriginalTopics []string
+	ErrorContains  string
+	Statuses       []DeadLetterStatus
+	FailedAfter    *time.Time
+	FailedBefore   *time.Time
+}
+
+type DeadLetterPage struct {
+	DeadLetters []*DeadLetter `json:"dead_letters"`
+	NextCursor  string        `json:"next_cursor,omitempty"`
+}
+
+type DeadLetterCount struct {
+	Reason   string `json:"reason"`
+	Open     int64  `json:"open"`
+	Resolved int64  `json:"resolved"`
+	Replayed int64  `json:"replayed"`
+	Total    int64  `json:"total"`
+}
+
+// Queries
+// Newest first
+type ListDeadLettersQry struct {
+	Filter DeadLetterFilter
+	Limit  int
+	Cursor string
+}
+
+// Commands
+type ResolveDeadLetterCmd struct {
+	ID   int64
+	Note string
+}
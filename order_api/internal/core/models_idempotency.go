@@ -0,0 +1,14 @@
+package core
+
+import "time"
+
+type IdempotencyRecord struct {
+	Key         string
+	RequestHash string
+	StatusCode  int
+	Headers     map[string]string
+	Body        []byte
+	Completed   bool
+	CreatedAt   time.Time
+	ExpiresAt   time.Time
+}
@@ -12,6 +12,10 @@ const (
 	StatusPending   Status = "pending"
 	StatusConfirmed Status = "confirmed"
 	StatusFailed    Status = "failed"
+	StatusCancelled Status = "cancelled"
+	StatusShipped   Status = "shipped"
+	StatusDelivered Status = "delivered"
+	StatusRefunded  Status = "refunded"
 )
 
 func MapStrToStatus(s string) (*Status, error) {
@@ -22,6 +26,14 @@ func MapStrToStatus(s string) (*Status, error) {
 		return ptr.Ptr(StatusConfirmed), nil
 	case string(StatusFailed):
 		return ptr.Ptr(StatusFailed), nil
+	case string(StatusCancelled):
+		return ptr.Ptr(StatusCancelled), nil
+	case string(StatusShipped):
+		return ptr.Ptr(StatusShipped), nil
+	case string(StatusDelivered):
+		return ptr.Ptr(StatusDelivered), nil
+	case string(StatusRefunded):
+		return ptr.Ptr(StatusRefunded), nil
 	default:
 		return nil, errors.New("unknown status")
 	}
@@ -0,0 +1,15 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/Anacardo89/order_svc_hex/order_api/internal/core"
+)
+
+type CommandTracker interface {
+	Track(ctx context.Context, status *core.CommandStatus) error
+	Resolve(ctx context.Context, result *core.CommandResult) error
+	Get(ctx context.Context, qry *core.GetCommandQry) (*core.CommandStatus, error)
+	// Blocks until the command completes or ctx is done, returning its latest status
+	Wait(ctx context.Context, qry *core.GetCommandQry) (*core.CommandStatus, error)
+}
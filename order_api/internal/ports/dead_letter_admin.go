@@ -0,0 +1,18 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/Anacardo89/order_svc_hex/order_api/internal/core"
+)
+
+type DeadLetterAdmin interface {
+	ListDeadLetters(ctx context.Context, qry *core.ListDeadLettersQry) (*core.DeadLetterPage, error)
+	GetDeadLetter(ctx context.Context, id int64) (*core.DeadLetter, error)
+	ResolveDeadLetter(ctx context.Context, cmd *core.ResolveDeadLetterCmd) (*core.DeadLetter, error)
+	// Republishes the original message, the entry is only marked replayed once that succeeds
+	ReplayDeadLetter(ctx context.Context, id int64) (*core.DeadLetter, error)
+	// The filter needs statuses or failed_before
+	PurgeDeadLetters(ctx context.Context, filter core.DeadLetterFilter) (int64, error)
+	CountDeadLetters(ctx context.Context, filter core.DeadLetterFilter) ([]*core.DeadLetterCount, error)
+}
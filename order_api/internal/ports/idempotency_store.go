@@ -0,0 +1,14 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/Anacardo89/order_svc_hex/order_api/internal/core"
+)
+
+type IdempotencyStore interface {
+	// Returns the existing record when the key is already taken, nil when it was reserved
+	Reserve(ctx context.Context, key, requestHash string) (*core.IdempotencyRecord, error)
+	Complete(ctx context.Context, rec *core.IdempotencyRecord) error
+	Release(ctx context.Context, key string) error
+}
@@ -0,0 +1,17 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/Anacardo89/order_svc_hex/order_api/internal/core"
+)
+
+type OrderCache interface {
+	// Returns nil when the order isn't cached or has expired
+	Get(ctx context.Context, id uuid.UUID) (*core.Order, error)
+	Set(ctx context.Context, order *core.Order, ttl time.Duration) error
+	Delete(ctx context.Context, id uuid.UUID) error
+}
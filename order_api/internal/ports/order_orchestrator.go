@@ -8,7 +8,12 @@ import (
 
 type OrderOrchestrator interface {
 	GetOrder(ctx context.Context, qry *core.GetOrderQry) (*core.Order, error)
-	ListOrdersByStatus(ctx context.Context, qry *core.ListOrdersByStatusQry) ([]*core.Order, error)
+	GetOrdersByIDs(ctx context.Context, qry *core.GetOrdersByIDsQry) (*core.OrderBatch, error)
+	ListOrders(ctx context.Context, qry *core.ListOrdersQry) (*core.OrderPage, error)
+	StreamOrders(ctx context.Context, qry *core.ListOrdersQry, send func(*core.Order) error) (string, error)
+	WatchOrder(ctx context.Context, qry *core.WatchOrderQry, send func(*core.OrderChange) error) error
 	CreateOrder(ctx context.Context, req *core.CreateOrderCmd) error
 	UpdateOrderStatus(ctx context.Context, req *core.UpdateOrderStatusCmd) error
+	GetCommand(ctx context.Context, qry *core.GetCommandQry) (*core.CommandStatus, error)
+	WaitCommand(ctx context.Context, qry *core.WaitCommandQry) (*core.CommandStatus, error)
 }
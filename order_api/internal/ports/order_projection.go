@@ -0,0 +1,16 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/Anacardo89/order_svc_hex/order_api/internal/core"
+)
+
+// Local copy of order state kept up to date from order events
+type OrderProjection interface {
+	OrderReader
+	// Keeps the newest version of each order, older or repeated events are ignored
+	Apply(ctx context.Context, order *core.Order) error
+	// Reads fail with core.ErrReaderUnavailable until the events topic is read up to where it was on start
+	SetCaughtUp(caughtUp bool)
+}
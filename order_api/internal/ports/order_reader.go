@@ -8,5 +8,10 @@ import (
 
 type OrderReader interface {
 	GetByID(ctx context.Context, qry *core.GetOrderQry) (*core.Order, error)
-	ListByStatus(ctx context.Context, qry *core.ListOrdersByStatusQry) ([]*core.Order, error)
+	GetByIDs(ctx context.Context, qry *core.GetOrdersByIDsQry) (*core.OrderBatch, error)
+	List(ctx context.Context, qry *core.ListOrdersQry) (*core.OrderPage, error)
+	// Returns the token for the next page, empty on the last one
+	Stream(ctx context.Context, qry *core.ListOrdersQry, send func(*core.Order) error) (string, error)
+	// Blocks sending changes until ctx is done or the subscription ends
+	Watch(ctx context.Context, qry *core.WatchOrderQry, send func(*core.OrderChange) error) error
 }
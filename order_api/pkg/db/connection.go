@@ -0,0 +1,32 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+func Connect(
+	dsn string,
+	maxConns, minConns int32,
+	maxConnLifetime, maxConnIdleTime time.Duration,
+) (*pgxpool.Pool, error) {
+	config, err := pgxpool.ParseConfig(dsn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse config: %w", err)
+	}
+	config.MaxConns = maxConns
+	config.MinConns = minConns
+	config.MaxConnLifetime = maxConnLifetime
+	config.MaxConnIdleTime = maxConnIdleTime
+	pool, err := pgxpool.NewWithConfig(context.Background(), config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create pool: %w", err)
+	}
+	if err := pool.Ping(context.Background()); err != nil {
+		return nil, fmt.Errorf("failed to ping database: %w", err)
+	}
+	return pool, nil
+}
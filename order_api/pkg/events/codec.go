@@ -0,0 +1,70 @@
+package events
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/Anacardo89/order_svc_hex/order_api/proto/orderpb"
+)
+
+const (
+	ContentTypeJSON     = "application/json"
+	ContentTypeProtobuf = "application/x-protobuf"
+)
+
+var commandMessages = map[string]func() proto.Message{
+	EventTypeOrderCreated:       func() proto.Message { return &orderpb.OrderCreatedCommand{} },
+	EventTypeOrderStatusUpdated: func() proto.Message { return &orderpb.OrderStatusUpdatedCommand{} },
+}
+
+func MapStrToContentType(s string) (string, error) {
+	switch s {
+	case "", "json":
+		return ContentTypeJSON, nil
+	case "protobuf":
+		return ContentTypeProtobuf, nil
+	default:
+		return "", fmt.Errorf("unknown content type: %s", s)
+	}
+}
+
+func EncodeCommand(contentType string, env *Envelope) ([]byte, error) {
+	switch contentType {
+	case "", ContentTypeJSON:
+		return json.Marshal(env)
+	case ContentTypeProtobuf:
+		return encodeProtoEnvelope(env)
+	default:
+		return nil, fmt.Errorf("unsupported content type: %s", contentType)
+	}
+}
+
+// Goes through the JSON data, so a field the contract doesn't know fails here
+// instead of being dropped on the wire
+func encodeProtoEnvelope(env *Envelope) ([]byte, error) {
+	newMsg, ok := commandMessages[env.EventType]
+	if !ok {
+		return nil, fmt.Errorf("no protobuf message for %s", env.EventType)
+	}
+	msg := newMsg()
+	if err := protojson.Unmarshal(env.Data, msg); err != nil {
+		return nil, fmt.Errorf("failed to convert %s data: %w", env.EventType, err)
+	}
+	data, err := anypb.New(msg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to pack %s data: %w", env.EventType, err)
+	}
+	return proto.Marshal(&orderpb.CommandEnvelope{
+		EventId:       env.EventID,
+		EventType:     env.EventType,
+		SchemaVersion: int32(env.SchemaVersion),
+		OccurredAt:    timestamppb.New(env.OccurredAt),
+		Producer:      env.Producer,
+		Data:          data,
+	})
+}
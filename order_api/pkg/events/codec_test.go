@@ -0,0 +1,43 @@
+package events
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/Anacardo89/order_svc_hex/order_api/proto/orderpb"
+)
+
+func TestEncodeCommand_Protobuf(t *testing.T) {
+	occurredAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	env, err := NewEnvelope("cmd-1", EventTypeOrderCreated, 1, "order_api", occurredAt, map[string]any{
+		"id":     "11111111-1111-1111-1111-111111111111",
+		"items":  map[string]int{"sku_1": 2},
+		"status": "pending",
+	})
+	require.NoError(t, err)
+
+	raw, err := EncodeCommand(ContentTypeProtobuf, env)
+	require.NoError(t, err)
+	var pbEnv orderpb.CommandEnvelope
+	require.NoError(t, proto.Unmarshal(raw, &pbEnv))
+	assert.Equal(t, "cmd-1", pbEnv.EventId)
+	assert.Equal(t, EventTypeOrderCreated, pbEnv.EventType)
+	assert.True(t, occurredAt.Equal(pbEnv.OccurredAt.AsTime()))
+	var cmd orderpb.OrderCreatedCommand
+	require.NoError(t, pbEnv.Data.UnmarshalTo(&cmd))
+	assert.Equal(t, map[string]int32{"sku_1": 2}, cmd.Items)
+
+	// Fields outside the contract are rejected
+	env, err = NewEnvelope("cmd-2", EventTypeOrderStatusUpdated, 1, "order_api", occurredAt, map[string]any{
+		"id":     "11111111-1111-1111-1111-111111111111",
+		"status": "confirmed",
+		"reason": "manual",
+	})
+	require.NoError(t, err)
+	_, err = EncodeCommand(ContentTypeProtobuf, env)
+	assert.Error(t, err)
+}
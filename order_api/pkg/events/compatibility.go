@@ -0,0 +1,106 @@
+package events
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// Rough BACKWARD check for the local registry: data written with old must
+// still read with new. The real registry does the full check
+func backwardCompatible(old, new *Schema) (bool, error) {
+	if old.Type != new.Type {
+		return false, nil
+	}
+	switch new.Type {
+	case SchemaTypeJSON:
+		return jsonCompatible(old.Schema, new.Schema)
+	case SchemaTypeProtobuf:
+		return protoCompatible(old.Schema, new.Schema), nil
+	default:
+		return false, fmt.Errorf("unsupported schema type: %s", new.Type)
+	}
+}
+
+type jsonSchema struct {
+	Type       any                    `json:"type"`
+	Properties map[string]*jsonSchema `json:"properties"`
+	Required   []string               `json:"required"`
+	Items      *jsonSchema            `json:"items"`
+}
+
+func jsonCompatible(oldRaw, newRaw string) (bool, error) {
+	var old, new jsonSchema
+	if err := json.Unmarshal([]byte(oldRaw), &old); err != nil {
+		return false, fmt.Errorf("invalid JSON schema: %w", err)
+	}
+	if err := json.Unmarshal([]byte(newRaw), &new); err != nil {
+		return false, fmt.Errorf("invalid JSON schema: %w", err)
+	}
+	return jsonSchemaCompatible(&old, &new), nil
+}
+
+// Properties may be dropped or added as optional, kept ones can't change type
+// and old data can't miss a newly required one
+func jsonSchemaCompatible(old, new *jsonSchema) bool {
+	if old == nil || new == nil {
+		return true
+	}
+	if !reflect.DeepEqual(old.Type, new.Type) {
+		return false
+	}
+	for _, name := range new.Required {
+		if !slices.Contains(old.Required, name) {
+			return false
+		}
+	}
+	for name, oldProp := range old.Properties {
+		if !jsonSchemaCompatible(oldProp, new.Properties[name]) {
+			return false
+		}
+	}
+	return jsonSchemaCompatible(old.Items, new.Items)
+}
+
+var (
+	protoMessage = regexp.MustCompile(`^\s*message\s+(\w+)`)
+	protoField   = regexp.MustCompile(`^\s*(?:repeated\s+|optional\s+)?([\w.]+(?:\s*<[^>]+>)?)\s+\w+\s*=\s*(\d+)`)
+)
+
+// Field numbers can be added or removed, one still in use can't change type
+func protoCompatible(oldRaw, newRaw string) bool {
+	old, new := protoFields(oldRaw), protoFields(newRaw)
+	for key, oldType := range old {
+		if newType, ok := new[key]; ok && newType != oldType {
+			return false
+		}
+	}
+	return true
+}
+
+// Keyed by "<message>.<field number>", nested messages are flattened
+func protoFields(schema string) map[string]string {
+	fields := make(map[string]string)
+	// Open blocks, empty for the ones that aren't messages (enum, oneof, service)
+	var blocks []string
+	for _, line := range strings.Split(schema, "\n") {
+		if m := protoField.FindStringSubmatch(line); m != nil && len(blocks) > 0 {
+			key := strings.Join(slices.DeleteFunc(slices.Clone(blocks), func(b string) bool { return b == "" }), ".") + "." + m[2]
+			fields[key] = strings.Join(strings.Fields(m[1]), "")
+		}
+		if strings.Contains(line, "{") {
+			name := ""
+			if m := protoMessage.FindStringSubmatch(line); m != nil {
+				name = m[1]
+			}
+			blocks = append(blocks, name)
+		}
+		if strings.Contains(line, "}") && len(blocks) > 0 {
+			blocks = blocks[:len(blocks)-1]
+		}
+	}
+	return fields
+}
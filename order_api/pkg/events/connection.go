@@ -65,6 +65,42 @@ func (c *KafkaConnection) MakeConsumer(groupID string, topics []string) (*kafka.
 	return consumer, nil
 }
 
+// A new group starts at the end of the topics instead of reading their whole history
+func (c *KafkaConnection) MakeTailConsumer(groupID string, topics []string) (*kafka.Consumer, error) {
+	consumer, err := kafka.NewConsumer(&kafka.ConfigMap{
+		"bootstrap.servers": c.Brokers,
+		"group.id":          groupID,
+		"auto.offset.reset": "latest",
+	})
+	if err != nil {
+		return nil, err
+	}
+	err = consumer.SubscribeTopics(topics, rebalanceCb)
+	if err != nil {
+		return nil, fmt.Errorf("failed to subscribe to topics: %w", err)
+	}
+	return consumer, nil
+}
+
+// Never commits offsets, so every start reads the topics from the beginning.
+// The rebalance callback has to assign and unassign itself
+func (c *KafkaConnection) MakeReplayConsumer(groupID string, topics []string, rebalance kafka.RebalanceCb) (*kafka.Consumer, error) {
+	consumer, err := kafka.NewConsumer(&kafka.ConfigMap{
+		"bootstrap.servers":  c.Brokers,
+		"group.id":           groupID,
+		"auto.offset.reset":  "earliest",
+		"enable.auto.commit": false,
+	})
+	if err != nil {
+		return nil, err
+	}
+	err = consumer.SubscribeTopics(topics, rebalance)
+	if err != nil {
+		return nil, fmt.Errorf("failed to subscribe to topics: %w", err)
+	}
+	return consumer, nil
+}
+
 func (c *KafkaConnection) MakeProducer() (*kafka.Producer, error) {
 	producer, err := kafka.NewProducer(&kafka.ConfigMap{
 		"bootstrap.servers":  c.Brokers,
@@ -0,0 +1,39 @@
+package events
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Event types carried in the envelope
+const (
+	EventTypeOrderCreated       = "order.created"
+	EventTypeOrderStatusUpdated = "order.status_updated"
+)
+
+// Common wrapper for every command on the order topics, Data holds the
+// payload for EventType at SchemaVersion
+type Envelope struct {
+	EventID       string          `json:"event_id"`
+	EventType     string          `json:"event_type"`
+	SchemaVersion int             `json:"schema_version"`
+	OccurredAt    time.Time       `json:"occurred_at"`
+	Producer      string          `json:"producer"`
+	Data          json.RawMessage `json:"data"`
+}
+
+func NewEnvelope(eventID, eventType string, version int, producer string, occurredAt time.Time, data any) (*Envelope, error) {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal %s data: %w", eventType, err)
+	}
+	return &Envelope{
+		EventID:       eventID,
+		EventType:     eventType,
+		SchemaVersion: version,
+		OccurredAt:    occurredAt.UTC(),
+		Producer:      producer,
+		Data:          raw,
+	}, nil
+}
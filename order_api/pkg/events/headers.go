@@ -0,0 +1,19 @@
+package events
+
+import "github.com/confluentinc/confluent-kafka-go/v2/kafka"
+
+const (
+	HeaderCorrelationID = "correlation-id"
+	HeaderReplyTo       = "reply-to"
+	HeaderMessageID     = "message-id"
+	HeaderContentType   = "content-type"
+)
+
+func HeaderValue(headers []kafka.Header, key string) string {
+	for _, h := range headers {
+		if h.Key == key {
+			return string(h.Value)
+		}
+	}
+	return ""
+}
@@ -0,0 +1,40 @@
+package events
+
+import (
+	"context"
+	"errors"
+)
+
+var (
+	ErrSchemaNotFound     = errors.New("schema not found")
+	ErrIncompatibleSchema = errors.New("schema incompatible with latest version")
+)
+
+// Schema types as named by the registry REST API
+const (
+	SchemaTypeJSON     = "JSON"
+	SchemaTypeProtobuf = "PROTOBUF"
+)
+
+type Schema struct {
+	ID      int    `json:"id,omitempty"`
+	Subject string `json:"subject,omitempty"`
+	Version int    `json:"version,omitempty"`
+	Type    string `json:"schemaType,omitempty"`
+	Schema  string `json:"schema"`
+}
+
+// Subset of the Confluent Schema Registry API the serializers need
+type SchemaRegistry interface {
+	// Registering a schema already under subject returns its existing ID
+	Register(ctx context.Context, subject string, schema Schema) (int, error)
+	SchemaByID(ctx context.Context, id int) (*Schema, error)
+	Latest(ctx context.Context, subject string) (*Schema, error)
+	// A subject with no versions yet accepts anything
+	CheckCompatibility(ctx context.Context, subject string, schema Schema) (bool, error)
+}
+
+// Topic name strategy, the default of the Confluent serializers
+func ValueSubject(topic string) string {
+	return topic + "-value"
+}
@@ -0,0 +1,143 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+type localRegistryFile struct {
+	NextID   int                  `json:"next_id"`
+	Subjects map[string][]*Schema `json:"subjects"`
+}
+
+// Stand-in for dev and tests, keeps every subject in one JSON file. The file
+// is read again on every call so processes sharing it see each other's writes
+type LocalRegistry struct {
+	path string
+	mu   sync.Mutex
+}
+
+func NewLocalRegistry(path string) *LocalRegistry {
+	return &LocalRegistry{
+		path: path,
+	}
+}
+
+func (r *LocalRegistry) Register(ctx context.Context, subject string, schema Schema) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	f, err := r.load()
+	if err != nil {
+		return 0, err
+	}
+	for _, s := range f.Subjects[subject] {
+		if s.Type == schema.Type && s.Schema == schema.Schema {
+			return s.ID, nil
+		}
+	}
+	// Same schema under another subject keeps its ID, as the registry does
+	id := 0
+	for _, versions := range f.Subjects {
+		for _, s := range versions {
+			if s.Type == schema.Type && s.Schema == schema.Schema {
+				id = s.ID
+			}
+		}
+	}
+	if id == 0 {
+		f.NextID++
+		id = f.NextID
+	}
+	f.Subjects[subject] = append(f.Subjects[subject], &Schema{
+		ID:      id,
+		Subject: subject,
+		Version: len(f.Subjects[subject]) + 1,
+		Type:    schema.Type,
+		Schema:  schema.Schema,
+	})
+	if err := r.save(f); err != nil {
+		return 0, err
+	}
+	return id, nil
+}
+
+func (r *LocalRegistry) SchemaByID(ctx context.Context, id int) (*Schema, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	f, err := r.load()
+	if err != nil {
+		return nil, err
+	}
+	for _, versions := range f.Subjects {
+		for _, s := range versions {
+			if s.ID == id {
+				return s, nil
+			}
+		}
+	}
+	return nil, ErrSchemaNotFound
+}
+
+func (r *LocalRegistry) Latest(ctx context.Context, subject string) (*Schema, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	f, err := r.load()
+	if err != nil {
+		return nil, err
+	}
+	versions := f.Subjects[subject]
+	if len(versions) == 0 {
+		return nil, ErrSchemaNotFound
+	}
+	return versions[len(versions)-1], nil
+}
+
+func (r *LocalRegistry) CheckCompatibility(ctx context.Context, subject string, schema Schema) (bool, error) {
+	latest, err := r.Latest(ctx, subject)
+	if errors.Is(err, ErrSchemaNotFound) {
+		return true, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return backwardCompatible(latest, &schema)
+}
+
+func (r *LocalRegistry) load() (*localRegistryFile, error) {
+	f := &localRegistryFile{Subjects: make(map[string][]*Schema)}
+	raw, err := os.ReadFile(r.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return f, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read local registry: %w", err)
+	}
+	if err := json.Unmarshal(raw, f); err != nil {
+		return nil, fmt.Errorf("failed to parse local registry: %w", err)
+	}
+	if f.Subjects == nil {
+		f.Subjects = make(map[string][]*Schema)
+	}
+	return f, nil
+}
+
+// Written to a temp file first so a reader never sees half of it
+func (r *LocalRegistry) save(f *localRegistryFile) error {
+	raw, err := json.MarshalIndent(f, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(r.path), 0o755); err != nil {
+		return fmt.Errorf("failed to create local registry dir: %w", err)
+	}
+	tmp := r.path + ".tmp"
+	if err := os.WriteFile(tmp, raw, 0o644); err != nil {
+		return fmt.Errorf("failed to write local registry: %w", err)
+	}
+	return os.Rename(tmp, r.path)
+}
@@ -0,0 +1,96 @@
+package events
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSerializer_LocalRegistry(t *testing.T) {
+	ctx := context.Background()
+	registry := NewLocalRegistry(filepath.Join(t.TempDir(), "schemas.json"))
+	serializer := NewSerializer(registry)
+
+	schema, err := CommandSchema(EventTypeOrderCreated, ContentTypeJSON)
+	require.NoError(t, err)
+	framed, err := serializer.Serialize(ctx, "orders.created", schema, []byte(`{}`))
+	require.NoError(t, err)
+	assert.Equal(t, byte(0), framed[0])
+	id := int(binary.BigEndian.Uint32(framed[1:5]))
+	assert.Equal(t, []byte(`{}`), framed[5:])
+
+	// Another process sharing the file sees the schema
+	stored, err := NewLocalRegistry(registry.path).SchemaByID(ctx, id)
+	require.NoError(t, err)
+	assert.Equal(t, ValueSubject("orders.created"), stored.Subject)
+	assert.Equal(t, SchemaTypeJSON, stored.Type)
+
+	protoSchema, err := CommandSchema(EventTypeOrderCreated, ContentTypeProtobuf)
+	require.NoError(t, err)
+	framed, err = serializer.Serialize(ctx, "orders.status_updated", protoSchema, []byte{0x0a})
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0, 0x0a}, framed[5:], "protobuf payloads carry the message index")
+
+	// Changing a field's type breaks readers of older data
+	breaking := Schema{
+		Type:   SchemaTypeJSON,
+		Schema: strings.Replace(schema.Schema, `"schema_version": { "type": "integer" }`, `"schema_version": { "type": "string" }`, 1),
+	}
+	_, err = NewSerializer(registry).Serialize(ctx, "orders.created", breaking, []byte(`{}`))
+	assert.ErrorIs(t, err, ErrIncompatibleSchema)
+}
+
+func TestProtoCompatible(t *testing.T) {
+	old := `message OrderCreatedCommand {
+    string id = 1;
+    map<string, int32> items = 2;
+}`
+	assert.True(t, protoCompatible(old, old+"\nmessage Other {\n    int64 id = 1;\n}"))
+	assert.True(t, protoCompatible(old, strings.Replace(old, "string id = 1;", "string order_id = 1;", 1)))
+	assert.False(t, protoCompatible(old, strings.Replace(old, "map<string, int32>", "map<string, int64>", 1)))
+}
+
+func TestHTTPRegistry(t *testing.T) {
+	var registered Schema
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", registryContentType)
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/compatibility/subjects/orders.created-value/versions/latest":
+			w.WriteHeader(http.StatusNotFound)
+			json.NewEncoder(w).Encode(registryError{Code: errCodeSubjectNotFound, Message: "Subject not found"})
+		case r.Method == http.MethodPost && r.URL.Path == "/subjects/orders.created-value/versions":
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&registered))
+			json.NewEncoder(w).Encode(map[string]int{"id": 7})
+		case r.Method == http.MethodGet && r.URL.Path == "/schemas/ids/7":
+			json.NewEncoder(w).Encode(registered)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			json.NewEncoder(w).Encode(registryError{Code: errCodeSchemaNotFound, Message: "Schema not found"})
+		}
+	}))
+	defer srv.Close()
+
+	ctx := context.Background()
+	registry := NewHTTPRegistry(srv.URL, time.Second)
+	schema := Schema{Type: SchemaTypeProtobuf, Schema: `syntax = "proto3";`}
+	framed, err := NewSerializer(registry).Serialize(ctx, "orders.created", schema, []byte{0x0a})
+	require.NoError(t, err)
+	assert.Equal(t, uint32(7), binary.BigEndian.Uint32(framed[1:5]))
+
+	got, err := registry.SchemaByID(ctx, 7)
+	require.NoError(t, err)
+	assert.Equal(t, schema.Schema, got.Schema)
+	assert.Equal(t, SchemaTypeProtobuf, got.Type)
+
+	_, err = registry.SchemaByID(ctx, 8)
+	assert.ErrorIs(t, err, ErrSchemaNotFound)
+}
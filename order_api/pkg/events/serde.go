@@ -0,0 +1,115 @@
+package events
+
+import (
+	"context"
+	"embed"
+	"encoding/binary"
+	"fmt"
+	"sync"
+)
+
+// Confluent wire format: magic byte, 4 byte big endian schema ID, then for
+// protobuf the message indexes, then the payload
+const (
+	magicByte    = 0
+	headerLength = 5
+)
+
+// schemas/commands.proto is a copy of contracts/orders/commands.proto, a test keeps them equal
+//
+//go:embed schemas
+var schemaFiles embed.FS
+
+var commandSchemaFiles = map[string]string{
+	EventTypeOrderCreated:       "schemas/order_created.json",
+	EventTypeOrderStatusUpdated: "schemas/order_status_updated.json",
+}
+
+// Schema the registry should hold for a command in the given encoding
+func CommandSchema(eventType, contentType string) (Schema, error) {
+	var (
+		schemaType string
+		file       string
+	)
+	switch contentType {
+	case "", ContentTypeJSON:
+		schemaType = SchemaTypeJSON
+		file = commandSchemaFiles[eventType]
+		if file == "" {
+			return Schema{}, fmt.Errorf("no JSON schema for %s", eventType)
+		}
+	case ContentTypeProtobuf:
+		schemaType = SchemaTypeProtobuf
+		file = "schemas/commands.proto"
+	default:
+		return Schema{}, fmt.Errorf("unsupported content type: %s", contentType)
+	}
+	raw, err := schemaFiles.ReadFile(file)
+	if err != nil {
+		return Schema{}, err
+	}
+	return Schema{Type: schemaType, Schema: string(raw)}, nil
+}
+
+// Registers each schema once per subject, refusing to produce with one the
+// registry finds incompatible
+type Serializer struct {
+	registry SchemaRegistry
+	mu       sync.Mutex
+	ids      map[string]int
+}
+
+func NewSerializer(registry SchemaRegistry) *Serializer {
+	return &Serializer{
+		registry: registry,
+		ids:      make(map[string]int),
+	}
+}
+
+func (s *Serializer) Serialize(ctx context.Context, topic string, schema Schema, payload []byte) ([]byte, error) {
+	id, err := s.schemaID(ctx, ValueSubject(topic), schema)
+	if err != nil {
+		return nil, err
+	}
+	return frame(id, schema.Type, payload), nil
+}
+
+// The lock only guards the cache, registry calls run without it so a slow registry
+// doesn't hold up producers whose schema is known. Racing callers both register,
+// which is harmless as registering is idempotent
+func (s *Serializer) schemaID(ctx context.Context, subject string, schema Schema) (int, error) {
+	key := subject + "|" + schema.Type + "|" + schema.Schema
+	s.mu.Lock()
+	id, ok := s.ids[key]
+	s.mu.Unlock()
+	if ok {
+		return id, nil
+	}
+	ok, err := s.registry.CheckCompatibility(ctx, subject, schema)
+	if err != nil {
+		return 0, fmt.Errorf("failed to check %s compatibility: %w", subject, err)
+	}
+	if !ok {
+		return 0, fmt.Errorf("%w: %s", ErrIncompatibleSchema, subject)
+	}
+	id, err = s.registry.Register(ctx, subject, schema)
+	if err != nil {
+		return 0, fmt.Errorf("failed to register %s: %w", subject, err)
+	}
+	s.mu.Lock()
+	s.ids[key] = id
+	s.mu.Unlock()
+	return id, nil
+}
+
+// Our protobuf payloads are always CommandEnvelope, the first message in its
+// file, which the format writes as a single 0
+func frame(id int, schemaType string, payload []byte) []byte {
+	out := make([]byte, headerLength, headerLength+1+len(payload))
+	out[0] = magicByte
+	binary.BigEndian.PutUint32(out[1:headerLength], uint32(id))
+	if schemaType == SchemaTypeProtobuf {
+		out = append(out, 0)
+	}
+	return append(out, payload...)
+}
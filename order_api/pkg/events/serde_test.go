@@ -0,0 +1,56 @@
+package events
+
+import (
+	"context"
+	"os"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCommandsProto_MatchesContract(t *testing.T) {
+	contract, err := os.ReadFile("../../../contracts/orders/commands.proto")
+	require.NoError(t, err)
+	embedded, err := schemaFiles.ReadFile("schemas/commands.proto")
+	require.NoError(t, err)
+	assert.Equal(t, string(contract), string(embedded), "copy contracts/orders/commands.proto to pkg/events/schemas")
+}
+
+type blockingRegistry struct {
+	SchemaRegistry
+	entered chan struct{}
+	release chan struct{}
+}
+
+func (r *blockingRegistry) CheckCompatibility(ctx context.Context, subject string, schema Schema) (bool, error) {
+	close(r.entered)
+	<-r.release
+	return true, nil
+}
+
+func (r *blockingRegistry) Register(ctx context.Context, subject string, schema Schema) (int, error) {
+	return 1, nil
+}
+
+func TestSerializer_RegistryCallsDontBlockCachedSchemas(t *testing.T) {
+	ctx := context.Background()
+	registry := &blockingRegistry{entered: make(chan struct{}), release: make(chan struct{})}
+	serializer := NewSerializer(registry)
+	known := Schema{Type: SchemaTypeJSON, Schema: `{"type":"object"}`}
+	serializer.ids[ValueSubject("orders.created")+"|"+known.Type+"|"+known.Schema] = 7
+
+	var wg sync.WaitGroup
+	wg.Go(func() {
+		_, err := serializer.Serialize(ctx, "orders.status_updated", known, []byte(`{}`))
+		assert.NoError(t, err)
+	})
+	<-registry.entered
+	// Answered from the cache while the other subject waits on the registry
+	framed, err := serializer.Serialize(ctx, "orders.created", known, []byte(`{}`))
+	require.NoError(t, err)
+	assert.Equal(t, byte(7), framed[4])
+	close(registry.release)
+	wg.Wait()
+}
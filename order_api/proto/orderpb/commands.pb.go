@@ -0,0 +1,305 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v6.33.4
+// source: contracts/orders/commands.proto
+
+package orderpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	anypb "google.golang.org/protobuf/types/known/anypb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Protobuf form of the command envelope on the order topics, data holds
+// one of the command messages below
+type CommandEnvelope struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EventId       string                 `protobuf:"bytes,1,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	EventType     string                 `protobuf:"bytes,2,opt,name=event_type,json=eventType,proto3" json:"event_type,omitempty"`
+	SchemaVersion int32                  `protobuf:"varint,3,opt,name=schema_version,json=schemaVersion,proto3" json:"schema_version,omitempty"`
+	OccurredAt    *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=occurred_at,json=occurredAt,proto3" json:"occurred_at,omitempty"`
+	Producer      string                 `protobuf:"bytes,5,opt,name=producer,proto3" json:"producer,omitempty"`
+	Data          *anypb.Any             `protobuf:"bytes,6,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CommandEnvelope) Reset() {
+	*x = CommandEnvelope{}
+	mi := &file_contracts_orders_commands_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CommandEnvelope) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CommandEnvelope) ProtoMessage() {}
+
+func (x *CommandEnvelope) ProtoReflect() protoreflect.Message {
+	mi := &file_contracts_orders_commands_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CommandEnvelope.ProtoReflect.Descriptor instead.
+func (*CommandEnvelope) Descriptor() ([]byte, []int) {
+	return file_contracts_orders_commands_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *CommandEnvelope) GetEventId() string {
+	if x != nil {
+		return x.EventId
+	}
+	return ""
+}
+
+func (x *CommandEnvelope) GetEventType() string {
+	if x != nil {
+		return x.EventType
+	}
+	return ""
+}
+
+func (x *CommandEnvelope) GetSchemaVersion() int32 {
+	if x != nil {
+		return x.SchemaVersion
+	}
+	return 0
+}
+
+func (x *CommandEnvelope) GetOccurredAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.OccurredAt
+	}
+	return nil
+}
+
+func (x *CommandEnvelope) GetProducer() string {
+	if x != nil {
+		return x.Producer
+	}
+	return ""
+}
+
+func (x *CommandEnvelope) GetData() *anypb.Any {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+// Field names match the JSON payloads, so either encoding decodes the same
+type OrderCreatedCommand struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Items         map[string]int32       `protobuf:"bytes,2,rep,name=items,proto3" json:"items,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+	Status        string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrderCreatedCommand) Reset() {
+	*x = OrderCreatedCommand{}
+	mi := &file_contracts_orders_commands_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrderCreatedCommand) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderCreatedCommand) ProtoMessage() {}
+
+func (x *OrderCreatedCommand) ProtoReflect() protoreflect.Message {
+	mi := &file_contracts_orders_commands_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderCreatedCommand.ProtoReflect.Descriptor instead.
+func (*OrderCreatedCommand) Descriptor() ([]byte, []int) {
+	return file_contracts_orders_commands_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *OrderCreatedCommand) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *OrderCreatedCommand) GetItems() map[string]int32 {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *OrderCreatedCommand) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+type OrderStatusUpdatedCommand struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrderStatusUpdatedCommand) Reset() {
+	*x = OrderStatusUpdatedCommand{}
+	mi := &file_contracts_orders_commands_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrderStatusUpdatedCommand) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderStatusUpdatedCommand) ProtoMessage() {}
+
+func (x *OrderStatusUpdatedCommand) ProtoReflect() protoreflect.Message {
+	mi := &file_contracts_orders_commands_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderStatusUpdatedCommand.ProtoReflect.Descriptor instead.
+func (*OrderStatusUpdatedCommand) Descriptor() ([]byte, []int) {
+	return file_contracts_orders_commands_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *OrderStatusUpdatedCommand) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *OrderStatusUpdatedCommand) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+var File_contracts_orders_commands_proto protoreflect.FileDescriptor
+
+const file_contracts_orders_commands_proto_rawDesc = "" +
+	"\n" +
+	"\x1fcontracts/orders/commands.proto\x12\x05order\x1a\x19google/protobuf/any.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xf5\x01\n" +
+	"\x0fCommandEnvelope\x12\x19\n" +
+	"\bevent_id\x18\x01 \x01(\tR\aeventId\x12\x1d\n" +
+	"\n" +
+	"event_type\x18\x02 \x01(\tR\teventType\x12%\n" +
+	"\x0eschema_version\x18\x03 \x01(\x05R\rschemaVersion\x12;\n" +
+	"\voccurred_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"occurredAt\x12\x1a\n" +
+	"\bproducer\x18\x05 \x01(\tR\bproducer\x12(\n" +
+	"\x04data\x18\x06 \x01(\v2\x14.google.protobuf.AnyR\x04data\"\xb4\x01\n" +
+	"\x13OrderCreatedCommand\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12;\n" +
+	"\x05items\x18\x02 \x03(\v2%.order.OrderCreatedCommand.ItemsEntryR\x05items\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\x1a8\n" +
+	"\n" +
+	"ItemsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x05R\x05value:\x028\x01\"C\n" +
+	"\x19OrderStatusUpdatedCommand\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06statusB>Z<github.com/Anacardo89/order_svc_hex/contracts/orders;orderpbb\x06proto3"
+
+var (
+	file_contracts_orders_commands_proto_rawDescOnce sync.Once
+	file_contracts_orders_commands_proto_rawDescData []byte
+)
+
+func file_contracts_orders_commands_proto_rawDescGZIP() []byte {
+	file_contracts_orders_commands_proto_rawDescOnce.Do(func() {
+		file_contracts_orders_commands_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_contracts_orders_commands_proto_rawDesc), len(file_contracts_orders_commands_proto_rawDesc)))
+	})
+	return file_contracts_orders_commands_proto_rawDescData
+}
+
+var file_contracts_orders_commands_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_contracts_orders_commands_proto_goTypes = []any{
+	(*CommandEnvelope)(nil),           // 0: order.CommandEnvelope
+	(*OrderCreatedCommand)(nil),       // 1: order.OrderCreatedCommand
+	(*OrderStatusUpdatedCommand)(nil), // 2: order.OrderStatusUpdatedCommand
+	nil,                               // 3: order.OrderCreatedCommand.ItemsEntry
+	(*timestamppb.Timestamp)(nil),     // 4: google.protobuf.Timestamp
+	(*anypb.Any)(nil),                 // 5: google.protobuf.Any
+}
+var file_contracts_orders_commands_proto_depIdxs = []int32{
+	4, // 0: order.CommandEnvelope.occurred_at:type_name -> google.protobuf.Timestamp
+	5, // 1: order.CommandEnvelope.data:type_name -> google.protobuf.Any
+	3, // 2: order.OrderCreatedCommand.items:type_name -> order.OrderCreatedCommand.ItemsEntry
+	3, // [3:3] is the sub-list for method output_type
+	3, // [3:3] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_contracts_orders_commands_proto_init() }
+func file_contracts_orders_commands_proto_init() {
+	if File_contracts_orders_commands_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_contracts_orders_commands_proto_rawDesc), len(file_contracts_orders_commands_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   4,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_contracts_orders_commands_proto_goTypes,
+		DependencyIndexes: file_contracts_orders_commands_proto_depIdxs,
+		MessageInfos:      file_contracts_orders_commands_proto_msgTypes,
+	}.Build()
+	File_contracts_orders_commands_proto = out.File
+	file_contracts_orders_commands_proto_goTypes = nil
+	file_contracts_orders_commands_proto_depIdxs = nil
+}
@@ -0,0 +1,1006 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v6.33.4
+// source: contracts/orders/dead_letters.proto
+
+package orderpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Dead letter lifecycle, entries start open
+type DeadLetterStatus int32
+
+const (
+	DeadLetterStatus_DEAD_LETTER_OPEN     DeadLetterStatus = 0
+	DeadLetterStatus_DEAD_LETTER_RESOLVED DeadLetterStatus = 1
+	DeadLetterStatus_DEAD_LETTER_REPLAYED DeadLetterStatus = 2
+)
+
+// Enum value maps for DeadLetterStatus.
+var (
+	DeadLetterStatus_name = map[int32]string{
+		0: "DEAD_LETTER_OPEN",
+		1: "DEAD_LETTER_RESOLVED",
+		2: "DEAD_LETTER_REPLAYED",
+	}
+	DeadLetterStatus_value = map[string]int32{
+		"DEAD_LETTER_OPEN":     0,
+		"DEAD_LETTER_RESOLVED": 1,
+		"DEAD_LETTER_REPLAYED": 2,
+	}
+)
+
+func (x DeadLetterStatus) Enum() *DeadLetterStatus {
+	p := new(DeadLetterStatus)
+	*p = x
+	return p
+}
+
+func (x DeadLetterStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (DeadLetterStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_contracts_orders_dead_letters_proto_enumTypes[0].Descriptor()
+}
+
+func (DeadLetterStatus) Type() protoreflect.EnumType {
+	return &file_contracts_orders_dead_letters_proto_enumTypes[0]
+}
+
+func (x DeadLetterStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use DeadLetterStatus.Descriptor instead.
+func (DeadLetterStatus) EnumDescriptor() ([]byte, []int) {
+	return file_contracts_orders_dead_letters_proto_rawDescGZIP(), []int{0}
+}
+
+// Dead letter message, original_* describe the message as first published
+type DeadLetter struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Id                int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	FailedAt          *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=failed_at,json=failedAt,proto3" json:"failed_at,omitempty"`
+	Reason            string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	Error             string                 `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
+	OriginalTopic     string                 `protobuf:"bytes,5,opt,name=original_topic,json=originalTopic,proto3" json:"original_topic,omitempty"`
+	OriginalKey       []byte                 `protobuf:"bytes,6,opt,name=original_key,json=originalKey,proto3" json:"original_key,omitempty"`
+	OriginalValue     []byte                 `protobuf:"bytes,7,opt,name=original_value,json=originalValue,proto3" json:"original_value,omitempty"`
+	OriginalHeaders   map[string]string      `protobuf:"bytes,8,rep,name=original_headers,json=originalHeaders,proto3" json:"original_headers,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	OriginalPartition int32                  `protobuf:"varint,9,opt,name=original_partition,json=originalPartition,proto3" json:"original_partition,omitempty"`
+	OriginalOffset    int64                  `protobuf:"varint,10,opt,name=original_offset,json=originalOffset,proto3" json:"original_offset,omitempty"`
+	Attempts          int32                  `protobuf:"varint,11,opt,name=attempts,proto3" json:"attempts,omitempty"`
+	TraceId           string                 `protobuf:"bytes,12,opt,name=trace_id,json=traceId,proto3" json:"trace_id,omitempty"`
+	SpanId            string                 `protobuf:"bytes,13,opt,name=span_id,json=spanId,proto3" json:"span_id,omitempty"`
+	Status            DeadLetterStatus       `protobuf:"varint,14,opt,name=status,proto3,enum=order.DeadLetterStatus" json:"status,omitempty"`
+	ResolvedAt        *timestamppb.Timestamp `protobuf:"bytes,15,opt,name=resolved_at,json=resolvedAt,proto3" json:"resolved_at,omitempty"`
+	ReplayedAt        *timestamppb.Timestamp `protobuf:"bytes,16,opt,name=replayed_at,json=replayedAt,proto3" json:"replayed_at,omitempty"`
+	Note              string                 `protobuf:"bytes,17,opt,name=note,proto3" json:"note,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *DeadLetter) Reset() {
+	*x = DeadLetter{}
+	mi := &file_contracts_orders_dead_letters_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeadLetter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeadLetter) ProtoMessage() {}
+
+func (x *DeadLetter) ProtoReflect() protoreflect.Message {
+	mi := &file_contracts_orders_dead_letters_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeadLetter.ProtoReflect.Descriptor instead.
+func (*DeadLetter) Descriptor() ([]byte, []int) {
+	return file_contracts_orders_dead_letters_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *DeadLetter) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *DeadLetter) GetFailedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.FailedAt
+	}
+	return nil
+}
+
+func (x *DeadLetter) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *DeadLetter) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *DeadLetter) GetOriginalTopic() string {
+	if x != nil {
+		return x.OriginalTopic
+	}
+	return ""
+}
+
+func (x *DeadLetter) GetOriginalKey() []byte {
+	if x != nil {
+		return x.OriginalKey
+	}
+	return nil
+}
+
+func (x *DeadLetter) GetOriginalValue() []byte {
+	if x != nil {
+		return x.OriginalValue
+	}
+	return nil
+}
+
+func (x *DeadLetter) GetOriginalHeaders() map[string]string {
+	if x != nil {
+		return x.OriginalHeaders
+	}
+	return nil
+}
+
+func (x *DeadLetter) GetOriginalPartition() int32 {
+	if x != nil {
+		return x.OriginalPartition
+	}
+	return 0
+}
+
+func (x *DeadLetter) GetOriginalOffset() int64 {
+	if x != nil {
+		return x.OriginalOffset
+	}
+	return 0
+}
+
+func (x *DeadLetter) GetAttempts() int32 {
+	if x != nil {
+		return x.Attempts
+	}
+	return 0
+}
+
+func (x *DeadLetter) GetTraceId() string {
+	if x != nil {
+		return x.TraceId
+	}
+	return ""
+}
+
+func (x *DeadLetter) GetSpanId() string {
+	if x != nil {
+		return x.SpanId
+	}
+	return ""
+}
+
+func (x *DeadLetter) GetStatus() DeadLetterStatus {
+	if x != nil {
+		return x.Status
+	}
+	return DeadLetterStatus_DEAD_LETTER_OPEN
+}
+
+func (x *DeadLetter) GetResolvedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ResolvedAt
+	}
+	return nil
+}
+
+func (x *DeadLetter) GetReplayedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ReplayedAt
+	}
+	return nil
+}
+
+func (x *DeadLetter) GetNote() string {
+	if x != nil {
+		return x.Note
+	}
+	return ""
+}
+
+// All filters are optional and combined with AND
+type DeadLetterFilter struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Reasons        []string               `protobuf:"bytes,1,rep,name=reasons,proto3" json:"reasons,omitempty"`
+	OriginalTopics []string               `protobuf:"bytes,2,rep,name=original_topics,json=originalTopics,proto3" json:"original_topics,omitempty"`
+	// Case insensitive substring of error
+	ErrorContains string                 `protobuf:"bytes,3,opt,name=error_contains,json=errorContains,proto3" json:"error_contains,omitempty"`
+	Statuses      []DeadLetterStatus     `protobuf:"varint,4,rep,packed,name=statuses,proto3,enum=order.DeadLetterStatus" json:"statuses,omitempty"`
+	FailedAfter   *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=failed_after,json=failedAfter,proto3" json:"failed_after,omitempty"`
+	FailedBefore  *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=failed_before,json=failedBefore,proto3" json:"failed_before,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeadLetterFilter) Reset() {
+	*x = DeadLetterFilter{}
+	mi := &file_contracts_orders_dead_letters_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeadLetterFilter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeadLetterFilter) ProtoMessage() {}
+
+func (x *DeadLetterFilter) ProtoReflect() protoreflect.Message {
+	mi := &file_contracts_orders_dead_letters_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeadLetterFilter.ProtoReflect.Descriptor instead.
+func (*DeadLetterFilter) Descriptor() ([]byte, []int) {
+	return file_contracts_orders_dead_letters_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *DeadLetterFilter) GetReasons() []string {
+	if x != nil {
+		return x.Reasons
+	}
+	return nil
+}
+
+func (x *DeadLetterFilter) GetOriginalTopics() []string {
+	if x != nil {
+		return x.OriginalTopics
+	}
+	return nil
+}
+
+func (x *DeadLetterFilter) GetErrorContains() string {
+	if x != nil {
+		return x.ErrorContains
+	}
+	return ""
+}
+
+func (x *DeadLetterFilter) GetStatuses() []DeadLetterStatus {
+	if x != nil {
+		return x.Statuses
+	}
+	return nil
+}
+
+func (x *DeadLetterFilter) GetFailedAfter() *timestamppb.Timestamp {
+	if x != nil {
+		return x.FailedAfter
+	}
+	return nil
+}
+
+func (x *DeadLetterFilter) GetFailedBefore() *timestamppb.Timestamp {
+	if x != nil {
+		return x.FailedBefore
+	}
+	return nil
+}
+
+// Newest first
+type ListDeadLettersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Filter        *DeadLetterFilter      `protobuf:"bytes,1,opt,name=filter,proto3" json:"filter,omitempty"`
+	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	PageToken     string                 `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDeadLettersRequest) Reset() {
+	*x = ListDeadLettersRequest{}
+	mi := &file_contracts_orders_dead_letters_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDeadLettersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDeadLettersRequest) ProtoMessage() {}
+
+func (x *ListDeadLettersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_contracts_orders_dead_letters_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDeadLettersRequest.ProtoReflect.Descriptor instead.
+func (*ListDeadLettersRequest) Descriptor() ([]byte, []int) {
+	return file_contracts_orders_dead_letters_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ListDeadLettersRequest) GetFilter() *DeadLetterFilter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+func (x *ListDeadLettersRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListDeadLettersRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type ListDeadLettersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DeadLetters   []*DeadLetter          `protobuf:"bytes,1,rep,name=dead_letters,json=deadLetters,proto3" json:"dead_letters,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDeadLettersResponse) Reset() {
+	*x = ListDeadLettersResponse{}
+	mi := &file_contracts_orders_dead_letters_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDeadLettersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDeadLettersResponse) ProtoMessage() {}
+
+func (x *ListDeadLettersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_contracts_orders_dead_letters_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDeadLettersResponse.ProtoReflect.Descriptor instead.
+func (*ListDeadLettersResponse) Descriptor() ([]byte, []int) {
+	return file_contracts_orders_dead_letters_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ListDeadLettersResponse) GetDeadLetters() []*DeadLetter {
+	if x != nil {
+		return x.DeadLetters
+	}
+	return nil
+}
+
+func (x *ListDeadLettersResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type GetDeadLetterRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDeadLetterRequest) Reset() {
+	*x = GetDeadLetterRequest{}
+	mi := &file_contracts_orders_dead_letters_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDeadLetterRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDeadLetterRequest) ProtoMessage() {}
+
+func (x *GetDeadLetterRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_contracts_orders_dead_letters_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDeadLetterRequest.ProtoReflect.Descriptor instead.
+func (*GetDeadLetterRequest) Descriptor() ([]byte, []int) {
+	return file_contracts_orders_dead_letters_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetDeadLetterRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type ResolveDeadLetterRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Note          string                 `protobuf:"bytes,2,opt,name=note,proto3" json:"note,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResolveDeadLetterRequest) Reset() {
+	*x = ResolveDeadLetterRequest{}
+	mi := &file_contracts_orders_dead_letters_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResolveDeadLetterRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResolveDeadLetterRequest) ProtoMessage() {}
+
+func (x *ResolveDeadLetterRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_contracts_orders_dead_letters_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResolveDeadLetterRequest.ProtoReflect.Descriptor instead.
+func (*ResolveDeadLetterRequest) Descriptor() ([]byte, []int) {
+	return file_contracts_orders_dead_letters_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ResolveDeadLetterRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *ResolveDeadLetterRequest) GetNote() string {
+	if x != nil {
+		return x.Note
+	}
+	return ""
+}
+
+// Republishes the original message to original_topic
+type ReplayDeadLetterRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReplayDeadLetterRequest) Reset() {
+	*x = ReplayDeadLetterRequest{}
+	mi := &file_contracts_orders_dead_letters_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReplayDeadLetterRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReplayDeadLetterRequest) ProtoMessage() {}
+
+func (x *ReplayDeadLetterRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_contracts_orders_dead_letters_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReplayDeadLetterRequest.ProtoReflect.Descriptor instead.
+func (*ReplayDeadLetterRequest) Descriptor() ([]byte, []int) {
+	return file_contracts_orders_dead_letters_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ReplayDeadLetterRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+// Needs statuses or failed_before, so a purge never empties the table by accident
+type PurgeDeadLettersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Filter        *DeadLetterFilter      `protobuf:"bytes,1,opt,name=filter,proto3" json:"filter,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PurgeDeadLettersRequest) Reset() {
+	*x = PurgeDeadLettersRequest{}
+	mi := &file_contracts_orders_dead_letters_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PurgeDeadLettersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PurgeDeadLettersRequest) ProtoMessage() {}
+
+func (x *PurgeDeadLettersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_contracts_orders_dead_letters_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PurgeDeadLettersRequest.ProtoReflect.Descriptor instead.
+func (*PurgeDeadLettersRequest) Descriptor() ([]byte, []int) {
+	return file_contracts_orders_dead_letters_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *PurgeDeadLettersRequest) GetFilter() *DeadLetterFilter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+type PurgeDeadLettersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Deleted       int64                  `protobuf:"varint,1,opt,name=deleted,proto3" json:"deleted,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PurgeDeadLettersResponse) Reset() {
+	*x = PurgeDeadLettersResponse{}
+	mi := &file_contracts_orders_dead_letters_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PurgeDeadLettersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PurgeDeadLettersResponse) ProtoMessage() {}
+
+func (x *PurgeDeadLettersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_contracts_orders_dead_letters_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PurgeDeadLettersResponse.ProtoReflect.Descriptor instead.
+func (*PurgeDeadLettersResponse) Descriptor() ([]byte, []int) {
+	return file_contracts_orders_dead_letters_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *PurgeDeadLettersResponse) GetDeleted() int64 {
+	if x != nil {
+		return x.Deleted
+	}
+	return 0
+}
+
+type CountDeadLettersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Filter        *DeadLetterFilter      `protobuf:"bytes,1,opt,name=filter,proto3" json:"filter,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CountDeadLettersRequest) Reset() {
+	*x = CountDeadLettersRequest{}
+	mi := &file_contracts_orders_dead_letters_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CountDeadLettersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CountDeadLettersRequest) ProtoMessage() {}
+
+func (x *CountDeadLettersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_contracts_orders_dead_letters_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CountDeadLettersRequest.ProtoReflect.Descriptor instead.
+func (*CountDeadLettersRequest) Descriptor() ([]byte, []int) {
+	return file_contracts_orders_dead_letters_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *CountDeadLettersRequest) GetFilter() *DeadLetterFilter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+type ReasonCount struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Reason        string                 `protobuf:"bytes,1,opt,name=reason,proto3" json:"reason,omitempty"`
+	Open          int64                  `protobuf:"varint,2,opt,name=open,proto3" json:"open,omitempty"`
+	Resolved      int64                  `protobuf:"varint,3,opt,name=resolved,proto3" json:"resolved,omitempty"`
+	Replayed      int64                  `protobuf:"varint,4,opt,name=replayed,proto3" json:"replayed,omitempty"`
+	Total         int64                  `protobuf:"varint,5,opt,name=total,proto3" json:"total,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReasonCount) Reset() {
+	*x = ReasonCount{}
+	mi := &file_contracts_orders_dead_letters_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReasonCount) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReasonCount) ProtoMessage() {}
+
+func (x *ReasonCount) ProtoReflect() protoreflect.Message {
+	mi := &file_contracts_orders_dead_letters_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReasonCount.ProtoReflect.Descriptor instead.
+func (*ReasonCount) Descriptor() ([]byte, []int) {
+	return file_contracts_orders_dead_letters_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ReasonCount) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *ReasonCount) GetOpen() int64 {
+	if x != nil {
+		return x.Open
+	}
+	return 0
+}
+
+func (x *ReasonCount) GetResolved() int64 {
+	if x != nil {
+		return x.Resolved
+	}
+	return 0
+}
+
+func (x *ReasonCount) GetReplayed() int64 {
+	if x != nil {
+		return x.Replayed
+	}
+	return 0
+}
+
+func (x *ReasonCount) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+type CountDeadLettersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Counts        []*ReasonCount         `protobuf:"bytes,1,rep,name=counts,proto3" json:"counts,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CountDeadLettersResponse) Reset() {
+	*x = CountDeadLettersResponse{}
+	mi := &file_contracts_orders_dead_letters_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CountDeadLettersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CountDeadLettersResponse) ProtoMessage() {}
+
+func (x *CountDeadLettersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_contracts_orders_dead_letters_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CountDeadLettersResponse.ProtoReflect.Descriptor instead.
+func (*CountDeadLettersResponse) Descriptor() ([]byte, []int) {
+	return file_contracts_orders_dead_letters_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *CountDeadLettersResponse) GetCounts() []*ReasonCount {
+	if x != nil {
+		return x.Counts
+	}
+	return nil
+}
+
+var File_contracts_orders_dead_letters_proto protoreflect.FileDescriptor
+
+const file_contracts_orders_dead_letters_proto_rawDesc = "" +
+	"\n" +
+	"#contracts/orders/dead_letters.proto\x12\x05order\x1a\x1fgoogle/protobuf/timestamp.proto\"\xf2\x05\n" +
+	"\n" +
+	"DeadLetter\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x127\n" +
+	"\tfailed_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\bfailedAt\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\x12\x14\n" +
+	"\x05error\x18\x04 \x01(\tR\x05error\x12%\n" +
+	"\x0eoriginal_topic\x18\x05 \x01(\tR\roriginalTopic\x12!\n" +
+	"\foriginal_key\x18\x06 \x01(\fR\voriginalKey\x12%\n" +
+	"\x0eoriginal_value\x18\a \x01(\fR\roriginalValue\x12Q\n" +
+	"\x10original_headers\x18\b \x03(\v2&.order.DeadLetter.OriginalHeadersEntryR\x0foriginalHeaders\x12-\n" +
+	"\x12original_partition\x18\t \x01(\x05R\x11originalPartition\x12'\n" +
+	"\x0foriginal_offset\x18\n" +
+	" \x01(\x03R\x0eoriginalOffset\x12\x1a\n" +
+	"\battempts\x18\v \x01(\x05R\battempts\x12\x19\n" +
+	"\btrace_id\x18\f \x01(\tR\atraceId\x12\x17\n" +
+	"\aspan_id\x18\r \x01(\tR\x06spanId\x12/\n" +
+	"\x06status\x18\x0e \x01(\x0e2\x17.order.DeadLetterStatusR\x06status\x12;\n" +
+	"\vresolved_at\x18\x0f \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"resolvedAt\x12;\n" +
+	"\vreplayed_at\x18\x10 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"replayedAt\x12\x12\n" +
+	"\x04note\x18\x11 \x01(\tR\x04note\x1aB\n" +
+	"\x14OriginalHeadersEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xb1\x02\n" +
+	"\x10DeadLetterFilter\x12\x18\n" +
+	"\areasons\x18\x01 \x03(\tR\areasons\x12'\n" +
+	"\x0foriginal_topics\x18\x02 \x03(\tR\x0eoriginalTopics\x12%\n" +
+	"\x0eerror_contains\x18\x03 \x01(\tR\rerrorContains\x123\n" +
+	"\bstatuses\x18\x04 \x03(\x0e2\x17.order.DeadLetterStatusR\bstatuses\x12=\n" +
+	"\ffailed_after\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\vfailedAfter\x12?\n" +
+	"\rfailed_before\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\ffailedBefore\"~\n" +
+	"\x16ListDeadLettersRequest\x12/\n" +
+	"\x06filter\x18\x01 \x01(\v2\x17.order.DeadLetterFilterR\x06filter\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x03 \x01(\tR\tpageToken\"w\n" +
+	"\x17ListDeadLettersResponse\x124\n" +
+	"\fdead_letters\x18\x01 \x03(\v2\x11.order.DeadLetterR\vdeadLetters\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"&\n" +
+	"\x14GetDeadLetterRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\">\n" +
+	"\x18ResolveDeadLetterRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x12\n" +
+	"\x04note\x18\x02 \x01(\tR\x04note\")\n" +
+	"\x17ReplayDeadLetterRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\"J\n" +
+	"\x17PurgeDeadLettersRequest\x12/\n" +
+	"\x06filter\x18\x01 \x01(\v2\x17.order.DeadLetterFilterR\x06filter\"4\n" +
+	"\x18PurgeDeadLettersResponse\x12\x18\n" +
+	"\adeleted\x18\x01 \x01(\x03R\adeleted\"J\n" +
+	"\x17CountDeadLettersRequest\x12/\n" +
+	"\x06filter\x18\x01 \x01(\v2\x17.order.DeadLetterFilterR\x06filter\"\x87\x01\n" +
+	"\vReasonCount\x12\x16\n" +
+	"\x06reason\x18\x01 \x01(\tR\x06reason\x12\x12\n" +
+	"\x04open\x18\x02 \x01(\x03R\x04open\x12\x1a\n" +
+	"\bresolved\x18\x03 \x01(\x03R\bresolved\x12\x1a\n" +
+	"\breplayed\x18\x04 \x01(\x03R\breplayed\x12\x14\n" +
+	"\x05total\x18\x05 \x01(\x03R\x05total\"F\n" +
+	"\x18CountDeadLettersResponse\x12*\n" +
+	"\x06counts\x18\x01 \x03(\v2\x12.order.ReasonCountR\x06counts*\\\n" +
+	"\x10DeadLetterStatus\x12\x14\n" +
+	"\x10DEAD_LETTER_OPEN\x10\x00\x12\x18\n" +
+	"\x14DEAD_LETTER_RESOLVED\x10\x01\x12\x18\n" +
+	"\x14DEAD_LETTER_REPLAYED\x10\x022\xe5\x03\n" +
+	"\x16DeadLetterAdminService\x12P\n" +
+	"\x0fListDeadLetters\x12\x1d.order.ListDeadLettersRequest\x1a\x1e.order.ListDeadLettersResponse\x12?\n" +
+	"\rGetDeadLetter\x12\x1b.order.GetDeadLetterRequest\x1a\x11.order.DeadLetter\x12G\n" +
+	"\x11ResolveDeadLetter\x12\x1f.order.ResolveDeadLetterRequest\x1a\x11.order.DeadLetter\x12E\n" +
+	"\x10ReplayDeadLetter\x12\x1e.order.ReplayDeadLetterRequest\x1a\x11.order.DeadLetter\x12S\n" +
+	"\x10PurgeDeadLetters\x12\x1e.order.PurgeDeadLettersRequest\x1a\x1f.order.PurgeDeadLettersResponse\x12S\n" +
+	"\x10CountDeadLetters\x12\x1e.order.CountDeadLettersRequest\x1a\x1f.order.CountDeadLettersResponseB>Z<github.com/Anacardo89/order_svc_hex/contracts/orders;orderpbb\x06proto3"
+
+var (
+	file_contracts_orders_dead_letters_proto_rawDescOnce sync.Once
+	file_contracts_orders_dead_letters_proto_rawDescData []byte
+)
+
+func file_contracts_orders_dead_letters_proto_rawDescGZIP() []byte {
+	file_contracts_orders_dead_letters_proto_rawDescOnce.Do(func() {
+		file_contracts_orders_dead_letters_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_contracts_orders_dead_letters_proto_rawDesc), len(file_contracts_orders_dead_letters_proto_rawDesc)))
+	})
+	return file_contracts_orders_dead_letters_proto_rawDescData
+}
+
+var file_contracts_orders_dead_letters_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_contracts_orders_dead_letters_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
+var file_contracts_orders_dead_letters_proto_goTypes = []any{
+	(DeadLetterStatus)(0),            // 0: order.DeadLetterStatus
+	(*DeadLetter)(nil),               // 1: order.DeadLetter
+	(*DeadLetterFilter)(nil),         // 2: order.DeadLetterFilter
+	(*ListDeadLettersRequest)(nil),   // 3: order.ListDeadLettersRequest
+	(*ListDeadLettersResponse)(nil),  // 4: order.ListDeadLettersResponse
+	(*GetDeadLetterRequest)(nil),     // 5: order.GetDeadLetterRequest
+	(*ResolveDeadLetterRequest)(nil), // 6: order.ResolveDeadLetterRequest
+	(*ReplayDeadLetterRequest)(nil),  // 7: order.ReplayDeadLetterRequest
+	(*PurgeDeadLettersRequest)(nil),  // 8: order.PurgeDeadLettersRequest
+	(*PurgeDeadLettersResponse)(nil), // 9: order.PurgeDeadLettersResponse
+	(*CountDeadLettersRequest)(nil),  // 10: order.CountDeadLettersRequest
+	(*ReasonCount)(nil),              // 11: order.ReasonCount
+	(*CountDeadLettersResponse)(nil), // 12: order.CountDeadLettersResponse
+	nil,                              // 13: order.DeadLetter.OriginalHeadersEntry
+	(*timestamppb.Timestamp)(nil),    // 14: google.protobuf.Timestamp
+}
+var file_contracts_orders_dead_letters_proto_depIdxs = []int32{
+	14, // 0: order.DeadLetter.failed_at:type_name -> google.protobuf.Timestamp
+	13, // 1: order.DeadLetter.original_headers:type_name -> order.DeadLetter.OriginalHeadersEntry
+	0,  // 2: order.DeadLetter.status:type_name -> order.DeadLetterStatus
+	14, // 3: order.DeadLetter.resolved_at:type_name -> google.protobuf.Timestamp
+	14, // 4: order.DeadLetter.replayed_at:type_name -> google.protobuf.Timestamp
+	0,  // 5: order.DeadLetterFilter.statuses:type_name -> order.DeadLetterStatus
+	14, // 6: order.DeadLetterFilter.failed_after:type_name -> google.protobuf.Timestamp
+	14, // 7: order.DeadLetterFilter.failed_before:type_name -> google.protobuf.Timestamp
+	2,  // 8: order.ListDeadLettersRequest.filter:type_name -> order.DeadLetterFilter
+	1,  // 9: order.ListDeadLettersResponse.dead_letters:type_name -> order.DeadLetter
+	2,  // 10: order.PurgeDeadLettersRequest.filter:type_name -> order.DeadLetterFilter
+	2,  // 11: order.CountDeadLettersRequest.filter:type_name -> order.DeadLetterFilter
+	11, // 12: order.CountDeadLettersResponse.counts:type_name -> order.ReasonCount
+	3,  // 13: order.DeadLetterAdminService.ListDeadLetters:input_type -> order.ListDeadLettersRequest
+	5,  // 14: order.DeadLetterAdminService.GetDeadLetter:input_type -> order.GetDeadLetterRequest
+	6,  // 15: order.DeadLetterAdminService.ResolveDeadLetter:input_type -> order.ResolveDeadLetterRequest
+	7,  // 16: order.DeadLetterAdminService.ReplayDeadLetter:input_type -> order.ReplayDeadLetterRequest
+	8,  // 17: order.DeadLetterAdminService.PurgeDeadLetters:input_type -> order.PurgeDeadLettersRequest
+	10, // 18: order.DeadLetterAdminService.CountDeadLetters:input_type -> order.CountDeadLettersRequest
+	4,  // 19: order.DeadLetterAdminService.ListDeadLetters:output_type -> order.ListDeadLettersResponse
+	1,  // 20: order.DeadLetterAdminService.GetDeadLetter:output_type -> order.DeadLetter
+	1,  // 21: order.DeadLetterAdminService.ResolveDeadLetter:output_type -> order.DeadLetter
+	1,  // 22: order.DeadLetterAdminService.ReplayDeadLetter:output_type -> order.DeadLetter
+	9,  // 23: order.DeadLetterAdminService.PurgeDeadLetters:output_type -> order.PurgeDeadLettersResponse
+	12, // 24: order.DeadLetterAdminService.CountDeadLetters:output_type -> order.CountDeadLettersResponse
+	19, // [19:25] is the sub-list for method output_type
+	13, // [13:19] is the sub-list for method input_type
+	13, // [13:13] is the sub-list for extension type_name
+	13, // [13:13] is the sub-list for extension extendee
+	0,  // [0:13] is the sub-list for field type_name
+}
+
+func init() { file_contracts_orders_dead_letters_proto_init() }
+func file_contracts_orders_dead_letters_proto_init() {
+	if File_contracts_orders_dead_letters_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_contracts_orders_dead_letters_proto_rawDesc), len(file_contracts_orders_dead_letters_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   13,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_contracts_orders_dead_letters_proto_goTypes,
+		DependencyIndexes: file_contracts_orders_dead_letters_proto_depIdxs,
+		EnumInfos:         file_contracts_orders_dead_letters_proto_enumTypes,
+		MessageInfos:      file_contracts_orders_dead_letters_proto_msgTypes,
+	}.Build()
+	File_contracts_orders_dead_letters_proto = out.File
+	file_contracts_orders_dead_letters_proto_goTypes = nil
+	file_contracts_orders_dead_letters_proto_depIdxs = nil
+}
@@ -0,0 +1,159 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/Anacardo89/order_svc_hex/order_svc/config"
+	"github.com/Anacardo89/order_svc_hex/order_svc/internal/adapters/in/messaging/kafka/dlqreader"
+	"github.com/Anacardo89/order_svc_hex/order_svc/internal/adapters/infra/log/loki/logger"
+	"github.com/Anacardo89/order_svc_hex/order_svc/internal/adapters/out/messaging/kafka/orderreplay"
+	"github.com/Anacardo89/order_svc_hex/order_svc/internal/adapters/out/store/pgx/orderrepo"
+	"github.com/Anacardo89/order_svc_hex/order_svc/internal/core"
+	"github.com/Anacardo89/order_svc_hex/order_svc/pkg/db"
+	"github.com/Anacardo89/order_svc_hex/order_svc/pkg/events"
+)
+
+// Re-drives DLQ entries to the topic they were first published to.
+// Usage: dlqreplay [-since 2026-01-02T15:04:05Z] [-reason handler_error] [-dry-run]
+func main() {
+	// Setup
+	var (
+		fromOffset    = flag.Int64("from-offset", -1, "first DLQ offset to read, inclusive")
+		toOffset      = flag.Int64("to-offset", -1, "last DLQ offset to read, inclusive")
+		since         = flag.String("since", "", "read entries written at or after this RFC3339 time")
+		until         = flag.String("until", "", "read entries written at or before this RFC3339 time")
+		reasons       = flag.String("reason", "", "comma separated reasons to replay")
+		topics        = flag.String("original-topic", "", "comma separated original topics to replay")
+		errorContains = flag.String("error-contains", "", "replay entries whose error contains this text")
+		dryRun        = flag.Bool("dry-run", false, "report what would be replayed without publishing")
+		reportPath    = flag.String("report", "", "write the JSON report to this file instead of stdout")
+	)
+	flag.Parse()
+	rng, err := parseRange(*fromOffset, *toOffset, *since, *until)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		flag.Usage()
+		os.Exit(2)
+	}
+	filter := core.DeadLetterFilter{
+		Reasons:        splitList(*reasons),
+		OriginalTopics: splitList(*topics),
+		ErrorContains:  *errorContains,
+	}
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		slog.Error("failed to load config", "error", err)
+		os.Exit(1)
+	}
+	logger.BaseLogger = logger.NewLogger(cfg.Log.Endpoint, map[string]string{
+		"service": "order_svc_dlqreplay",
+	})
+	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+	dlqTopic, ok := cfg.Kafka.Topics["OrderDLQ"]
+	if !ok {
+		slog.Error("no topic for OrderDLQ defined")
+		os.Exit(1)
+	}
+	conn := events.NewKafkaConnection(cfg.Kafka.Brokers)
+	reader, err := dlqreader.NewDlqReader(conn, cfg.Kafka.GroupID+"-dlqreplay", dlqTopic)
+	if err != nil {
+		slog.Error("failed to create DLQ reader", "error", err)
+		os.Exit(1)
+	}
+	defer reader.Close()
+	replayClient, err := orderreplay.NewReplayClient(conn)
+	if err != nil {
+		slog.Error("failed to create replay client", "error", err)
+		os.Exit(1)
+	}
+	defer replayClient.Close()
+	// Replayed entries are settled in dead_letters as the admin API does
+	dbConn, err := db.Connect(cfg.DB.DSN, cfg.DB.MaxConns, cfg.DB.MinConns, cfg.DB.MaxConnLifetime, cfg.DB.MaxConnIdleTime)
+	if err != nil {
+		slog.Error("failed to connect to db", "error", err)
+		os.Exit(1)
+	}
+	defer dbConn.Close()
+	store := orderrepo.NewRepo(dbConn)
+
+	// Execution
+	report, err := replay(ctx, reader, replayClient, store, rng, filter, *dryRun)
+	if writeErr := writeReport(*reportPath, report); writeErr != nil {
+		slog.Error("failed to write report", "error", writeErr)
+	}
+	if err != nil {
+		slog.Error("DLQ read stopped early, the report is partial", "error", err)
+		os.Exit(1)
+	}
+	if report.Failed > 0 {
+		os.Exit(1)
+	}
+}
+
+func parseRange(fromOffset, toOffset int64, since, until string) (core.DeadLetterRange, error) {
+	var rng core.DeadLetterRange
+	if fromOffset >= 0 {
+		rng.FromOffset = &fromOffset
+	}
+	if toOffset >= 0 {
+		rng.ToOffset = &toOffset
+	}
+	if since != "" {
+		t, err := time.Parse(time.RFC3339, since)
+		if err != nil {
+			return rng, fmt.Errorf("invalid -since: %w", err)
+		}
+		rng.Since = &t
+	}
+	if until != "" {
+		t, err := time.Parse(time.RFC3339, until)
+		if err != nil {
+			return rng, fmt.Errorf("invalid -until: %w", err)
+		}
+		rng.Until = &t
+	}
+	if rng.FromOffset != nil && rng.ToOffset != nil && *rng.FromOffset > *rng.ToOffset {
+		return rng, errors.New("-from-offset is after -to-offset")
+	}
+	if rng.Since != nil && rng.Until != nil && rng.Since.After(*rng.Until) {
+		return rng, errors.New("-since is after -until")
+	}
+	return rng, nil
+}
+
+// The logger also writes to stdout, a file keeps the report parseable
+func writeReport(path string, report *Report) error {
+	out := os.Stdout
+	if path != "" {
+		f, err := os.Create(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		out = f
+	}
+	enc := json.NewEncoder(out)
+	enc.SetIndent("", "  ")
+	return enc.Encode(report)
+}
+
+func splitList(s string) []string {
+	var out []string
+	for _, v := range strings.Split(s, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			out = append(out, v)
+		}
+	}
+	return out
+}
@@ -0,0 +1,119 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Anacardo89/order_svc_hex/order_svc/internal/core"
+	"github.com/Anacardo89/order_svc_hex/order_svc/internal/ports"
+)
+
+type EntryStatus string
+
+const (
+	StatusReplayed    EntryStatus = "replayed"
+	StatusWouldReplay EntryStatus = "would_replay"
+	StatusFailed      EntryStatus = "failed"
+	// Resolved or replayed already according to dead_letters
+	StatusSkipped EntryStatus = "skipped"
+)
+
+type ReportEntry struct {
+	Partition     int32  `json:"partition"`
+	Offset        int64  `json:"offset"`
+	Reason        string `json:"reason"`
+	OriginalTopic string `json:"original_topic"`
+	OriginalKey   string `json:"original_key,omitempty"`
+	// Zero when the entry was never indexed
+	DeadLetterID int64       `json:"dead_letter_id,omitempty"`
+	Status       EntryStatus `json:"status"`
+	Error        string      `json:"error,omitempty"`
+}
+
+type Report struct {
+	DryRun   bool           `json:"dry_run"`
+	Scanned  int            `json:"scanned"`
+	Matched  int            `json:"matched"`
+	Replayed int            `json:"replayed"`
+	Skipped  int            `json:"skipped"`
+	Failed   int            `json:"failed"`
+	ByReason map[string]int `json:"by_reason"`
+	Entries  []ReportEntry  `json:"entries"`
+}
+
+// A failed or undecodable entry is reported and the run goes on, only read errors stop it.
+// Indexed entries are replayed only while open and marked replayed after, like the admin API does
+func replay(ctx context.Context, source ports.DeadLetterSource, replayer ports.DeadLetterReplayer, store ports.DeadLetterStore, rng core.DeadLetterRange, filter core.DeadLetterFilter, dryRun bool) (*Report, error) {
+	report := &Report{
+		DryRun:   dryRun,
+		ByReason: map[string]int{},
+		Entries:  []ReportEntry{},
+	}
+	err := source.Read(ctx, rng, func(l *core.DeadLetter, readErr error) error {
+		report.Scanned++
+		if readErr != nil {
+			report.Failed++
+			report.Entries = append(report.Entries, ReportEntry{
+				Partition: l.Partition,
+				Offset:    l.Offset,
+				Status:    StatusFailed,
+				Error:     readErr.Error(),
+			})
+			return nil
+		}
+		if !filter.Matches(l) {
+			return nil
+		}
+		report.Matched++
+		report.ByReason[l.Reason]++
+		entry := ReportEntry{
+			Partition:     l.Partition,
+			Offset:        l.Offset,
+			Reason:        l.Reason,
+			OriginalTopic: l.OriginalTopic,
+			OriginalKey:   string(l.OriginalKey),
+			Status:        StatusWouldReplay,
+		}
+		report.Entries = append(report.Entries, settle(ctx, report, replayer, store, l, entry, dryRun))
+		return nil
+	})
+	return report, err
+}
+
+func settle(ctx context.Context, report *Report, replayer ports.DeadLetterReplayer, store ports.DeadLetterStore, l *core.DeadLetter, entry ReportEntry, dryRun bool) ReportEntry {
+	indexed, err := store.FindDeadLetter(ctx, l.OriginalTopic, l.OriginalPartition, l.OriginalOffset)
+	if err != nil && !errors.Is(err, core.ErrDeadLetterNotFound) {
+		entry.Status = StatusFailed
+		entry.Error = fmt.Sprintf("failed to look up dead letter: %s", err)
+		report.Failed++
+		return entry
+	}
+	if indexed != nil {
+		entry.DeadLetterID = indexed.ID
+		if indexed.Status != core.DeadLetterOpen {
+			entry.Status = StatusSkipped
+			entry.Error = fmt.Sprintf("dead letter is %s", indexed.Status)
+			report.Skipped++
+			return entry
+		}
+	}
+	if dryRun {
+		return entry
+	}
+	if err := replayer.Replay(ctx, l); err != nil {
+		entry.Status = StatusFailed
+		entry.Error = err.Error()
+		report.Failed++
+		return entry
+	}
+	entry.Status = StatusReplayed
+	report.Replayed++
+	if indexed != nil {
+		// Already published, the entry still counts as replayed
+		if _, err := store.MarkDeadLetterReplayed(ctx, indexed.ID); err != nil {
+			entry.Error = fmt.Sprintf("replayed, but failed to mark dead letter replayed: %s", err)
+		}
+	}
+	return entry
+}
@@ -0,0 +1,162 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Anacardo89/order_svc_hex/order_svc/internal/core"
+	"github.com/Anacardo89/order_svc_hex/order_svc/internal/ports"
+)
+
+type fakeSource struct {
+	letters []*core.DeadLetter
+	// Offsets handed over as undecodable
+	bad map[int64]error
+}
+
+func (f *fakeSource) Read(ctx context.Context, rng core.DeadLetterRange, fn func(*core.DeadLetter, error) error) error {
+	for _, l := range f.letters {
+		if err := fn(l, f.bad[l.Offset]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Indexed entries by original offset
+type fakeStore struct {
+	ports.DeadLetterStore
+	letters map[int64]*core.DeadLetter
+}
+
+func (f *fakeStore) FindDeadLetter(ctx context.Context, topic string, partition int32, offset int64) (*core.DeadLetter, error) {
+	l, ok := f.letters[offset]
+	if !ok {
+		return nil, core.ErrDeadLetterNotFound
+	}
+	return l, nil
+}
+
+func (f *fakeStore) MarkDeadLetterReplayed(ctx context.Context, id int64) (*core.DeadLetter, error) {
+	for _, l := range f.letters {
+		if l.ID == id {
+			l.Status = core.DeadLetterReplayed
+			return l, nil
+		}
+	}
+	return nil, core.ErrDeadLetterNotFound
+}
+
+type fakeReplayer struct {
+	replayed []int64
+	failAt   int64
+}
+
+func (f *fakeReplayer) Replay(ctx context.Context, l *core.DeadLetter) error {
+	if l.Offset == f.failAt {
+		return errors.New("broker down")
+	}
+	f.replayed = append(f.replayed, l.Offset)
+	return nil
+}
+
+func TestReplay(t *testing.T) {
+	source := &fakeSource{letters: []*core.DeadLetter{
+		{Offset: 0, Reason: "handler_error", OriginalTopic: "orders.created", OriginalOffset: 100, Error: "connection reset by peer"},
+		{Offset: 1, Reason: "unmarshal_failed", OriginalTopic: "orders.created", OriginalOffset: 101, Error: "bad json"},
+		{Offset: 2, Reason: "handler_error", OriginalTopic: "orders.status_updated", OriginalOffset: 102, Error: "Connection reset"},
+		{Offset: 3, Reason: "handler_error", OriginalTopic: "orders.created", OriginalOffset: 103, Error: "deadlock detected"},
+	}}
+	filter := core.DeadLetterFilter{
+		Reasons:       []string{"handler_error"},
+		ErrorContains: "connection reset",
+	}
+
+	tests := []struct {
+		name         string
+		dryRun       bool
+		failAt       int64
+		indexed      map[int64]*core.DeadLetter
+		wantReplayed []int64
+		wantStatuses []EntryStatus
+		wantFailed   int
+		wantSettled  map[int64]core.DeadLetterStatus
+	}{
+		{
+			name:         "dry run publishes nothing",
+			dryRun:       true,
+			failAt:       -1,
+			wantStatuses: []EntryStatus{StatusWouldReplay, StatusWouldReplay},
+		},
+		{
+			name:         "replays matching entries",
+			failAt:       -1,
+			wantReplayed: []int64{0, 2},
+			wantStatuses: []EntryStatus{StatusReplayed, StatusReplayed},
+		},
+		{
+			name:         "a failed replay doesn't stop the run",
+			failAt:       0,
+			wantReplayed: []int64{2},
+			wantStatuses: []EntryStatus{StatusFailed, StatusReplayed},
+			wantFailed:   1,
+		},
+		{
+			name:   "indexed entries are replayed only while open",
+			failAt: -1,
+			indexed: map[int64]*core.DeadLetter{
+				100: {ID: 1, Status: core.DeadLetterOpen},
+				102: {ID: 2, Status: core.DeadLetterResolved},
+			},
+			wantReplayed: []int64{0},
+			wantStatuses: []EntryStatus{StatusReplayed, StatusSkipped},
+			wantSettled:  map[int64]core.DeadLetterStatus{100: core.DeadLetterReplayed, 102: core.DeadLetterResolved},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			replayer := &fakeReplayer{failAt: tt.failAt}
+			store := &fakeStore{letters: tt.indexed}
+			report, err := replay(context.Background(), source, replayer, store, core.DeadLetterRange{}, filter, tt.dryRun)
+			require.NoError(t, err)
+
+			assert.Equal(t, 4, report.Scanned)
+			assert.Equal(t, 2, report.Matched)
+			assert.Equal(t, map[string]int{"handler_error": 2}, report.ByReason)
+			assert.Equal(t, tt.wantFailed, report.Failed)
+			assert.Equal(t, tt.wantReplayed, replayer.replayed)
+			var statuses []EntryStatus
+			for _, e := range report.Entries {
+				statuses = append(statuses, e.Status)
+			}
+			assert.Equal(t, tt.wantStatuses, statuses)
+			for offset, want := range tt.wantSettled {
+				assert.Equal(t, want, store.letters[offset].Status)
+			}
+		})
+	}
+}
+
+func TestReplay_UndecodableEntry(t *testing.T) {
+	source := &fakeSource{
+		letters: []*core.DeadLetter{
+			{Offset: 0},
+			{Offset: 1, Reason: "handler_error", OriginalTopic: "orders.created"},
+		},
+		bad: map[int64]error{0: errors.New("bad json")},
+	}
+	replayer := &fakeReplayer{failAt: -1}
+
+	report, err := replay(context.Background(), source, replayer, &fakeStore{}, core.DeadLetterRange{}, core.DeadLetterFilter{}, false)
+	require.NoError(t, err)
+	assert.Equal(t, 2, report.Scanned)
+	assert.Equal(t, 1, report.Failed)
+	assert.Equal(t, []int64{1}, replayer.replayed)
+	require.Len(t, report.Entries, 2)
+	assert.Equal(t, StatusFailed, report.Entries[0].Status)
+	assert.Equal(t, "bad json", report.Entries[0].Error)
+}
@@ -4,16 +4,25 @@ import (
 	"errors"
 	"fmt"
 	"path/filepath"
+	"time"
 
 	"github.com/Anacardo89/order_svc_hex/order_svc/config"
 	"github.com/Anacardo89/order_svc_hex/order_svc/internal/adapters/in/messaging/kafka/orderconsumer"
+	"github.com/Anacardo89/order_svc_hex/order_svc/internal/adapters/in/rpc/grpc/dlqadmin"
+	"github.com/Anacardo89/order_svc_hex/order_svc/internal/adapters/out/messaging/kafka/commandresult"
 	"github.com/Anacardo89/order_svc_hex/order_svc/internal/adapters/out/messaging/kafka/orderdlq"
+	"github.com/Anacardo89/order_svc_hex/order_svc/internal/adapters/out/messaging/kafka/orderevents"
+	"github.com/Anacardo89/order_svc_hex/order_svc/internal/adapters/out/messaging/kafka/orderreplay"
+	"github.com/Anacardo89/order_svc_hex/order_svc/internal/adapters/out/messaging/kafka/orderretry"
 	"github.com/Anacardo89/order_svc_hex/order_svc/internal/adapters/out/store/pgx/orderrepo"
 	"github.com/Anacardo89/order_svc_hex/order_svc/internal/ports"
 	"github.com/Anacardo89/order_svc_hex/order_svc/pkg/db"
 	"github.com/Anacardo89/order_svc_hex/order_svc/pkg/events"
 )
 
+// Tier consumers block until a message is due, librdkafka's default max.poll.interval.ms
+const maxRetryDelay = 5 * time.Minute
+
 func initDB(cfg config.Config) (*orderrepo.OrderRepo, error) {
 	dbConn, err := db.Connect(cfg.DB.DSN, cfg.DB.MaxConns, cfg.DB.MinConns, cfg.DB.MaxConnLifetime, cfg.DB.MaxConnIdleTime)
 	if err != nil {
@@ -27,12 +36,39 @@ func initDB(cfg config.Config) (*orderrepo.OrderRepo, error) {
 	return orderrepo.NewRepo(dbConn), nil
 }
 
-func initMessaging(cfg config.Kafka, repo ports.OrderRepo, metrics *orderconsumer.ConsumerMetrics) (*orderconsumer.OrderConsumerClient, func(), error) {
+// Nil keeps consumers to unframed values
+func initSchemaRegistry(cfg config.Config) (*events.Deserializer, error) {
+	switch cfg.SchemaRegistry.Kind {
+	case "", "none":
+		return nil, nil
+	case "http":
+		return events.NewDeserializer(events.NewHTTPRegistry(cfg.SchemaRegistry.URL, cfg.SchemaRegistry.Timeout)), nil
+	case "local":
+		path := filepath.Join(cfg.AppHome, cfg.SchemaRegistry.Path)
+		return events.NewDeserializer(events.NewLocalRegistry(path)), nil
+	default:
+		return nil, fmt.Errorf("unknown schema registry: %s", cfg.SchemaRegistry.Kind)
+	}
+}
+
+// Returns the command consumer followed by one consumer per retry tier
+func initMessaging(cfg config.Kafka, retryCfg config.Retry, consumerCfg config.Consumer, repo ports.OrderRepo, deadLetters ports.DeadLetterStore, deserializer *events.Deserializer, metrics *orderconsumer.ConsumerMetrics) ([]*orderconsumer.OrderConsumerClient, func(), error) {
 	conn := events.NewKafkaConnection(cfg.Brokers)
 	allTopics := []string{}
 	for _, v := range cfg.Topics {
 		allTopics = append(allTopics, v)
 	}
+	tiers := make([]orderretry.Tier, 0, len(retryCfg.Tiers))
+	for _, t := range retryCfg.Tiers {
+		if t.Topic == "" {
+			return nil, nil, errors.New("retry tier without topic")
+		}
+		if t.Delay >= maxRetryDelay {
+			return nil, nil, fmt.Errorf("retry tier %s: delay must stay below %s", t.Topic, maxRetryDelay)
+		}
+		tiers = append(tiers, orderretry.Tier{Topic: t.Topic, Delay: t.Delay})
+		allTopics = append(allTopics, t.Topic)
+	}
 	if err := events.EnsureTopics(cfg.Brokers, allTopics, 1); err != nil {
 		return nil, nil, fmt.Errorf("failed to ensure topics: %s", err)
 	}
@@ -44,27 +80,94 @@ func initMessaging(cfg config.Kafka, repo ports.OrderRepo, metrics *orderconsume
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to create DLQ Client: %s", err)
 	}
-	closeDlq := func() { dlqClient.Close() }
+	resultTopic, ok := cfg.Topics["OrderCommandResult"]
+	if !ok {
+		dlqClient.Close()
+		return nil, nil, errors.New("no topic for OrderCommandResult defined")
+	}
+	resultClient, err := commandresult.NewResultClient(conn, resultTopic)
+	if err != nil {
+		dlqClient.Close()
+		return nil, nil, fmt.Errorf("failed to create Result Client: %s", err)
+	}
+	retryClient, err := orderretry.NewRetryClient(conn, tiers)
+	if err != nil {
+		dlqClient.Close()
+		resultClient.Close()
+		return nil, nil, fmt.Errorf("failed to create Retry Client: %s", err)
+	}
+	closeProducers := func() {
+		dlqClient.Close()
+		resultClient.Close()
+		retryClient.Close()
+	}
+	indexedDlq := orderdlq.NewIndexedDlq(dlqClient, deadLetters)
 	consumerTopics := []string{}
 	createdTopic, ok := cfg.Topics["OrderCreated"]
 	if !ok {
-		closeDlq()
+		closeProducers()
 		return nil, nil, errors.New("no topic for OrderCreated defined")
 	} else {
 		consumerTopics = append(consumerTopics, createdTopic)
 	}
 	updatedTopic, ok := cfg.Topics["OrderStatusUpdated"]
 	if !ok {
-		closeDlq()
+		closeProducers()
 		return nil, nil, errors.New("no topic for OrderStatusUpdated defined")
 	} else {
 		consumerTopics = append(consumerTopics, updatedTopic)
 	}
 	orderHandler := orderconsumer.NewOrderHandler(repo)
-	orderConsumerClient, err := orderconsumer.NewOrderConsumerClient(conn, cfg.GroupID, consumerTopics, orderHandler, dlqClient, metrics)
+	poolCfg := orderconsumer.PoolConfig{
+		Workers:      consumerCfg.Workers,
+		QueueSize:    consumerCfg.QueueSize,
+		BatchSize:    consumerCfg.BatchSize,
+		BatchWait:    consumerCfg.BatchWait,
+		DrainTimeout: consumerCfg.DrainTimeout,
+	}
+	consumers := []*orderconsumer.OrderConsumerClient{}
+	closeAll := func() {
+		for _, c := range consumers {
+			c.Close()
+		}
+		closeProducers()
+	}
+	orderConsumerClient, err := orderconsumer.NewOrderConsumerClient(conn, cfg.GroupID, consumerTopics, orderHandler, indexedDlq, retryClient, resultClient, deserializer, poolCfg, metrics)
 	if err != nil {
-		closeDlq()
+		closeAll()
 		return nil, nil, fmt.Errorf("failed to create Order Client: %s", err)
 	}
-	return orderConsumerClient, closeDlq, nil
+	consumers = append(consumers, orderConsumerClient)
+	// One consumer per tier, so waiting out a long delay never holds back a shorter one
+	for _, t := range tiers {
+		retryConsumer, err := orderconsumer.NewOrderConsumerClient(conn, cfg.GroupID+"-"+t.Topic, []string{t.Topic}, orderHandler, indexedDlq, retryClient, resultClient, deserializer, poolCfg, metrics)
+		if err != nil {
+			closeAll()
+			return nil, nil, fmt.Errorf("failed to create Retry Consumer for %s: %s", t.Topic, err)
+		}
+		consumers = append(consumers, retryConsumer)
+	}
+	return consumers, closeProducers, nil
+}
+
+func initOrderEvents(cfg config.Kafka) (*orderevents.EventsClient, error) {
+	eventsTopic, ok := cfg.Topics["OrderEvents"]
+	if !ok {
+		return nil, errors.New("no topic for OrderEvents defined")
+	}
+	conn := events.NewKafkaConnection(cfg.Brokers)
+	eventsClient, err := orderevents.NewEventsClient(conn, eventsTopic)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Events Client: %s", err)
+	}
+	return eventsClient, nil
+}
+
+func initDeadLetterAdmin(cfg config.Kafka, store ports.DeadLetterStore) (ports.DeadLetterAdmin, func(), error) {
+	conn := events.NewKafkaConnection(cfg.Brokers)
+	replayClient, err := orderreplay.NewReplayClient(conn)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create Replay Client: %s", err)
+	}
+	return dlqadmin.NewDeadLetterAdminService(store, replayClient), replayClient.Close, nil
 }
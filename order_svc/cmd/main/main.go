@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"errors"
 	"log/slog"
 	"net/http"
 	"os"
@@ -10,12 +11,18 @@ import (
 
 	"github.com/Anacardo89/order_svc_hex/order_svc/config"
 	"github.com/Anacardo89/order_svc_hex/order_svc/internal/adapters/in/messaging/kafka/orderconsumer"
+	"github.com/Anacardo89/order_svc_hex/order_svc/internal/adapters/in/rpc/grpc/dlqadmin"
 	"github.com/Anacardo89/order_svc_hex/order_svc/internal/adapters/in/rpc/grpc/orderserver"
 	"github.com/Anacardo89/order_svc_hex/order_svc/internal/adapters/infra/log/loki/logger"
+	"github.com/Anacardo89/order_svc_hex/order_svc/internal/adapters/out/messaging/kafka/orderevents"
+	"github.com/Anacardo89/order_svc_hex/order_svc/internal/adapters/out/store/pgx/orderfeed"
 	"github.com/Anacardo89/order_svc_hex/order_svc/internal/ports"
 	"github.com/Anacardo89/order_svc_hex/order_svc/pkg/observability"
+	pb "github.com/Anacardo89/order_svc_hex/order_svc/proto/orderpb"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"go.opentelemetry.io/otel"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 )
 
 func main() {
@@ -73,23 +80,57 @@ func main() {
 		os.Exit(1)
 	}
 	defer dbRepo.Close()
-	orderConsumer, closeDlq, err := initMessaging(cfg.Kafka, dbRepo, consumerMetrics)
+	deserializer, err := initSchemaRegistry(*cfg)
+	if err != nil {
+		logger.BaseLogger.Error(ctx, "failed to init schema registry", ports.Field{Key: "error", Value: err})
+		os.Exit(1)
+	}
+	orderConsumers, closeProducers, err := initMessaging(cfg.Kafka, cfg.Retry, cfg.Consumer, dbRepo, dbRepo, deserializer, consumerMetrics)
 	if err != nil {
 		logger.BaseLogger.Error(ctx, "failed to init messaging", ports.Field{Key: "error", Value: err})
 		os.Exit(1)
 	}
-	defer orderConsumer.Close()
-	defer closeDlq()
-	grpcService := orderserver.NewOrderGRPCService(dbRepo)
-	grpcServer, err := orderserver.NewOrderGRPCServer(cfg.Server.Port, grpcService, grpcMetrics)
+	for _, c := range orderConsumers {
+		defer c.Close()
+	}
+	defer closeProducers()
+	dedupPruner := orderconsumer.NewDedupPruner(dbRepo, cfg.Dedup.Retention, cfg.Dedup.PruneInterval)
+	// WatchOrders depends on the feed listener, the order service reports not serving without it
+	healthServer := health.NewServer()
+	setFeedHealth := func(listening bool) {
+		status := healthpb.HealthCheckResponse_NOT_SERVING
+		if listening {
+			status = healthpb.HealthCheckResponse_SERVING
+		}
+		healthServer.SetServingStatus(pb.OrderService_ServiceDesc.ServiceName, status)
+	}
+	setFeedHealth(false)
+	orderFeed := orderfeed.NewFeed(cfg.DB.DSN, dbRepo, setFeedHealth)
+	eventsClient, err := initOrderEvents(cfg.Kafka)
+	if err != nil {
+		logger.BaseLogger.Error(ctx, "failed to init order events", ports.Field{Key: "error", Value: err})
+		os.Exit(1)
+	}
+	defer eventsClient.Close()
+	outboxRelay := orderevents.NewOutboxRelay(dbRepo, eventsClient, cfg.Outbox.PollInterval, cfg.Outbox.BatchSize, cfg.Outbox.Retention, cfg.Outbox.CleanupInterval)
+	dlqAdmin, closeReplay, err := initDeadLetterAdmin(cfg.Kafka, dbRepo)
+	if err != nil {
+		logger.BaseLogger.Error(ctx, "failed to init dead letter admin", ports.Field{Key: "error", Value: err})
+		os.Exit(1)
+	}
+	defer closeReplay()
+	grpcService := orderserver.NewOrderGRPCService(dbRepo, orderFeed)
+	grpcServer, err := orderserver.NewOrderGRPCServer(cfg.Server.Port, grpcService, grpcMetrics, cfg.Server.MaxBatchSize)
 	if err != nil {
 		logger.BaseLogger.Error(ctx, "failed to create gRPC server", ports.Field{Key: "error", Value: err})
 		os.Exit(1)
 	}
+	dlqadmin.RegisterDeadLetterAdminServer(grpcServer.Server, dlqAdmin)
+	healthpb.RegisterHealthServer(grpcServer.Server, healthServer)
 
 	stopChan := make(chan os.Signal, 1)
 	errSrvChan := make(chan error, 1)
-	errEventChan := make(chan error, 1)
+	errEventChan := make(chan error, len(orderConsumers))
 	signal.Notify(stopChan, syscall.SIGINT, syscall.SIGTERM)
 
 	// Execution
@@ -97,10 +138,21 @@ func main() {
 		logger.BaseLogger.Info(ctx, "gRPC server listening on", ports.Field{Key: "address", Value: grpcServer.Listener.Addr()})
 		errSrvChan <- grpcServer.Server.Serve(grpcServer.Listener)
 	}()
+	for _, c := range orderConsumers {
+		go func() {
+			logger.BaseLogger.Info(ctx, "consumer starting")
+			errEventChan <- c.Consume(ctx)
+		}()
+	}
 	go func() {
-		logger.BaseLogger.Info(ctx, "consumer starting")
-		errEventChan <- orderConsumer.Consume(ctx)
+		err := orderFeed.Run(ctx)
+		if err != nil && !errors.Is(err, context.Canceled) {
+			logger.BaseLogger.Error(ctx, "order feed stopped", ports.Field{Key: "error", Value: err})
+		}
+		setFeedHealth(false)
 	}()
+	go outboxRelay.Run(ctx)
+	go dedupPruner.Run(ctx)
 
 	// Metrics
 	go func() {
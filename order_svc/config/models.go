@@ -4,28 +4,39 @@ import "time"
 
 func New() *Config {
 	return &Config{
-		Server: Server{},
-		DB:     DB{},
-		Kafka:  Kafka{},
-		Log:    Log{},
-		Trace:  Trace{},
-		Metric: Metric{},
+		Server:         Server{},
+		DB:             DB{},
+		Kafka:          Kafka{},
+		Log:            Log{},
+		Trace:          Trace{},
+		Metric:         Metric{},
+		Outbox:         Outbox{},
+		Dedup:          Dedup{},
+		Retry:          Retry{},
+		Consumer:       Consumer{},
+		SchemaRegistry: SchemaRegistry{},
 	}
 }
 
 type Config struct {
-	AppHome string `env:"APP_HOME" envDefault:""`
-	Server  Server
-	DB      DB    `yaml:"db"`
-	Kafka   Kafka `yaml:"kafka"`
-	Log     Log
-	Trace   Trace
-	Metric  Metric `yaml:"metric"`
+	AppHome        string `env:"APP_HOME" envDefault:""`
+	Server         Server `yaml:"server"`
+	DB             DB     `yaml:"db"`
+	Kafka          Kafka  `yaml:"kafka"`
+	Log            Log
+	Trace          Trace
+	Metric         Metric         `yaml:"metric"`
+	Outbox         Outbox         `yaml:"outbox"`
+	Dedup          Dedup          `yaml:"dedup"`
+	Retry          Retry          `yaml:"retry"`
+	Consumer       Consumer       `yaml:"consumer"`
+	SchemaRegistry SchemaRegistry `yaml:"schema_registry"`
 }
 
 type Server struct {
-	Host string `env:"HOST" envDefault:"localhost"`
-	Port string `env:"PORT" envDefault:"50051"`
+	Host         string `env:"HOST" envDefault:"localhost"`
+	Port         string `env:"PORT" envDefault:"50051"`
+	MaxBatchSize int    `yaml:"max_batch_size"` // ids per GetOrdersByIDs call
 }
 
 type DB struct {
@@ -54,3 +65,41 @@ type Metric struct {
 	Endpoint     string        `env:"PROMETHEUS_ENDPOINT" envDefault:"prometheus:4317"`
 	ReaderPeriod time.Duration `yaml:"reader_period"`
 }
+
+type Outbox struct {
+	PollInterval    time.Duration `yaml:"poll_interval"`
+	BatchSize       int           `yaml:"batch_size"`
+	Retention       time.Duration `yaml:"retention"` // how long published events are kept
+	CleanupInterval time.Duration `yaml:"cleanup_interval"`
+}
+
+type Dedup struct {
+	Retention     time.Duration `yaml:"retention"` // how long processed message ids are remembered
+	PruneInterval time.Duration `yaml:"prune_interval"`
+}
+
+// Messages with the same key always go to the same worker and keep their order
+type Consumer struct {
+	Workers      int           `yaml:"workers"`
+	QueueSize    int           `yaml:"queue_size"`    // per worker, a full queue stops polling
+	BatchSize    int           `yaml:"batch_size"`    // orders.created written together, below 2 writes one at a time
+	BatchWait    time.Duration `yaml:"batch_wait"`    // longest a gathered batch waits to fill
+	DrainTimeout time.Duration `yaml:"drain_timeout"` // running messages a rebalance waits for, below max.poll.interval.ms
+}
+
+// Tiers are tried in order, a message fails into the DLQ after the last one
+type Retry struct {
+	Tiers []RetryTier `yaml:"tiers"`
+}
+
+type RetryTier struct {
+	Topic string        `yaml:"topic"`
+	Delay time.Duration `yaml:"delay"`
+}
+
+type SchemaRegistry struct {
+	Kind    string        `yaml:"kind"` // none | http | local
+	URL     string        `env:"SCHEMA_REGISTRY_URL" envDefault:"http://schema-registry:8081"`
+	Path    string        `yaml:"path"` // local registry file, relative to APP_HOME
+	Timeout time.Duration `yaml:"timeout"`
+}
@@ -0,0 +1,22 @@
+-- Enum values can't be dropped, so the type is rebuilt and rows folded back
+ALTER TYPE order_status RENAME TO order_status_old;
+
+CREATE TYPE order_status AS ENUM (
+    'pending',
+    'confirmed',
+    'failed'
+);
+
+ALTER TABLE orders ALTER COLUMN status DROP DEFAULT;
+ALTER TABLE orders ALTER COLUMN status TYPE order_status USING (
+    CASE status::text
+        WHEN 'cancelled' THEN 'failed'
+        WHEN 'refunded'  THEN 'failed'
+        WHEN 'shipped'   THEN 'confirmed'
+        WHEN 'delivered' THEN 'confirmed'
+        ELSE status::text
+    END
+)::order_status;
+ALTER TABLE orders ALTER COLUMN status SET DEFAULT 'pending';
+
+DROP TYPE order_status_old;
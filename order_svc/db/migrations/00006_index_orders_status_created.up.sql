@@ -0,0 +1 @@
+CREATE INDEX IF NOT EXISTS idx_orders_status_created_id ON orders (status, created_at, id);
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_orders_items;
+DROP INDEX IF EXISTS idx_orders_status_updated_id;
+DROP INDEX IF EXISTS idx_orders_updated_id;
+DROP INDEX IF EXISTS idx_orders_created_id;
@@ -0,0 +1,4 @@
+CREATE INDEX IF NOT EXISTS idx_orders_created_id ON orders (created_at, id);
+CREATE INDEX IF NOT EXISTS idx_orders_updated_id ON orders (updated_at, id);
+CREATE INDEX IF NOT EXISTS idx_orders_status_updated_id ON orders (status, updated_at, id);
+CREATE INDEX IF NOT EXISTS idx_orders_items ON orders USING GIN (items);
@@ -0,0 +1,2 @@
+DROP TRIGGER IF EXISTS notify_orders_changed ON orders;
+DROP FUNCTION IF EXISTS notify_order_change();
@@ -0,0 +1,20 @@
+CREATE OR REPLACE FUNCTION notify_order_change()
+RETURNS TRIGGER AS $$
+BEGIN
+    -- Payload stays well under the 8000 byte NOTIFY limit, listeners load the row
+    PERFORM pg_notify(
+        'orders_changes',
+        json_build_object(
+            'op',         TG_OP,
+            'id',         NEW.id,
+            'updated_at', NEW.updated_at
+        )::text
+    );
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER notify_orders_changed
+AFTER INSERT OR UPDATE ON orders
+FOR EACH ROW
+EXECUTE FUNCTION notify_order_change();
@@ -0,0 +1,13 @@
+CREATE TABLE outbox (
+    id            BIGSERIAL    PRIMARY KEY,
+    aggregate_id  UUID         NOT NULL,
+    event_type    TEXT         NOT NULL,
+    payload       JSONB        NOT NULL,
+    -- Trace context of the write, so the published event joins the same trace
+    headers       JSONB        NOT NULL DEFAULT '{}',
+    created_at    TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+    published_at  TIMESTAMPTZ
+);
+
+CREATE INDEX idx_outbox_unpublished ON outbox (id) WHERE published_at IS NULL;
+CREATE INDEX idx_outbox_published_at ON outbox (published_at) WHERE published_at IS NOT NULL;
@@ -0,0 +1,6 @@
+CREATE TABLE processed_messages (
+    message_id    TEXT         PRIMARY KEY,
+    processed_at  TIMESTAMPTZ  NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_processed_messages_processed_at ON processed_messages (processed_at);
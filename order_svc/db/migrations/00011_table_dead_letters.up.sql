@@ -0,0 +1,29 @@
+CREATE TYPE dead_letter_status AS ENUM (
+    'open',
+    'resolved',
+    'replayed'
+);
+
+CREATE TABLE dead_letters (
+    id                  BIGSERIAL           PRIMARY KEY,
+    failed_at           TIMESTAMPTZ         NOT NULL,
+    reason              TEXT                NOT NULL,
+    error               TEXT                NOT NULL DEFAULT '',
+    original_topic      TEXT                NOT NULL,
+    original_key        BYTEA,
+    original_value      BYTEA               NOT NULL,
+    original_headers    JSONB               NOT NULL DEFAULT '{}',
+    original_partition  INT                 NOT NULL,
+    original_offset     BIGINT              NOT NULL,
+    attempts            INT                 NOT NULL DEFAULT 0,
+    trace_id            TEXT                NOT NULL DEFAULT '',
+    span_id             TEXT                NOT NULL DEFAULT '',
+    status              dead_letter_status  NOT NULL DEFAULT 'open',
+    note                TEXT                NOT NULL DEFAULT '',
+    resolved_at         TIMESTAMPTZ,
+    replayed_at         TIMESTAMPTZ
+);
+
+CREATE INDEX idx_dead_letters_status_id ON dead_letters (status, id);
+CREATE INDEX idx_dead_letters_reason ON dead_letters (reason);
+CREATE INDEX idx_dead_letters_failed_at ON dead_letters (failed_at);
@@ -0,0 +1 @@
+CREATE INDEX IF NOT EXISTS idx_dead_letters_source ON dead_letters (original_topic, original_partition, original_offset);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_orders_item_skus;
+CREATE INDEX IF NOT EXISTS idx_orders_items ON orders USING GIN (items);
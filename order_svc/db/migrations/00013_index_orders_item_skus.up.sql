@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_orders_items;
+CREATE INDEX IF NOT EXISTS idx_orders_item_skus ON orders USING GIN ((jsonb_path_query_array(items, '$.keyvalue().key')) jsonb_path_ops);
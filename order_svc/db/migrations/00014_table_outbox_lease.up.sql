@@ -0,0 +1,6 @@
+-- Held by the instance relaying the outbox, it runs out if that instance dies
+CREATE TABLE outbox_lease (
+    name        TEXT         PRIMARY KEY,
+    holder      TEXT         NOT NULL,
+    expires_at  TIMESTAMPTZ  NOT NULL
+);
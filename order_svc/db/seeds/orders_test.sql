@@ -1,4 +1,7 @@
 TRUNCATE TABLE orders;
+TRUNCATE TABLE outbox;
+TRUNCATE TABLE processed_messages;
+TRUNCATE TABLE dead_letters;
 
 INSERT INTO orders (id, items, status, created_at, updated_at) VALUES
 (
@@ -21,4 +24,32 @@ INSERT INTO orders (id, items, status, created_at, updated_at) VALUES
     'failed',
     NOW() - INTERVAL '1 minute',
     NOW() - INTERVAL '1 minute'
+),
+(
+    '44444444-4444-4444-4444-444444444444',
+    '{"sku_6": 1}'::jsonb,
+    'cancelled',
+    NOW() - INTERVAL '50 minutes',
+    NOW() - INTERVAL '50 minutes'
+),
+(
+    '55555555-5555-5555-5555-555555555555',
+    '{"sku_7": 2}'::jsonb,
+    'shipped',
+    NOW() - INTERVAL '40 minutes',
+    NOW() - INTERVAL '40 minutes'
+),
+(
+    '66666666-6666-6666-6666-666666666666',
+    '{"sku_8": 4}'::jsonb,
+    'delivered',
+    NOW() - INTERVAL '30 minutes',
+    NOW() - INTERVAL '30 minutes'
+),
+(
+    '77777777-7777-7777-7777-777777777777',
+    '{"sku_9": 1, "sku_1": 1}'::jsonb,
+    'refunded',
+    NOW() - INTERVAL '20 minutes',
+    NOW() - INTERVAL '20 minutes'
 );
@@ -0,0 +1,27 @@
+package dlqreader
+
+import (
+	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+
+	"github.com/Anacardo89/order_svc_hex/order_svc/pkg/events"
+)
+
+type DlqReader struct {
+	consumer *kafka.Consumer
+	topic    string
+}
+
+func NewDlqReader(kc *events.KafkaConnection, groupID, topic string) (*DlqReader, error) {
+	c, err := kc.MakeReader(groupID)
+	if err != nil {
+		return nil, err
+	}
+	return &DlqReader{
+		consumer: c,
+		topic:    topic,
+	}, nil
+}
+
+func (r *DlqReader) Close() {
+	r.consumer.Close()
+}
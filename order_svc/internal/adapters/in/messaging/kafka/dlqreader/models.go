@@ -0,0 +1,49 @@
+package dlqreader
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+
+	"github.com/Anacardo89/order_svc_hex/order_svc/internal/core"
+)
+
+type DlqPayload struct {
+	Timestamp       time.Time         `json:"timestamp"`
+	Reason          string            `json:"reason"`
+	Error           string            `json:"error"`
+	OriginalTopic   string            `json:"original_topic"`
+	OriginalKey     []byte            `json:"original_key,omitempty"`
+	OriginalValue   []byte            `json:"original_value"`
+	OriginalHeaders map[string]string `json:"original_headers,omitempty"`
+	Partition       int32             `json:"partition,omitempty"`
+	Offset          int64             `json:"offset,omitempty"`
+	Attempts        int               `json:"attempts,omitempty"`
+	TraceID         string            `json:"trace_id"`
+	SpanID          string            `json:"span_id"`
+}
+
+func mapDlqPayloadToLetter(msg *kafka.Message) (*core.DeadLetter, error) {
+	var p DlqPayload
+	if err := json.Unmarshal(msg.Value, &p); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal DLQ entry at offset %d: %w", msg.TopicPartition.Offset, err)
+	}
+	return &core.DeadLetter{
+		Partition:         msg.TopicPartition.Partition,
+		Offset:            int64(msg.TopicPartition.Offset),
+		FailedAt:          p.Timestamp,
+		Reason:            p.Reason,
+		Error:             p.Error,
+		OriginalTopic:     p.OriginalTopic,
+		OriginalKey:       p.OriginalKey,
+		OriginalValue:     p.OriginalValue,
+		OriginalHeaders:   p.OriginalHeaders,
+		OriginalPartition: p.Partition,
+		OriginalOffset:    p.Offset,
+		Attempts:          p.Attempts,
+		TraceID:           p.TraceID,
+		SpanID:            p.SpanID,
+	}, nil
+}
@@ -0,0 +1,147 @@
+package dlqreader
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+
+	"github.com/Anacardo89/order_svc_hex/order_svc/internal/adapters/infra/log/loki/logger"
+	"github.com/Anacardo89/order_svc_hex/order_svc/internal/core"
+	"github.com/Anacardo89/order_svc_hex/order_svc/internal/ports"
+)
+
+const (
+	metadataTimeoutMs = 5000
+	pollTimeout       = 500 * time.Millisecond
+	// Only reached when the last offsets of a partition hold no message
+	idleTimeout = 10 * time.Second
+)
+
+type partitionBounds struct {
+	start int64
+	end   int64 // exclusive
+	done  bool
+}
+
+// Reads up to the end of each partition as it was when the read started,
+// entries written meanwhile are left for the next run
+func (r *DlqReader) Read(ctx context.Context, rng core.DeadLetterRange, fn func(*core.DeadLetter, error) error) error {
+	log := logger.BaseLogger
+	bounds, err := r.partitionBounds(rng)
+	if err != nil {
+		return err
+	}
+	if len(bounds) == 0 {
+		return nil
+	}
+	assignment := make([]kafka.TopicPartition, 0, len(bounds))
+	for p, b := range bounds {
+		assignment = append(assignment, kafka.TopicPartition{
+			Topic:     &r.topic,
+			Partition: p,
+			Offset:    kafka.Offset(b.start),
+		})
+	}
+	if err := r.consumer.Assign(assignment); err != nil {
+		return fmt.Errorf("failed to assign partitions: %w", err)
+	}
+	defer r.consumer.Unassign()
+	remaining := len(bounds)
+	lastRead := time.Now()
+	for remaining > 0 {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		msg, err := r.consumer.ReadMessage(pollTimeout)
+		if err != nil {
+			var kerr kafka.Error
+			if errors.As(err, &kerr) && kerr.IsTimeout() {
+				if time.Since(lastRead) > idleTimeout {
+					log.Warn(ctx, "DLQ read idle, stopping", ports.Field{Key: "unfinished_partitions", Value: remaining})
+					return nil
+				}
+				continue
+			}
+			return err
+		}
+		lastRead = time.Now()
+		b, ok := bounds[msg.TopicPartition.Partition]
+		if !ok || b.done {
+			continue
+		}
+		offset := int64(msg.TopicPartition.Offset)
+		if offset >= b.end || (rng.Until != nil && msg.Timestamp.After(*rng.Until)) {
+			b.done = true
+			remaining--
+			continue
+		}
+		if offset == b.end-1 {
+			b.done = true
+			remaining--
+		}
+		// A bad entry is handed over with its error, the ones after it are still read
+		letter, err := mapDlqPayloadToLetter(msg)
+		if err != nil {
+			log.Warn(ctx, "undecodable DLQ entry", ports.Field{Key: "error", Value: err})
+			letter = &core.DeadLetter{
+				Partition: msg.TopicPartition.Partition,
+				Offset:    int64(msg.TopicPartition.Offset),
+			}
+		}
+		if err := fn(letter, err); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *DlqReader) partitionBounds(rng core.DeadLetterRange) (map[int32]*partitionBounds, error) {
+	md, err := r.consumer.GetMetadata(&r.topic, false, metadataTimeoutMs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get metadata: %w", err)
+	}
+	tm, ok := md.Topics[r.topic]
+	if !ok || tm.Error.Code() != kafka.ErrNoError {
+		return nil, fmt.Errorf("topic %s not available: %v", r.topic, tm.Error)
+	}
+	bounds := make(map[int32]*partitionBounds, len(tm.Partitions))
+	for _, p := range tm.Partitions {
+		low, high, err := r.consumer.QueryWatermarkOffsets(r.topic, p.ID, metadataTimeoutMs)
+		if err != nil {
+			return nil, fmt.Errorf("failed to query offsets of partition %d: %w", p.ID, err)
+		}
+		start, end := low, high
+		if rng.FromOffset != nil && *rng.FromOffset > start {
+			start = *rng.FromOffset
+		}
+		if rng.ToOffset != nil && *rng.ToOffset+1 < end {
+			end = *rng.ToOffset + 1
+		}
+		if rng.Since != nil {
+			// The offset field carries the timestamp to look up
+			found, err := r.consumer.OffsetsForTimes([]kafka.TopicPartition{{
+				Topic:     &r.topic,
+				Partition: p.ID,
+				Offset:    kafka.Offset(rng.Since.UnixMilli()),
+			}}, metadataTimeoutMs)
+			if err != nil {
+				return nil, fmt.Errorf("failed to look up offsets by time: %w", err)
+			}
+			if len(found) == 0 || found[0].Offset < 0 {
+				continue
+			}
+			start = max(start, int64(found[0].Offset))
+		}
+		if start >= end {
+			continue
+		}
+		bounds[p.ID] = &partitionBounds{
+			start: start,
+			end:   end,
+		}
+	}
+	return bounds, nil
+}
@@ -0,0 +1,101 @@
+package orderconsumer
+
+import (
+	"context"
+	"time"
+
+	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/Anacardo89/order_svc_hex/order_svc/internal/adapters/infra/log/loki/logger"
+	"github.com/Anacardo89/order_svc_hex/order_svc/internal/ports"
+	"github.com/Anacardo89/order_svc_hex/order_svc/pkg/events"
+)
+
+// Only first deliveries, a retried message already failed once and goes on its own
+func isBatchable(msg *kafka.Message) bool {
+	return *msg.TopicPartition.Topic == "orders.created"
+}
+
+// Writes the orders in one transaction. When that fails every message is handled
+// on its own, so a bad record only fails itself
+func (c *OrderConsumerClient) handleBatch(msgs []*kafka.Message) {
+	// Observability
+	start := time.Now()
+	metricAttrs := metric.WithAttributes(
+		attribute.String("messaging.source", "orders.created"),
+	)
+	links := make([]trace.Link, 0, len(msgs))
+	for _, msg := range msgs {
+		links = append(links, trace.LinkFromContext(extractContextFromKafka(msg)))
+	}
+	tracer := otel.Tracer("order_svc.kafka")
+	ctx, span := tracer.Start(context.Background(), "kafka.consume.batch",
+		trace.WithSpanKind(trace.SpanKindConsumer),
+		trace.WithLinks(links...),
+		trace.WithAttributes(
+			attribute.String("messaging.system", "kafka"),
+			attribute.String("messaging.operation", "consume"),
+			attribute.String("messaging.source", "orders.created"),
+			attribute.Int("messaging.batch.message_count", len(msgs)),
+		),
+	)
+	log := logger.BaseLogger
+	defer span.End()
+
+	// Execution
+	decoded := make([]*kafka.Message, 0, len(msgs))
+	orders := make([]ports.OrderMessage, 0, len(msgs))
+	for _, msg := range msgs {
+		order, err := mapEventPaylodToOrder(ctx, msg, c.deserializer)
+		if err != nil {
+			// Fails the same way on its own, without holding back the rest
+			c.handleMessage(msg)
+			continue
+		}
+		decoded = append(decoded, msg)
+		orders = append(orders, ports.OrderMessage{
+			MsgID: events.HeaderValue(msg.Headers, events.HeaderMessageID),
+			Order: order,
+		})
+	}
+	if len(orders) == 0 {
+		return
+	}
+	duplicates, err := c.handler.OnOrdersCreated(ctx, orders)
+	if err != nil {
+		log.Error(ctx, "batch write failed, handling messages one by one", ports.Field{Key: "error", Value: err})
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "batch_failed")
+		c.orderConsumer.metrics.batchFallbacks.Add(ctx, 1, metricAttrs)
+		for _, msg := range decoded {
+			c.handleMessage(msg)
+		}
+		return
+	}
+	skipped := make(map[int]struct{}, len(duplicates))
+	for _, i := range duplicates {
+		skipped[i] = struct{}{}
+	}
+	for i, msg := range decoded {
+		msgCtx := extractContextFromKafka(msg)
+		if _, ok := skipped[i]; ok {
+			// Already applied, answer again in case the first reply was lost
+			log.Info(msgCtx, "skipping duplicate message", ports.Field{Key: "message_id", Value: orders[i].MsgID})
+			c.orderConsumer.metrics.duplicates.Add(msgCtx, 1, metricAttrs)
+		} else {
+			c.orderConsumer.metrics.duration.Record(msgCtx, time.Since(start).Seconds(), metricAttrs)
+			c.orderConsumer.metrics.consumed.Add(msgCtx, 1, metricAttrs)
+		}
+		if result, ok := makeCommandResult(msg, orders[i].Order, "", nil); ok {
+			if err := c.resultClient.PublishResult(msgCtx, result); err != nil {
+				log.Error(msgCtx, "failed to publish command result", ports.Field{Key: "error", Value: err})
+			}
+		}
+	}
+	c.orderConsumer.metrics.batchSize.Record(ctx, int64(len(orders)), metricAttrs)
+}
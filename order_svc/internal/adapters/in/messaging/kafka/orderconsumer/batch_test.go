@@ -0,0 +1,166 @@
+package orderconsumer
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+
+	"github.com/Anacardo89/order_svc_hex/order_svc/internal/adapters/infra/log/loki/logger"
+	"github.com/Anacardo89/order_svc_hex/order_svc/internal/core"
+	"github.com/Anacardo89/order_svc_hex/order_svc/internal/ports"
+	"github.com/Anacardo89/order_svc_hex/order_svc/pkg/events"
+)
+
+type fakeHandler struct {
+	ports.OrderConsumer
+	batchErr   error
+	duplicates []int
+	batches    [][]ports.OrderMessage
+	single     []string
+}
+
+func (f *fakeHandler) OnOrdersCreated(ctx context.Context, msgs []ports.OrderMessage) ([]int, error) {
+	f.batches = append(f.batches, msgs)
+	return f.duplicates, f.batchErr
+}
+
+func (f *fakeHandler) OnOrderCreated(ctx context.Context, msgID string, order core.Order) error {
+	f.single = append(f.single, msgID)
+	return nil
+}
+
+type fakeDLQ struct {
+	msgs []ports.DLQMessage
+}
+
+func (f *fakeDLQ) PublishDLQ(ctx context.Context, msg ports.DLQMessage) error {
+	f.msgs = append(f.msgs, msg)
+	return nil
+}
+
+type fakeResults struct {
+	results []core.CommandResult
+}
+
+func (f *fakeResults) PublishResult(ctx context.Context, result core.CommandResult) error {
+	f.results = append(f.results, result)
+	return nil
+}
+
+func newBatchClient(t *testing.T, handler *fakeHandler) (*OrderConsumerClient, *fakeDLQ, *fakeResults, *sdkmetric.ManualReader) {
+	logger.BaseLogger = logger.NopLogger{}
+	reader := sdkmetric.NewManualReader()
+	metrics, err := NewConsumerMetrics(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)).Meter("test"))
+	require.NoError(t, err)
+	dlq := &fakeDLQ{}
+	results := &fakeResults{}
+	return &OrderConsumerClient{
+		orderConsumer: &Consumer{metrics: metrics},
+		handler:       handler,
+		dlqClient:     dlq,
+		resultClient:  results,
+	}, dlq, results, reader
+}
+
+func counterTotal(t *testing.T, reader *sdkmetric.ManualReader, name string) int64 {
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+	var total int64
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if sum, ok := m.Data.(metricdata.Sum[int64]); ok && m.Name == name {
+				for _, dp := range sum.DataPoints {
+					total += dp.Value
+				}
+			}
+		}
+	}
+	return total
+}
+
+func createdMsg(t *testing.T, msgID string, cmdID uuid.UUID, value []byte) *kafka.Message {
+	if value == nil {
+		value = envelopeValue(t, events.EventTypeOrderCreated, OrderCreatedVersion, time.Now(), OrderCreatedEvent{
+			ID:     uuid.NewString(),
+			Items:  map[string]int{"sku_1": 1},
+			Status: "pending",
+		})
+	}
+	return commandMsg("orders.created", value,
+		kafka.Header{Key: events.HeaderMessageID, Value: []byte(msgID)},
+		kafka.Header{Key: events.HeaderCorrelationID, Value: []byte(cmdID.String())},
+	)
+}
+
+func TestHandleBatch(t *testing.T) {
+	t.Run("undecodable message is handled on its own", func(t *testing.T) {
+		handler := &fakeHandler{}
+		client, dlq, results, _ := newBatchClient(t, handler)
+		bad := uuid.New()
+
+		client.handleBatch([]*kafka.Message{
+			createdMsg(t, "msg-1", uuid.New(), nil),
+			createdMsg(t, "msg-bad", bad, []byte("not an order")),
+			createdMsg(t, "msg-2", uuid.New(), nil),
+		})
+
+		require.Len(t, handler.batches, 1)
+		assert.Equal(t, []string{"msg-1", "msg-2"}, []string{handler.batches[0][0].MsgID, handler.batches[0][1].MsgID})
+		assert.Empty(t, handler.single)
+		require.Len(t, dlq.msgs, 1)
+		assert.Equal(t, "unmarshal_failed", dlq.msgs[0].Reason)
+		require.Len(t, results.results, 3)
+		assert.Equal(t, bad, results.results[0].CommandID)
+		assert.Equal(t, core.CommandRejected, results.results[0].Outcome)
+	})
+
+	t.Run("failed batch falls back to one by one", func(t *testing.T) {
+		handler := &fakeHandler{batchErr: errors.New("tx aborted")}
+		client, dlq, results, _ := newBatchClient(t, handler)
+
+		client.handleBatch([]*kafka.Message{
+			createdMsg(t, "msg-1", uuid.New(), nil),
+			createdMsg(t, "msg-2", uuid.New(), nil),
+		})
+
+		assert.Len(t, handler.batches, 1)
+		assert.Equal(t, []string{"msg-1", "msg-2"}, handler.single)
+		assert.Empty(t, dlq.msgs)
+		require.Len(t, results.results, 2)
+		for _, r := range results.results {
+			assert.Equal(t, core.CommandApplied, r.Outcome)
+		}
+	})
+
+	t.Run("every message is answered, duplicates included", func(t *testing.T) {
+		// msg-1 is delivered twice, the repo applies the first copy and skips the second
+		handler := &fakeHandler{duplicates: []int{2}}
+		client, dlq, results, reader := newBatchClient(t, handler)
+		cmds := []uuid.UUID{uuid.New(), uuid.New(), uuid.New()}
+
+		client.handleBatch([]*kafka.Message{
+			createdMsg(t, "msg-1", cmds[0], nil),
+			createdMsg(t, "msg-2", cmds[1], nil),
+			createdMsg(t, "msg-1", cmds[2], nil),
+		})
+
+		assert.Empty(t, handler.single)
+		assert.Empty(t, dlq.msgs)
+		require.Len(t, results.results, 3)
+		for i, r := range results.results {
+			assert.Equal(t, cmds[i], r.CommandID)
+			assert.Equal(t, core.CommandApplied, r.Outcome)
+			assert.Equal(t, handler.batches[0][i].Order.ID, r.OrderID)
+		}
+		assert.Equal(t, int64(1), counterTotal(t, reader, "order.event.duplicate.total"))
+		assert.Equal(t, int64(2), counterTotal(t, reader, "order.event.consumed.total"))
+	})
+}
@@ -9,6 +9,11 @@ type OrderConsumerClient struct {
 	orderConsumer *Consumer
 	handler       ports.OrderConsumer
 	dlqClient     ports.OrderDLQ
+	retryClient   ports.OrderRetry
+	resultClient  ports.CommandResultPublisher
+	// Nil when no schema registry is configured
+	deserializer *events.Deserializer
+	pool         *workerPool
 }
 
 func NewOrderConsumerClient(
@@ -17,17 +22,26 @@ func NewOrderConsumerClient(
 	topics []string,
 	handler ports.OrderConsumer,
 	dlqClient ports.OrderDLQ,
+	retryClient ports.OrderRetry,
+	resultClient ports.CommandResultPublisher,
+	deserializer *events.Deserializer,
+	poolCfg PoolConfig,
 	metrics *ConsumerMetrics,
 ) (*OrderConsumerClient, error) {
-	c, err := NewConsumer(kc, groupId, topics, metrics)
+	client := &OrderConsumerClient{
+		handler:      handler,
+		dlqClient:    dlqClient,
+		retryClient:  retryClient,
+		resultClient: resultClient,
+		deserializer: deserializer,
+	}
+	client.pool = newWorkerPool(poolCfg, metrics, client.handleMessage, client.handleBatch)
+	c, err := NewConsumer(kc, groupId, topics, client.pool.onRevoke, metrics)
 	if err != nil {
 		return nil, err
 	}
-	return &OrderConsumerClient{
-		orderConsumer: c,
-		handler:       handler,
-		dlqClient:     dlqClient,
-	}, nil
+	client.orderConsumer = c
+	return client, nil
 }
 
 func (c *OrderConsumerClient) Close() {
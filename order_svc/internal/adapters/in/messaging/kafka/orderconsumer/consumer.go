@@ -11,8 +11,8 @@ type Consumer struct {
 	topics   []string
 }
 
-func NewConsumer(kc *events.KafkaConnection, groupID string, topics []string, metrics *ConsumerMetrics) (*Consumer, error) {
-	c, err := kc.MakeConsumer(groupID, topics)
+func NewConsumer(kc *events.KafkaConnection, groupID string, topics []string, onRevoke events.RevokeHook, metrics *ConsumerMetrics) (*Consumer, error) {
+	c, err := kc.MakeConsumer(groupID, topics, onRevoke)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,56 @@
+package orderconsumer
+
+import (
+	"context"
+	"time"
+
+	"github.com/Anacardo89/order_svc_hex/order_svc/internal/adapters/infra/log/loki/logger"
+	"github.com/Anacardo89/order_svc_hex/order_svc/internal/ports"
+)
+
+const (
+	defaultDedupRetention = 7 * 24 * time.Hour
+	defaultPruneInterval  = time.Hour
+)
+
+// Retention must outlast any redelivery, a message replayed after it is applied again
+type DedupPruner struct {
+	processed ports.ProcessedMessages
+	retention time.Duration
+	interval  time.Duration
+}
+
+func NewDedupPruner(processed ports.ProcessedMessages, retention, interval time.Duration) *DedupPruner {
+	if retention <= 0 {
+		retention = defaultDedupRetention
+	}
+	if interval <= 0 {
+		interval = defaultPruneInterval
+	}
+	return &DedupPruner{
+		processed: processed,
+		retention: retention,
+		interval:  interval,
+	}
+}
+
+func (p *DedupPruner) Run(ctx context.Context) {
+	log := logger.BaseLogger
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			n, err := p.processed.PruneProcessed(ctx, time.Now().Add(-p.retention))
+			if err != nil {
+				log.Error(ctx, "failed to prune processed messages", ports.Field{Key: "error", Value: err})
+				continue
+			}
+			if n > 0 {
+				log.Info(ctx, "pruned processed messages", ports.Field{Key: "deleted", Value: n})
+			}
+		}
+	}
+}
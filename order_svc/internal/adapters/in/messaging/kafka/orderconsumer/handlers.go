@@ -17,10 +17,14 @@ func NewOrderHandler(repo ports.OrderRepo) ports.OrderConsumer {
 	}
 }
 
-func (h *OrderHandler) OnOrderCreated(ctx context.Context, order core.Order) error {
-	return h.repo.Create(ctx, &order)
+func (h *OrderHandler) OnOrderCreated(ctx context.Context, msgID string, order core.Order) error {
+	return h.repo.CreateFromMessage(ctx, msgID, &order)
 }
 
-func (h *OrderHandler) OnOrderStatusUpdated(ctx context.Context, order core.Order) error {
-	return h.repo.UpdateStatus(ctx, order.ID, *order.Status)
+func (h *OrderHandler) OnOrdersCreated(ctx context.Context, msgs []ports.OrderMessage) ([]int, error) {
+	return h.repo.CreateBatchFromMessages(ctx, msgs)
+}
+
+func (h *OrderHandler) OnOrderStatusUpdated(ctx context.Context, msgID string, order core.Order) error {
+	return h.repo.UpdateStatusFromMessage(ctx, msgID, order.ID, *order.Status)
 }
@@ -7,11 +7,17 @@ import (
 )
 
 type ConsumerMetrics struct {
-	consumed    metric.Int64Counter
-	duration    metric.Float64Histogram
-	failed      metric.Int64Counter
-	dlqProduced metric.Int64Counter
-	dataLoss    metric.Int64Counter
+	consumed       metric.Int64Counter
+	duration       metric.Float64Histogram
+	failed         metric.Int64Counter
+	dlqProduced    metric.Int64Counter
+	dataLoss       metric.Int64Counter
+	duplicates     metric.Int64Counter
+	retried        metric.Int64Counter
+	inFlight       metric.Int64UpDownCounter
+	queueDepth     metric.Int64UpDownCounter
+	batchSize      metric.Int64Histogram
+	batchFallbacks metric.Int64Counter
 }
 
 func NewConsumerMetrics(meter metric.Meter) (*ConsumerMetrics, error) {
@@ -50,11 +56,59 @@ func NewConsumerMetrics(meter metric.Meter) (*ConsumerMetrics, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to create DLQ fail counter: %w", err)
 	}
+	dup, err := meter.Int64Counter("order.event.duplicate.total",
+		metric.WithDescription("Total number of redelivered events skipped"),
+		metric.WithUnit("{event}"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create duplicate events counter: %w", err)
+	}
+	retried, err := meter.Int64Counter("order.event.retry.total",
+		metric.WithDescription("Total number of events sent to a retry tier"),
+		metric.WithUnit("{event}"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create retry counter: %w", err)
+	}
+	inFlight, err := meter.Int64UpDownCounter("order.event.inflight",
+		metric.WithDescription("Number of events being processed by a worker"),
+		metric.WithUnit("{event}"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create in-flight events counter: %w", err)
+	}
+	queueDepth, err := meter.Int64UpDownCounter("order.event.queue.depth",
+		metric.WithDescription("Number of events waiting in worker queues"),
+		metric.WithUnit("{event}"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create queue depth counter: %w", err)
+	}
+	batchSize, err := meter.Int64Histogram("order.event.batch.size",
+		metric.WithDescription("Number of events written together in one batch"),
+		metric.WithUnit("{event}"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create batch size histogram: %w", err)
+	}
+	batchFallbacks, err := meter.Int64Counter("order.event.batch.fallback",
+		metric.WithDescription("Total number of batches handled one event at a time after failing"),
+		metric.WithUnit("{batch}"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create batch fallback counter: %w", err)
+	}
 	return &ConsumerMetrics{
-		consumed:    cons,
-		duration:    dur,
-		failed:      fail,
-		dlqProduced: dlqCounter,
-		dataLoss:    dlqFail,
+		consumed:       cons,
+		duration:       dur,
+		failed:         fail,
+		dlqProduced:    dlqCounter,
+		dataLoss:       dlqFail,
+		duplicates:     dup,
+		retried:        retried,
+		inFlight:       inFlight,
+		queueDepth:     queueDepth,
+		batchSize:      batchSize,
+		batchFallbacks: batchFallbacks,
 	}, nil
 }
@@ -1,11 +1,15 @@
 package orderconsumer
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/Anacardo89/order_svc_hex/order_svc/internal/core"
 	"github.com/Anacardo89/order_svc_hex/order_svc/internal/ports"
+	"github.com/Anacardo89/order_svc_hex/order_svc/pkg/events"
 	"github.com/Anacardo89/order_svc_hex/order_svc/pkg/ptr"
 	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
 	"github.com/google/uuid"
@@ -18,7 +22,31 @@ const (
 	TopicOrderStatusUpdated TopicKey = "OrderStatusUpdated"
 )
 
+// Schema versions this consumer decodes, older ones are upcast first
+const (
+	OrderCreatedVersion       = 1
+	OrderStatusUpdatedVersion = 1
+)
+
+var topicEventTypes = map[string]string{
+	"orders.created":        events.EventTypeOrderCreated,
+	"orders.status_updated": events.EventTypeOrderStatusUpdated,
+}
+
+// Version 1 wrapped the unchanged bare payloads of version 0 in the envelope
+func newUpcasters() *events.UpcasterRegistry {
+	r := events.NewUpcasterRegistry()
+	r.SetCurrent(events.EventTypeOrderCreated, OrderCreatedVersion)
+	r.SetCurrent(events.EventTypeOrderStatusUpdated, OrderStatusUpdatedVersion)
+	r.Register(events.EventTypeOrderCreated, 0, events.SameData)
+	r.Register(events.EventTypeOrderStatusUpdated, 0, events.SameData)
+	return r
+}
+
+var upcasters = newUpcasters()
+
 type OrderCreatedEvent struct {
+	ID     string         `json:"id"`
 	Items  map[string]int `json:"items"`
 	Status string         `json:"status"`
 }
@@ -28,25 +56,67 @@ type OrderStatusUpdatedEvent struct {
 	Status string `json:"status"`
 }
 
-func mapEventPaylodToOrder(msg *kafka.Message) (*core.Order, error) {
-	switch *msg.TopicPartition.Topic {
-	case "orders.created":
+// Framed values name their encoding through the schema, plain ones through the header
+func mapEventPaylodToOrder(ctx context.Context, msg *kafka.Message, deserializer *events.Deserializer) (*core.Order, error) {
+	topic := sourceTopic(msg)
+	eventType, ok := topicEventTypes[topic]
+	if !ok {
+		return nil, fmt.Errorf("unknown topic: %s", topic)
+	}
+	value := msg.Value
+	contentType := events.HeaderValue(msg.Headers, events.HeaderContentType)
+	if events.IsFramed(value) {
+		if deserializer == nil {
+			return nil, errors.New("framed message but no schema registry configured")
+		}
+		schema, payload, err := deserializer.Deserialize(ctx, value)
+		if err != nil {
+			return nil, err
+		}
+		if contentType, err = events.ContentTypeFor(schema); err != nil {
+			return nil, err
+		}
+		value = payload
+	}
+	env, err := events.DecodeCommand(contentType, value, eventType)
+	if err != nil {
+		return nil, err
+	}
+	if env.EventType != eventType {
+		return nil, fmt.Errorf("unexpected event type %s on %s", env.EventType, topic)
+	}
+	if err := upcasters.Upcast(env); err != nil {
+		return nil, err
+	}
+	switch eventType {
+	case events.EventTypeOrderCreated:
 		var e OrderCreatedEvent
-		if err := json.Unmarshal(msg.Value, &e); err != nil {
+		if err := json.Unmarshal(env.Data, &e); err != nil {
 			return nil, fmt.Errorf("failed to unmarshal OrderCreated: %w", err)
 		}
+		var id uuid.UUID
+		if e.ID != "" {
+			parsed, err := uuid.Parse(e.ID)
+			if err != nil {
+				return nil, fmt.Errorf("invalid UUID in OrderCreated: %w", err)
+			}
+			id = parsed
+		}
 		var status *core.Status
 		if e.Status != "" {
 			s := core.Status(e.Status)
 			status = &s
 		}
+		// Zero for version 0, the store falls back to its own clock
 		return &core.Order{
-			Items:  e.Items,
-			Status: status,
+			ID:        id,
+			Items:     e.Items,
+			Status:    status,
+			CreatedAt: env.OccurredAt,
 		}, nil
-	case "orders.status_updated":
+	default:
 		var e OrderStatusUpdatedEvent
-		if err := json.Unmarshal(msg.Value, &e); err != nil {
+		if err := json.Unmarshal(env.Data, &e); err != nil {
 			return nil, fmt.Errorf("failed to unmarshal OrderStatusUpdated: %w", err)
 		}
 		id, err := uuid.Parse(e.ID)
@@ -57,21 +127,45 @@ func mapEventPaylodToOrder(msg *kafka.Message) (*core.Order, error) {
 			ID:     id,
 			Status: ptr.Ptr(core.Status(e.Status)),
 		}, nil
-	default:
-		return nil, fmt.Errorf("unknown topic: %s", *msg.TopicPartition.Topic)
 	}
 }
 
-func makeDlqMessage(msg *kafka.Message, traceID, spanID string, reason string, err error) ports.DLQMessage {
+func makeDlqMessage(msg *kafka.Message, traceID, spanID string, reason string, attempts int, err error) ports.DLQMessage {
+	partition, offset := sourcePosition(msg)
 	return ports.DLQMessage{
-		Reason:        reason,
-		Error:         err,
-		OriginalTopic: *msg.TopicPartition.Topic,
-		OriginalKey:   msg.Key,
-		OriginalValue: msg.Value,
-		Partition:     msg.TopicPartition.Partition,
-		Offset:        int64(msg.TopicPartition.Offset),
-		TraceID:       traceID,
-		SpanID:        spanID,
+		Reason:          reason,
+		Error:           err,
+		OriginalTopic:   sourceTopic(msg),
+		OriginalKey:     msg.Key,
+		OriginalValue:   msg.Value,
+		OriginalHeaders: originalHeaders(msg),
+		Partition:       partition,
+		Offset:          offset,
+		Attempts:        attempts,
+		TraceID:         traceID,
+		SpanID:          spanID,
+	}
+}
+
+// Only commands carrying a correlation ID get a reply
+func makeCommandResult(msg *kafka.Message, order *core.Order, reason string, err error) (core.CommandResult, bool) {
+	cmdID, parseErr := uuid.Parse(events.HeaderValue(msg.Headers, events.HeaderCorrelationID))
+	if parseErr != nil {
+		return core.CommandResult{}, false
+	}
+	result := core.CommandResult{
+		CommandID:   cmdID,
+		ReplyTo:     events.HeaderValue(msg.Headers, events.HeaderReplyTo),
+		Outcome:     core.CommandApplied,
+		ProcessedAt: time.Now().UTC(),
+	}
+	if order != nil {
+		result.OrderID = order.ID
+	}
+	if err != nil {
+		result.Outcome = core.CommandRejected
+		result.Reason = reason
+		result.Error = err.Error()
 	}
+	return result, true
 }
@@ -0,0 +1,165 @@
+package orderconsumer
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/Anacardo89/order_svc_hex/order_svc/internal/core"
+	"github.com/Anacardo89/order_svc_hex/order_svc/pkg/events"
+	"github.com/Anacardo89/order_svc_hex/order_svc/proto/orderpb"
+)
+
+func commandMsg(topic string, value []byte, headers ...kafka.Header) *kafka.Message {
+	return &kafka.Message{
+		TopicPartition: kafka.TopicPartition{Topic: &topic},
+		Value:          value,
+		Headers:        headers,
+	}
+}
+
+func protoValue(t *testing.T, occurredAt time.Time, data proto.Message) []byte {
+	packed, err := anypb.New(data)
+	require.NoError(t, err)
+	raw, err := proto.Marshal(&orderpb.CommandEnvelope{
+		EventId:       "cmd-1",
+		EventType:     events.EventTypeOrderCreated,
+		SchemaVersion: OrderCreatedVersion,
+		OccurredAt:    timestamppb.New(occurredAt),
+		Producer:      "order_api",
+		Data:          packed,
+	})
+	require.NoError(t, err)
+	return raw
+}
+
+func envelopeValue(t *testing.T, eventType string, version int, occurredAt time.Time, data any) []byte {
+	env, err := events.NewEnvelope("cmd-1", eventType, version, "order_api", occurredAt, data)
+	require.NoError(t, err)
+	raw, err := json.Marshal(env)
+	require.NoError(t, err)
+	return raw
+}
+
+func TestMapEventPayloadToOrder(t *testing.T) {
+	occurredAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	created := OrderCreatedEvent{
+		ID:     "11111111-1111-1111-1111-111111111111",
+		Items:  map[string]int{"sku_1": 2},
+		Status: "pending",
+	}
+	bare, err := json.Marshal(created)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name          string
+		msg           *kafka.Message
+		wantErr       bool
+		wantCreatedAt time.Time
+	}{
+		{
+			name:          "current envelope",
+			msg:           commandMsg("orders.created", envelopeValue(t, events.EventTypeOrderCreated, OrderCreatedVersion, occurredAt, created)),
+			wantCreatedAt: occurredAt,
+		},
+		{
+			name: "protobuf envelope",
+			msg: commandMsg("orders.created",
+				protoValue(t, occurredAt, &orderpb.OrderCreatedCommand{Id: created.ID, Items: map[string]int32{"sku_1": 2}, Status: created.Status}),
+				kafka.Header{Key: events.HeaderContentType, Value: []byte(events.ContentTypeProtobuf)},
+			),
+			wantCreatedAt: occurredAt,
+		},
+		{
+			name:    "unsupported content type",
+			msg:     commandMsg("orders.created", bare, kafka.Header{Key: events.HeaderContentType, Value: []byte("text/plain")}),
+			wantErr: true,
+		},
+		{
+			name: "bare payload from before the envelope",
+			msg:  commandMsg("orders.created", bare),
+		},
+		{
+			name:    "newer version than supported",
+			msg:     commandMsg("orders.created", envelopeValue(t, events.EventTypeOrderCreated, OrderCreatedVersion+1, occurredAt, created)),
+			wantErr: true,
+		},
+		{
+			name:    "event type does not match topic",
+			msg:     commandMsg("orders.created", envelopeValue(t, events.EventTypeOrderStatusUpdated, 1, occurredAt, created)),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			order, err := mapEventPaylodToOrder(context.Background(), tt.msg, nil)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, created.ID, order.ID.String())
+			assert.Equal(t, created.Items, order.Items)
+			assert.Equal(t, core.StatusPending, *order.Status)
+			assert.True(t, tt.wantCreatedAt.Equal(order.CreatedAt))
+		})
+	}
+}
+
+func TestMapEventPayloadToOrder_Framed(t *testing.T) {
+	ctx := context.Background()
+	jsonID, protoID := 1, 2
+	// Laid out the way order_api's local registry writes it
+	path := filepath.Join(t.TempDir(), "schemas.json")
+	raw, err := json.Marshal(map[string]any{
+		"subjects": map[string][]events.Schema{
+			"orders.created-value": {
+				{ID: jsonID, Subject: "orders.created-value", Version: 1, Type: events.SchemaTypeJSON, Schema: `{"type":"object"}`},
+				{ID: protoID, Subject: "orders.created-value", Version: 2, Type: events.SchemaTypeProtobuf, Schema: `syntax = "proto3";`},
+			},
+		},
+	})
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path, raw, 0o644))
+	registry := events.NewLocalRegistry(path)
+	frame := func(id int, indexes []byte, payload []byte) []byte {
+		out := binary.BigEndian.AppendUint32([]byte{0}, uint32(id))
+		return append(append(out, indexes...), payload...)
+	}
+	occurredAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	created := OrderCreatedEvent{ID: "11111111-1111-1111-1111-111111111111", Items: map[string]int{"sku_1": 2}}
+	deserializer := events.NewDeserializer(registry)
+
+	// The schema decides the encoding, a stale header doesn't matter
+	order, err := mapEventPaylodToOrder(ctx, commandMsg("orders.created",
+		frame(jsonID, nil, envelopeValue(t, events.EventTypeOrderCreated, OrderCreatedVersion, occurredAt, created)),
+		kafka.Header{Key: events.HeaderContentType, Value: []byte(events.ContentTypeProtobuf)},
+	), deserializer)
+	require.NoError(t, err)
+	assert.Equal(t, created.Items, order.Items)
+
+	order, err = mapEventPaylodToOrder(ctx, commandMsg("orders.created",
+		frame(protoID, []byte{0}, protoValue(t, occurredAt, &orderpb.OrderCreatedCommand{Id: created.ID, Items: map[string]int32{"sku_1": 2}})),
+	), deserializer)
+	require.NoError(t, err)
+	assert.Equal(t, created.Items, order.Items)
+	assert.True(t, occurredAt.Equal(order.CreatedAt))
+
+	_, err = mapEventPaylodToOrder(ctx, commandMsg("orders.created", frame(99, nil, []byte(`{}`))), deserializer)
+	assert.ErrorIs(t, err, events.ErrSchemaNotFound)
+
+	_, err = mapEventPaylodToOrder(ctx, commandMsg("orders.created", frame(jsonID, nil, []byte(`{}`))), nil)
+	assert.Error(t, err)
+}
@@ -0,0 +1,210 @@
+package orderconsumer
+
+import (
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+)
+
+type partitionKey struct {
+	topic     string
+	partition int32
+}
+
+type partitionOffsets struct {
+	// Dispatched and not yet committable, in offset order
+	pending []kafka.Offset
+	done    map[kafka.Offset]struct{}
+	// Next offset to commit, unset until something finishes
+	next kafka.Offset
+	// Set on revoke, queued messages are skipped and positions no longer stored
+	revoked bool
+	// First skipped offset, the position never moves past it. Unset while nothing was skipped
+	skippedAt kafka.Offset
+	// Revoke gave up waiting, the entry goes away once its last message finishes
+	abandoned bool
+}
+
+// Pops the finished head, reports whether the position moved
+func (p *partitionOffsets) advance() bool {
+	advanced := false
+	for len(p.pending) > 0 {
+		head := p.pending[0]
+		if _, ok := p.done[head]; !ok {
+			break
+		}
+		delete(p.done, head)
+		p.pending = p.pending[1:]
+		if p.skippedAt == kafka.OffsetInvalid || head < p.skippedAt {
+			p.next = head + 1
+			advanced = true
+		}
+	}
+	return advanced
+}
+
+// Workers finish out of order, a partition only commits up to its first unfinished offset
+type offsetTracker struct {
+	mu         sync.Mutex
+	drained    *sync.Cond
+	partitions map[partitionKey]*partitionOffsets
+}
+
+func newOffsetTracker() *offsetTracker {
+	t := &offsetTracker{
+		partitions: make(map[partitionKey]*partitionOffsets),
+	}
+	t.drained = sync.NewCond(&t.mu)
+	return t
+}
+
+func keyOf(tp kafka.TopicPartition) partitionKey {
+	return partitionKey{topic: *tp.Topic, partition: tp.Partition}
+}
+
+func (k partitionKey) at(offset kafka.Offset) kafka.TopicPartition {
+	topic := k.topic
+	return kafka.TopicPartition{Topic: &topic, Partition: k.partition, Offset: offset}
+}
+
+func (t *offsetTracker) add(tp kafka.TopicPartition) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	key := keyOf(tp)
+	p, ok := t.partitions[key]
+	// A revoked entry still there belongs to the previous assignment
+	if !ok || p.revoked {
+		p = &partitionOffsets{
+			done:      make(map[kafka.Offset]struct{}),
+			next:      kafka.OffsetInvalid,
+			skippedAt: kafka.OffsetInvalid,
+		}
+		t.partitions[key] = p
+	}
+	p.pending = append(p.pending, tp.Offset)
+}
+
+// Undoes the last add of a message that never reached a worker
+func (t *offsetTracker) remove(tp kafka.TopicPartition) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	p, ok := t.partitions[keyOf(tp)]
+	if !ok || len(p.pending) == 0 || p.pending[len(p.pending)-1] != tp.Offset {
+		return
+	}
+	p.pending = p.pending[:len(p.pending)-1]
+	t.drained.Broadcast()
+}
+
+// Calls advance with the position to commit when the partition's head moved.
+// It runs under the lock, so positions reach it in order even with several workers
+func (t *offsetTracker) done(tp kafka.TopicPartition, advance func(position kafka.TopicPartition)) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	key := keyOf(tp)
+	p, ok := t.partitions[key]
+	// Not pending means it was dispatched before the partition was assigned again
+	if !ok || !slices.Contains(p.pending, tp.Offset) {
+		return
+	}
+	p.done[tp.Offset] = struct{}{}
+	advanced := p.advance()
+	t.settled(key, p)
+	if advanced && !p.revoked {
+		advance(key.at(p.next))
+	}
+}
+
+// Reports whether a message may still be handled. A queued message of a revoked
+// partition is dropped instead, it is read again by whoever gets the partition
+func (t *offsetTracker) start(tp kafka.TopicPartition) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	key := keyOf(tp)
+	p, ok := t.partitions[key]
+	if !ok || !p.revoked {
+		return true
+	}
+	i := slices.Index(p.pending, tp.Offset)
+	if i < 0 {
+		return false
+	}
+	p.pending = slices.Delete(p.pending, i, i+1)
+	if p.skippedAt == kafka.OffsetInvalid || tp.Offset < p.skippedAt {
+		p.skippedAt = tp.Offset
+	}
+	p.advance()
+	t.settled(key, p)
+	return false
+}
+
+// Wakes drain once a partition has nothing left, must hold the lock
+func (t *offsetTracker) settled(key partitionKey, p *partitionOffsets) {
+	if len(p.pending) > 0 {
+		return
+	}
+	if p.abandoned && t.partitions[key] == p {
+		delete(t.partitions, key)
+	}
+	t.drained.Broadcast()
+}
+
+// Blocks until nothing dispatched from the partitions is still running or the
+// timeout runs out, then forgets them and returns where each should commit.
+// Messages still running past the timeout are read again by the next owner
+func (t *offsetTracker) drain(partitions []kafka.TopicPartition, timeout time.Duration) []kafka.TopicPartition {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for _, tp := range partitions {
+		if p, ok := t.partitions[keyOf(tp)]; ok {
+			p.revoked = true
+		}
+	}
+	expired := false
+	timer := time.AfterFunc(timeout, func() {
+		t.mu.Lock()
+		defer t.mu.Unlock()
+		expired = true
+		t.drained.Broadcast()
+	})
+	defer timer.Stop()
+	var positions []kafka.TopicPartition
+	for _, tp := range partitions {
+		key := keyOf(tp)
+		for !expired {
+			p, ok := t.partitions[key]
+			if !ok || len(p.pending) == 0 {
+				break
+			}
+			t.drained.Wait()
+		}
+		p, ok := t.partitions[key]
+		if !ok {
+			continue
+		}
+		if p.next != kafka.OffsetInvalid {
+			positions = append(positions, key.at(p.next))
+		}
+		if len(p.pending) == 0 {
+			delete(t.partitions, key)
+		} else {
+			p.abandoned = true
+		}
+	}
+	return positions
+}
+
+// Where every tracked partition should commit, without waiting
+func (t *offsetTracker) positions() []kafka.TopicPartition {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	var positions []kafka.TopicPartition
+	for key, p := range t.partitions {
+		if p.next != kafka.OffsetInvalid && !p.revoked {
+			positions = append(positions, key.at(p.next))
+		}
+	}
+	return positions
+}
@@ -0,0 +1,231 @@
+package orderconsumer
+
+import (
+	"context"
+	"encoding/binary"
+	"hash/fnv"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+
+	"github.com/Anacardo89/order_svc_hex/order_svc/internal/adapters/infra/log/loki/logger"
+	"github.com/Anacardo89/order_svc_hex/order_svc/internal/ports"
+)
+
+const (
+	defaultWorkers   = 1
+	defaultQueueSize = 100
+	defaultBatchWait = 50 * time.Millisecond
+	// Well below max.poll.interval.ms, revoke runs inside the poll
+	defaultDrainTimeout = 10 * time.Second
+)
+
+// Batching is off with BatchSize below 2
+type PoolConfig struct {
+	Workers   int
+	QueueSize int
+	BatchSize int
+	BatchWait time.Duration
+	// Longest a revoke waits for running messages of the lost partitions
+	DrainTimeout time.Duration
+}
+
+// Messages with the same key always go to the same worker, so they run in order.
+// A worker gathers consecutive batchable messages and hands them over together,
+// anything else flushes what it gathered first
+type workerPool struct {
+	handle      func(*kafka.Message)
+	handleBatch func([]*kafka.Message)
+	batchable   func(*kafka.Message) bool
+	batchSize   int
+	batchWait   time.Duration
+	drainWait   time.Duration
+	metrics     *ConsumerMetrics
+	queues      []chan *kafka.Message
+	offsets     *offsetTracker
+	// Set on start, offsets are stored as they become committable and committed on revoke and close
+	store  func([]kafka.TopicPartition) error
+	commit func([]kafka.TopicPartition) error
+	wg     sync.WaitGroup
+}
+
+func newWorkerPool(cfg PoolConfig, metrics *ConsumerMetrics, handle func(*kafka.Message), handleBatch func([]*kafka.Message)) *workerPool {
+	workers := cfg.Workers
+	if workers <= 0 {
+		workers = defaultWorkers
+	}
+	queueSize := cfg.QueueSize
+	if queueSize <= 0 {
+		queueSize = defaultQueueSize
+	}
+	queues := make([]chan *kafka.Message, workers)
+	for i := range queues {
+		queues[i] = make(chan *kafka.Message, queueSize)
+	}
+	batchWait := cfg.BatchWait
+	if batchWait <= 0 {
+		batchWait = defaultBatchWait
+	}
+	drainWait := cfg.DrainTimeout
+	if drainWait <= 0 {
+		drainWait = defaultDrainTimeout
+	}
+	p := &workerPool{
+		handle:    handle,
+		batchable: func(*kafka.Message) bool { return false },
+		batchWait: batchWait,
+		drainWait: drainWait,
+		metrics:   metrics,
+		queues:    queues,
+		offsets:   newOffsetTracker(),
+	}
+	if cfg.BatchSize > 1 && handleBatch != nil {
+		p.handleBatch = handleBatch
+		p.batchable = isBatchable
+		p.batchSize = cfg.BatchSize
+	}
+	return p
+}
+
+func (p *workerPool) start(consumer *kafka.Consumer) {
+	p.store = func(offsets []kafka.TopicPartition) error {
+		_, err := consumer.StoreOffsets(offsets)
+		return err
+	}
+	p.commit = func(offsets []kafka.TopicPartition) error {
+		_, err := consumer.CommitOffsets(offsets)
+		return err
+	}
+	p.run()
+}
+
+func (p *workerPool) run() {
+	for _, q := range p.queues {
+		p.wg.Go(func() {
+			p.work(q)
+		})
+	}
+}
+
+// Blocks while the worker's queue is full
+func (p *workerPool) dispatch(ctx context.Context, msg *kafka.Message) error {
+	metricAttrs := metric.WithAttributes(attribute.String("messaging.source", sourceTopic(msg)))
+	p.offsets.add(msg.TopicPartition)
+	p.metrics.queueDepth.Add(ctx, 1, metricAttrs)
+	select {
+	case p.queues[p.worker(msg)] <- msg:
+		return nil
+	case <-ctx.Done():
+		p.offsets.remove(msg.TopicPartition)
+		p.metrics.queueDepth.Add(ctx, -1, metricAttrs)
+		return ctx.Err()
+	}
+}
+
+// Keyless messages spread by partition, which still keeps them in partition order
+func (p *workerPool) worker(msg *kafka.Message) int {
+	h := fnv.New32a()
+	if len(msg.Key) > 0 {
+		h.Write(msg.Key)
+	} else {
+		h.Write(binary.BigEndian.AppendUint32(nil, uint32(msg.TopicPartition.Partition)))
+	}
+	return int(h.Sum32() % uint32(len(p.queues)))
+}
+
+func (p *workerPool) work(queue <-chan *kafka.Message) {
+	ctx := context.Background()
+	var (
+		batch []*kafka.Message
+		timer = time.NewTimer(p.batchWait)
+		// Nil while nothing is gathered
+		deadline <-chan time.Time
+	)
+	timer.Stop()
+	flush := func() {
+		timer.Stop()
+		deadline = nil
+		if len(batch) > 0 {
+			p.process(ctx, batch)
+			batch = nil
+		}
+	}
+	for {
+		select {
+		case msg, ok := <-queue:
+			if !ok {
+				flush()
+				return
+			}
+			p.metrics.queueDepth.Add(ctx, -1, metric.WithAttributes(attribute.String("messaging.source", sourceTopic(msg))))
+			if !p.batchable(msg) {
+				flush()
+				p.process(ctx, []*kafka.Message{msg})
+				continue
+			}
+			batch = append(batch, msg)
+			if len(batch) == 1 {
+				timer.Reset(p.batchWait)
+				deadline = timer.C
+			}
+			if len(batch) >= p.batchSize {
+				flush()
+			}
+		case <-deadline:
+			flush()
+		}
+	}
+}
+
+// Offsets are only marked done once the messages are handled, for a batch that is after it is written
+func (p *workerPool) process(ctx context.Context, msgs []*kafka.Message) {
+	msgs = slices.DeleteFunc(msgs, func(msg *kafka.Message) bool {
+		return !p.offsets.start(msg.TopicPartition)
+	})
+	if len(msgs) == 0 {
+		return
+	}
+	metricAttrs := metric.WithAttributes(attribute.String("messaging.source", sourceTopic(msgs[0])))
+	p.metrics.inFlight.Add(ctx, int64(len(msgs)), metricAttrs)
+	if len(msgs) == 1 {
+		p.handle(msgs[0])
+	} else {
+		p.handleBatch(msgs)
+	}
+	p.metrics.inFlight.Add(ctx, -int64(len(msgs)), metricAttrs)
+	for _, msg := range msgs {
+		p.offsets.done(msg.TopicPartition, func(position kafka.TopicPartition) {
+			if err := p.store([]kafka.TopicPartition{position}); err != nil {
+				logger.BaseLogger.Error(ctx, "failed to store offset", ports.Field{Key: "error", Value: err}, ports.Field{Key: "partition", Value: position})
+			}
+		})
+	}
+}
+
+// Nothing is dispatched while this runs, it is called from the polling goroutine.
+// Queued messages of the partitions are dropped, only running ones are waited for
+func (p *workerPool) onRevoke(_ *kafka.Consumer, partitions []kafka.TopicPartition) {
+	p.commitPositions(p.offsets.drain(partitions, p.drainWait))
+}
+
+// Lets the workers finish what is queued, then commits where they got to
+func (p *workerPool) close() {
+	for _, q := range p.queues {
+		close(q)
+	}
+	p.wg.Wait()
+	p.commitPositions(p.offsets.positions())
+}
+
+func (p *workerPool) commitPositions(positions []kafka.TopicPartition) {
+	if len(positions) == 0 || p.commit == nil {
+		return
+	}
+	if err := p.commit(positions); err != nil {
+		logger.BaseLogger.Error(context.Background(), "failed to commit offsets", ports.Field{Key: "error", Value: err}, ports.Field{Key: "partitions", Value: positions})
+	}
+}
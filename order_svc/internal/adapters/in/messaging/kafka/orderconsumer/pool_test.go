@@ -0,0 +1,229 @@
+package orderconsumer
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/metric/noop"
+)
+
+func partitionMsg(partition int32, offset kafka.Offset, key string) *kafka.Message {
+	topic := "orders.created"
+	return &kafka.Message{
+		TopicPartition: kafka.TopicPartition{Topic: &topic, Partition: partition, Offset: offset},
+		Key:            []byte(key),
+	}
+}
+
+func TestOffsetTracker(t *testing.T) {
+	tracker := newOffsetTracker()
+	for _, offset := range []kafka.Offset{10, 11, 12} {
+		tracker.add(partitionMsg(0, offset, "").TopicPartition)
+	}
+
+	var positions []kafka.Offset
+	advance := func(position kafka.TopicPartition) {
+		positions = append(positions, position.Offset)
+	}
+
+	// 11 finishing first must not commit past 10
+	tracker.done(partitionMsg(0, 11, "").TopicPartition, advance)
+	assert.Empty(t, positions)
+	assert.Empty(t, tracker.positions())
+
+	tracker.done(partitionMsg(0, 10, "").TopicPartition, advance)
+	tracker.done(partitionMsg(0, 12, "").TopicPartition, advance)
+	assert.Equal(t, []kafka.Offset{12, 13}, positions)
+
+	drained := tracker.drain([]kafka.TopicPartition{partitionMsg(0, 0, "").TopicPartition}, time.Second)
+	require.Len(t, drained, 1)
+	assert.Equal(t, kafka.Offset(13), drained[0].Offset)
+	assert.Empty(t, tracker.positions())
+}
+
+func TestWorkerPool_KeepsKeyOrder(t *testing.T) {
+	metrics, err := NewConsumerMetrics(noop.NewMeterProvider().Meter("test"))
+	require.NoError(t, err)
+
+	var (
+		mu     sync.Mutex
+		seen   = map[string][]kafka.Offset{}
+		stored []kafka.TopicPartition
+	)
+	pool := newWorkerPool(PoolConfig{Workers: 4, QueueSize: 2}, metrics, func(msg *kafka.Message) {
+		// Later messages finish sooner, so only the key routing keeps them ordered
+		time.Sleep(time.Duration(20-msg.TopicPartition.Offset) * time.Millisecond)
+		mu.Lock()
+		defer mu.Unlock()
+		seen[string(msg.Key)] = append(seen[string(msg.Key)], msg.TopicPartition.Offset)
+	}, nil)
+	pool.store = func(offsets []kafka.TopicPartition) error {
+		mu.Lock()
+		defer mu.Unlock()
+		stored = append(stored, offsets...)
+		return nil
+	}
+	var committed []kafka.TopicPartition
+	pool.commit = func(offsets []kafka.TopicPartition) error {
+		committed = offsets
+		return nil
+	}
+	pool.run()
+
+	keys := []string{"a", "b", "c"}
+	for offset := kafka.Offset(0); offset < 12; offset++ {
+		msg := partitionMsg(0, offset, keys[int(offset)%len(keys)])
+		require.NoError(t, pool.dispatch(context.Background(), msg))
+	}
+	assert.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(stored) > 0 && stored[len(stored)-1].Offset == 12
+	}, time.Second, 5*time.Millisecond)
+	pool.onRevoke(nil, []kafka.TopicPartition{partitionMsg(0, 0, "").TopicPartition})
+	require.Len(t, committed, 1)
+	assert.Equal(t, kafka.Offset(12), committed[0].Offset)
+
+	pool.close()
+	for i, key := range keys {
+		var want []kafka.Offset
+		for offset := kafka.Offset(i); offset < 12; offset += kafka.Offset(len(keys)) {
+			want = append(want, offset)
+		}
+		assert.Equal(t, want, seen[key], key)
+	}
+	// Stored positions only ever move forward
+	for i := 1; i < len(stored); i++ {
+		assert.Greater(t, stored[i].Offset, stored[i-1].Offset)
+	}
+}
+
+func TestWorkerPool_Batches(t *testing.T) {
+	metrics, err := NewConsumerMetrics(noop.NewMeterProvider().Meter("test"))
+	require.NoError(t, err)
+
+	var (
+		mu      sync.Mutex
+		handled [][]kafka.Offset
+		stored  []kafka.Offset
+	)
+	record := func(msgs ...*kafka.Message) {
+		mu.Lock()
+		defer mu.Unlock()
+		var offsets []kafka.Offset
+		for _, msg := range msgs {
+			offsets = append(offsets, msg.TopicPartition.Offset)
+		}
+		handled = append(handled, offsets)
+	}
+	pool := newWorkerPool(PoolConfig{Workers: 1, BatchSize: 3, BatchWait: 20 * time.Millisecond}, metrics,
+		func(msg *kafka.Message) { record(msg) },
+		func(msgs []*kafka.Message) { record(msgs...) },
+	)
+	pool.store = func(offsets []kafka.TopicPartition) error {
+		mu.Lock()
+		defer mu.Unlock()
+		stored = append(stored, offsets[0].Offset)
+		return nil
+	}
+	pool.run()
+
+	updated := func(offset kafka.Offset) *kafka.Message {
+		msg := partitionMsg(0, offset, "k")
+		topic := "orders.status_updated"
+		msg.TopicPartition.Topic = &topic
+		return msg
+	}
+	for _, msg := range []*kafka.Message{
+		partitionMsg(0, 0, "k"),
+		partitionMsg(0, 1, "k"),
+		partitionMsg(0, 2, "k"), // full
+		partitionMsg(0, 3, "k"),
+		updated(4), // flushes 3 first
+		partitionMsg(0, 5, "k"),
+	} {
+		require.NoError(t, pool.dispatch(context.Background(), msg))
+	}
+	// The last one goes out when the wait runs out
+	assert.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(stored) > 0 && stored[len(stored)-1] == 6
+	}, time.Second, 5*time.Millisecond)
+	pool.close()
+
+	assert.Equal(t, [][]kafka.Offset{{0, 1, 2}, {3}, {4}, {5}}, handled)
+}
+
+func TestWorkerPool_Revoke(t *testing.T) {
+	metrics, err := NewConsumerMetrics(noop.NewMeterProvider().Meter("test"))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name          string
+		drainTimeout  time.Duration
+		releaseAfter  time.Duration
+		wantCommitted []kafka.Offset
+	}{
+		{
+			name:          "waits for the running message and drops the queued ones",
+			drainTimeout:  time.Second,
+			releaseAfter:  20 * time.Millisecond,
+			wantCommitted: []kafka.Offset{1},
+		},
+		{
+			name:         "gives up on a message that runs past the timeout",
+			drainTimeout: 20 * time.Millisecond,
+			releaseAfter: 100 * time.Millisecond,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				mu      sync.Mutex
+				handled []kafka.Offset
+			)
+			started := make(chan struct{})
+			release := make(chan struct{})
+			pool := newWorkerPool(PoolConfig{Workers: 1, DrainTimeout: tt.drainTimeout}, metrics, func(msg *kafka.Message) {
+				if msg.TopicPartition.Offset == 0 {
+					close(started)
+					<-release
+				}
+				mu.Lock()
+				defer mu.Unlock()
+				handled = append(handled, msg.TopicPartition.Offset)
+			}, nil)
+			pool.store = func(offsets []kafka.TopicPartition) error {
+				return nil
+			}
+			var committed []kafka.Offset
+			pool.commit = func(offsets []kafka.TopicPartition) error {
+				for _, tp := range offsets {
+					committed = append(committed, tp.Offset)
+				}
+				return nil
+			}
+			pool.run()
+
+			for offset := kafka.Offset(0); offset < 3; offset++ {
+				require.NoError(t, pool.dispatch(context.Background(), partitionMsg(0, offset, "k")))
+			}
+			<-started
+			time.AfterFunc(tt.releaseAfter, func() { close(release) })
+			pool.onRevoke(nil, []kafka.TopicPartition{partitionMsg(0, 0, "").TopicPartition})
+			assert.Equal(t, tt.wantCommitted, committed)
+
+			pool.close()
+			// The next owner reads 1 and 2 again
+			assert.Equal(t, []kafka.Offset{0}, handled)
+			assert.Equal(t, tt.wantCommitted, committed)
+		})
+	}
+}
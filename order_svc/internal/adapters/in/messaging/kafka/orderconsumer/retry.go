@@ -0,0 +1,103 @@
+package orderconsumer
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+
+	"github.com/Anacardo89/order_svc_hex/order_svc/internal/core"
+	"github.com/Anacardo89/order_svc_hex/order_svc/internal/ports"
+	"github.com/Anacardo89/order_svc_hex/order_svc/pkg/events"
+)
+
+// Messages from a retry tier name the topic they were first published to
+func sourceTopic(msg *kafka.Message) string {
+	if topic := events.HeaderValue(msg.Headers, events.HeaderOriginalTopic); topic != "" {
+		return topic
+	}
+	return *msg.TopicPartition.Topic
+}
+
+// Partition and offset in the source topic, a retry tier's own position means nothing there
+func sourcePosition(msg *kafka.Message) (int32, int64) {
+	partition, pErr := strconv.ParseInt(events.HeaderValue(msg.Headers, events.HeaderOriginalPartition), 10, 32)
+	offset, oErr := strconv.ParseInt(events.HeaderValue(msg.Headers, events.HeaderOriginalOffset), 10, 64)
+	if pErr != nil || oErr != nil {
+		return msg.TopicPartition.Partition, int64(msg.TopicPartition.Offset)
+	}
+	return int32(partition), offset
+}
+
+// Attempts already made before this delivery
+func retryAttempt(msg *kafka.Message) int {
+	n, err := strconv.Atoi(events.HeaderValue(msg.Headers, events.HeaderRetryAttempt))
+	if err != nil {
+		return 0
+	}
+	return n
+}
+
+// Bad payloads and rejected commands fail the same way on every attempt.
+// A missing order may still be on its way through a retry tier
+func isRetryable(reason string, err error) bool {
+	switch reason {
+	case "order_not_found":
+		return true
+	case "handler_error":
+		return !errors.Is(err, core.ErrInvalidCommand)
+	default:
+		return false
+	}
+}
+
+// Tier delays are fixed, so a partition's head is always the next message due
+// and blocking on it holds back nothing that could run earlier.
+// Delays must stay below max.poll.interval.ms or the consumer is kicked from the group
+func waitNotBefore(ctx context.Context, msg *kafka.Message) error {
+	notBefore, err := time.Parse(time.RFC3339Nano, events.HeaderValue(msg.Headers, events.HeaderNotBefore))
+	if err != nil {
+		return nil
+	}
+	wait := time.Until(notBefore)
+	if wait <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// Headers as first published, without what a retry tier added
+func originalHeaders(msg *kafka.Message) map[string]string {
+	headers := make(map[string]string, len(msg.Headers))
+	for _, h := range msg.Headers {
+		switch h.Key {
+		case events.HeaderRetryAttempt, events.HeaderNotBefore, events.HeaderOriginalTopic,
+			events.HeaderOriginalPartition, events.HeaderOriginalOffset:
+			continue
+		}
+		headers[h.Key] = string(h.Value)
+	}
+	return headers
+}
+
+func makeRetryMessage(msg *kafka.Message, attempt int) ports.RetryMessage {
+	partition, offset := sourcePosition(msg)
+	return ports.RetryMessage{
+		OriginalTopic: sourceTopic(msg),
+		Key:           msg.Key,
+		Value:         msg.Value,
+		Partition:     partition,
+		Offset:        offset,
+		Headers:       originalHeaders(msg),
+		Attempt:       attempt,
+	}
+}
@@ -0,0 +1,92 @@
+package orderconsumer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Anacardo89/order_svc_hex/order_svc/internal/core"
+	"github.com/Anacardo89/order_svc_hex/order_svc/pkg/events"
+)
+
+func retryMsg(headers ...kafka.Header) *kafka.Message {
+	topic := "orders.retry.5s"
+	return &kafka.Message{
+		TopicPartition: kafka.TopicPartition{Topic: &topic},
+		Headers:        headers,
+	}
+}
+
+func TestRetryHeaders(t *testing.T) {
+	msg := retryMsg(
+		kafka.Header{Key: events.HeaderOriginalTopic, Value: []byte("orders.created")},
+		kafka.Header{Key: events.HeaderRetryAttempt, Value: []byte("2")},
+		kafka.Header{Key: events.HeaderMessageID, Value: []byte("m-1")},
+		kafka.Header{Key: events.HeaderOriginalPartition, Value: []byte("3")},
+		kafka.Header{Key: events.HeaderOriginalOffset, Value: []byte("42")},
+	)
+	msg.TopicPartition.Partition = 0
+	msg.TopicPartition.Offset = 7
+	assert.Equal(t, "orders.created", sourceTopic(msg))
+	assert.Equal(t, 2, retryAttempt(msg))
+
+	retry := makeRetryMessage(msg, 3)
+	assert.Equal(t, "orders.created", retry.OriginalTopic)
+	assert.Equal(t, 3, retry.Attempt)
+	assert.Equal(t, "m-1", retry.Headers[events.HeaderMessageID])
+	assert.NotContains(t, retry.Headers, events.HeaderOriginalOffset)
+	// The DLQ entry points at the source topic, not the tier
+	assert.Equal(t, int32(3), retry.Partition)
+	assert.Equal(t, int64(42), retry.Offset)
+	dlq := makeDlqMessage(msg, "", "", "handler_error", 3, errors.New("boom"))
+	assert.Equal(t, int32(3), dlq.Partition)
+	assert.Equal(t, int64(42), dlq.Offset)
+
+	// First delivery
+	plain := retryMsg()
+	plain.TopicPartition.Offset = 7
+	assert.Equal(t, "orders.retry.5s", sourceTopic(plain))
+	partition, offset := sourcePosition(plain)
+	assert.Equal(t, int32(0), partition)
+	assert.Equal(t, int64(7), offset)
+	assert.Equal(t, 0, retryAttempt(plain))
+}
+
+func TestIsRetryable(t *testing.T) {
+	tests := []struct {
+		name   string
+		reason string
+		err    error
+		want   bool
+	}{
+		{"transient handler error", "handler_error", errors.New("connection reset"), true},
+		{"invalid command", "handler_error", fmt.Errorf("%w: bad enum", core.ErrInvalidCommand), false},
+		{"bad payload", "unmarshal_failed", errors.New("bad json"), false},
+		{"invalid transition", "invalid_transition", core.ErrInvalidTransition, false},
+		{"order not created yet", "order_not_found", core.ErrOrderNotFound, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, isRetryable(tt.reason, tt.err))
+		})
+	}
+}
+
+func TestWaitNotBefore(t *testing.T) {
+	due := time.Now().Add(50 * time.Millisecond).UTC().Format(time.RFC3339Nano)
+	msg := retryMsg(kafka.Header{Key: events.HeaderNotBefore, Value: []byte(due)})
+
+	start := time.Now()
+	assert.NoError(t, waitNotBefore(context.Background(), msg))
+	assert.GreaterOrEqual(t, time.Since(start), 40*time.Millisecond)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	late := time.Now().Add(time.Hour).UTC().Format(time.RFC3339Nano)
+	assert.ErrorIs(t, waitNotBefore(ctx, retryMsg(kafka.Header{Key: events.HeaderNotBefore, Value: []byte(late)})), context.Canceled)
+}
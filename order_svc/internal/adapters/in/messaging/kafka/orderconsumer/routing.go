@@ -6,7 +6,9 @@ import (
 	"time"
 
 	"github.com/Anacardo89/order_svc_hex/order_svc/internal/adapters/infra/log/loki/logger"
+	"github.com/Anacardo89/order_svc_hex/order_svc/internal/core"
 	"github.com/Anacardo89/order_svc_hex/order_svc/internal/ports"
+	"github.com/Anacardo89/order_svc_hex/order_svc/pkg/events"
 	"github.com/Anacardo89/order_svc_hex/order_svc/pkg/observability"
 	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
 	"go.opentelemetry.io/otel"
@@ -17,42 +19,69 @@ import (
 	"go.opentelemetry.io/otel/trace"
 )
 
+const pollTimeout = 500 * time.Millisecond
+
+// Offsets are stored by the workers once everything before them in the partition is done
 func (c *OrderConsumerClient) Consume(ctx context.Context) error {
+	c.pool.start(c.orderConsumer.consumer)
+	defer c.pool.close()
 	for {
 		select {
 		case <-ctx.Done():
 			return ctx.Err()
 		default:
-			msg, err := c.orderConsumer.consumer.ReadMessage(-1)
+			msg, err := c.orderConsumer.consumer.ReadMessage(pollTimeout)
 			if err != nil {
+				if kErr, ok := err.(kafka.Error); ok && kErr.IsTimeout() {
+					continue
+				}
 				logger.BaseLogger.Error(ctx, "failed to read message", ports.Field{Key: "error", Value: err})
 				continue
 			}
-			c.handleMessage(msg)
+			// Abandoned here the message was never dispatched, its offset is not stored
+			// and it is read again after a restart
+			if err := waitNotBefore(ctx, msg); err != nil {
+				return err
+			}
+			if err := c.pool.dispatch(ctx, msg); err != nil {
+				return err
+			}
 		}
 	}
 }
 
 func (c *OrderConsumerClient) handleMessage(msg *kafka.Message) {
 	// Error Handling
-	fail := func(ctx context.Context, span trace.Span, msg *kafka.Message, reason string, metricAttrs metric.MeasurementOption, err error) {
+	// Returns false when the message went to a retry tier and the outcome is still open
+	fail := func(ctx context.Context, span trace.Span, msg *kafka.Message, reason string, metricAttrs metric.MeasurementOption, err error) bool {
+		log := logger.BaseLogger
 		c.orderConsumer.metrics.failed.Add(ctx, 1, metricAttrs, metric.WithAttributes(attribute.String("error.reason", reason)))
 		span.RecordError(err)
 		span.SetStatus(codes.Error, reason)
+		attempt := retryAttempt(msg) + 1
+		if c.retryClient != nil && isRetryable(reason, err) {
+			scheduled, retryErr := c.retryClient.PublishRetry(ctx, makeRetryMessage(msg, attempt))
+			if retryErr != nil {
+				log.Error(ctx, "retry publish failed, sending to DLQ", ports.Field{Key: "error", Value: retryErr})
+			} else if scheduled {
+				c.orderConsumer.metrics.retried.Add(ctx, 1, metricAttrs, metric.WithAttributes(attribute.Int("messaging.retry.attempt", attempt)))
+				return false
+			}
+		}
 		traceID, spanID := observability.GetTraceSpan(span)
-		dlqMsg := makeDlqMessage(msg, traceID, spanID, reason, err)
+		dlqMsg := makeDlqMessage(msg, traceID, spanID, reason, attempt, err)
 		if err := c.dlqClient.PublishDLQ(ctx, dlqMsg); err != nil {
-			log := logger.BaseLogger
 			log.Error(ctx, "CRITICAL - DLQ Publish failed", ports.Field{Key: "error", Value: err}, ports.Field{Key: "dlq_msg", Value: dlqMsg})
 			c.orderConsumer.metrics.dataLoss.Add(ctx, 1, metricAttrs)
 		} else {
 			c.orderConsumer.metrics.dlqProduced.Add(ctx, 1, metricAttrs)
 		}
+		return true
 	}
 
 	// Observability
 	start := time.Now()
-	topic := *msg.TopicPartition.Topic
+	topic := sourceTopic(msg)
 	metricAttrs := metric.WithAttributes(
 		attribute.String("messaging.source", topic),
 	)
@@ -63,38 +92,60 @@ func (c *OrderConsumerClient) handleMessage(msg *kafka.Message) {
 		trace.WithAttributes(
 			attribute.String("messaging.system", "kafka"),
 			attribute.String("messaging.operation", "consume"),
-			attribute.String("messaging.source", *msg.TopicPartition.Topic),
+			attribute.String("messaging.source", topic),
 		),
 	)
 	log := logger.BaseLogger
 	defer span.End()
 
 	// Execution
-	var success bool
-	order, err := mapEventPaylodToOrder(msg)
+	var (
+		success bool
+		final   = true
+		reason  string
+	)
+	msgID := events.HeaderValue(msg.Headers, events.HeaderMessageID)
+	span.SetAttributes(attribute.String("messaging.message_id", msgID))
+	order, err := mapEventPaylodToOrder(msgCtx, msg, c.deserializer)
 	if err != nil {
+		reason = "unmarshal_failed"
 		log.Error(msgCtx, "failed to unmarshal payload", ports.Field{Key: "error", Value: err})
-		fail(msgCtx, span, msg, "unmarshal_failed", metricAttrs, err)
+		final = fail(msgCtx, span, msg, reason, metricAttrs, err)
 	} else {
-		switch *msg.TopicPartition.Topic {
+		switch topic {
 		case "orders.created":
-			err = c.handler.OnOrderCreated(msgCtx, *order)
+			err = c.handler.OnOrderCreated(msgCtx, msgID, *order)
 		case "orders.status_updated":
-			err = c.handler.OnOrderStatusUpdated(msgCtx, *order)
+			err = c.handler.OnOrderStatusUpdated(msgCtx, msgID, *order)
 		default:
 			err = errors.New("unknown topic")
 		}
-		if err != nil {
+		if errors.Is(err, core.ErrDuplicateMessage) {
+			// Already applied, answer again in case the first reply was lost
+			log.Info(msgCtx, "skipping duplicate message", ports.Field{Key: "message_id", Value: msgID})
+			c.orderConsumer.metrics.duplicates.Add(msgCtx, 1, metricAttrs)
+			err = nil
+		} else if errors.Is(err, core.ErrOrderNotFound) {
+			reason = "order_not_found"
+			log.Error(msgCtx, "order not found", ports.Field{Key: "error", Value: err})
+			final = fail(msgCtx, span, msg, reason, metricAttrs, err)
+		} else if errors.Is(err, core.ErrInvalidTransition) {
+			reason = "invalid_transition"
+			log.Error(msgCtx, "invalid status transition", ports.Field{Key: "error", Value: err})
+			final = fail(msgCtx, span, msg, reason, metricAttrs, err)
+		} else if err != nil {
+			reason = "handler_error"
 			log.Error(msgCtx, "handler error", ports.Field{Key: "error", Value: err})
-			fail(msgCtx, span, msg, "handler_error", metricAttrs, err)
+			final = fail(msgCtx, span, msg, reason, metricAttrs, err)
 		} else {
 			success = true
 		}
 	}
-	if _, err := c.orderConsumer.consumer.CommitMessage(msg); err != nil {
-		log.Error(msgCtx, "failed to commit offset", ports.Field{Key: "error", Value: err})
-		fail(msgCtx, span, msg, "offset_commit_failed", metricAttrs, err)
-		return
+	// A retried command is answered once its last attempt settles it
+	if result, ok := makeCommandResult(msg, order, reason, err); ok && final {
+		if err := c.resultClient.PublishResult(msgCtx, result); err != nil {
+			log.Error(msgCtx, "failed to publish command result", ports.Field{Key: "error", Value: err})
+		}
 	}
 	if success {
 		c.orderConsumer.metrics.duration.Record(msgCtx, time.Since(start).Seconds(), metricAttrs)
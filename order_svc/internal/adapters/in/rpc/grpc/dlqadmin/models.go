@@ -0,0 +1,137 @@
+package dlqadmin
+
+import (
+	"encoding/base64"
+	"strconv"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/Anacardo89/order_svc_hex/order_svc/internal/core"
+	"github.com/Anacardo89/order_svc_hex/order_svc/pkg/ptr"
+	pb "github.com/Anacardo89/order_svc_hex/order_svc/proto/orderpb"
+)
+
+const (
+	defaultPageSize = 50
+	maxPageSize     = 500
+)
+
+func pageSize(limit int32) int {
+	switch {
+	case limit <= 0:
+		return defaultPageSize
+	case limit > maxPageSize:
+		return maxPageSize
+	default:
+		return int(limit)
+	}
+}
+
+// Tokens are opaque to clients: base64 of the last id seen
+func encodePageToken(next *int64) string {
+	if next == nil {
+		return ""
+	}
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.FormatInt(*next, 10)))
+}
+
+func decodePageToken(token string) (*int64, error) {
+	if token == "" {
+		return nil, nil
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, err
+	}
+	id, err := strconv.ParseInt(string(raw), 10, 64)
+	if err != nil {
+		return nil, err
+	}
+	return &id, nil
+}
+
+func mapStatusToProto(s core.DeadLetterStatus) pb.DeadLetterStatus {
+	switch s {
+	case core.DeadLetterResolved:
+		return pb.DeadLetterStatus_DEAD_LETTER_RESOLVED
+	case core.DeadLetterReplayed:
+		return pb.DeadLetterStatus_DEAD_LETTER_REPLAYED
+	default:
+		return pb.DeadLetterStatus_DEAD_LETTER_OPEN
+	}
+}
+
+func mapStatusToCore(s pb.DeadLetterStatus) core.DeadLetterStatus {
+	switch s {
+	case pb.DeadLetterStatus_DEAD_LETTER_RESOLVED:
+		return core.DeadLetterResolved
+	case pb.DeadLetterStatus_DEAD_LETTER_REPLAYED:
+		return core.DeadLetterReplayed
+	default:
+		return core.DeadLetterOpen
+	}
+}
+
+func toCoreFilter(f *pb.DeadLetterFilter) core.DeadLetterFilter {
+	if f == nil {
+		return core.DeadLetterFilter{}
+	}
+	filter := core.DeadLetterFilter{
+		Reasons:        f.Reasons,
+		OriginalTopics: f.OriginalTopics,
+		ErrorContains:  f.ErrorContains,
+	}
+	for _, s := range f.Statuses {
+		filter.Statuses = append(filter.Statuses, mapStatusToCore(s))
+	}
+	if f.FailedAfter != nil {
+		filter.FailedAfter = ptr.Ptr(f.FailedAfter.AsTime())
+	}
+	if f.FailedBefore != nil {
+		filter.FailedBefore = ptr.Ptr(f.FailedBefore.AsTime())
+	}
+	return filter
+}
+
+func toProtoDeadLetter(l *core.DeadLetter) *pb.DeadLetter {
+	out := &pb.DeadLetter{
+		Id:                l.ID,
+		FailedAt:          timestamppb.New(l.FailedAt),
+		Reason:            l.Reason,
+		Error:             l.Error,
+		OriginalTopic:     l.OriginalTopic,
+		OriginalKey:       l.OriginalKey,
+		OriginalValue:     l.OriginalValue,
+		OriginalHeaders:   l.OriginalHeaders,
+		OriginalPartition: l.OriginalPartition,
+		OriginalOffset:    l.OriginalOffset,
+		Attempts:          int32(l.Attempts),
+		TraceId:           l.TraceID,
+		SpanId:            l.SpanID,
+		Status:            mapStatusToProto(l.Status),
+		Note:              l.Note,
+	}
+	if l.ResolvedAt != nil {
+		out.ResolvedAt = timestamppb.New(*l.ResolvedAt)
+	}
+	if l.ReplayedAt != nil {
+		out.ReplayedAt = timestamppb.New(*l.ReplayedAt)
+	}
+	return out
+}
+
+func toProtoCounts(counts []*core.DeadLetterCount) *pb.CountDeadLettersResponse {
+	resp := &pb.CountDeadLettersResponse{
+		Counts: make([]*pb.ReasonCount, 0, len(counts)),
+	}
+	for _, c := range counts {
+		resp.Counts = append(resp.Counts, &pb.ReasonCount{
+			Reason:   c.Reason,
+			Open:     c.Open,
+			Resolved: c.Resolved,
+			Replayed: c.Replayed,
+			Total:    c.Total,
+		})
+	}
+	return resp
+}
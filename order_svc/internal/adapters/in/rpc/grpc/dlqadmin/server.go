@@ -0,0 +1,22 @@
+package dlqadmin
+
+import (
+	"google.golang.org/grpc"
+
+	"github.com/Anacardo89/order_svc_hex/order_svc/internal/ports"
+	pb "github.com/Anacardo89/order_svc_hex/order_svc/proto/orderpb"
+)
+
+type DeadLetterAdminGRPCServer struct {
+	pb.UnimplementedDeadLetterAdminServiceServer
+	service ports.DeadLetterAdmin
+}
+
+// Registers on the order gRPC server, sharing its listener and interceptors
+func RegisterDeadLetterAdminServer(s *grpc.Server, service ports.DeadLetterAdmin) *DeadLetterAdminGRPCServer {
+	server := &DeadLetterAdminGRPCServer{
+		service: service,
+	}
+	pb.RegisterDeadLetterAdminServiceServer(s, server)
+	return server
+}
@@ -0,0 +1,90 @@
+package dlqadmin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/Anacardo89/order_svc_hex/order_svc/internal/core"
+	pb "github.com/Anacardo89/order_svc_hex/order_svc/proto/orderpb"
+)
+
+func (s *DeadLetterAdminGRPCServer) ListDeadLetters(ctx context.Context, req *pb.ListDeadLettersRequest) (*pb.ListDeadLettersResponse, error) {
+	after, err := decodePageToken(req.PageToken)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid page_token")
+	}
+	page, err := s.service.ListDeadLetters(ctx, core.ListDeadLettersQry{
+		Filter:  toCoreFilter(req.Filter),
+		Limit:   pageSize(req.Limit),
+		AfterID: after,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list dead letters: %w", err)
+	}
+	resp := &pb.ListDeadLettersResponse{
+		DeadLetters:   make([]*pb.DeadLetter, 0, len(page.DeadLetters)),
+		NextPageToken: encodePageToken(page.Next),
+	}
+	for _, l := range page.DeadLetters {
+		resp.DeadLetters = append(resp.DeadLetters, toProtoDeadLetter(l))
+	}
+	return resp, nil
+}
+
+func (s *DeadLetterAdminGRPCServer) GetDeadLetter(ctx context.Context, req *pb.GetDeadLetterRequest) (*pb.DeadLetter, error) {
+	letter, err := s.service.GetDeadLetter(ctx, req.Id)
+	if err != nil {
+		return nil, mapError("failed to get dead letter", err)
+	}
+	return toProtoDeadLetter(letter), nil
+}
+
+func (s *DeadLetterAdminGRPCServer) ResolveDeadLetter(ctx context.Context, req *pb.ResolveDeadLetterRequest) (*pb.DeadLetter, error) {
+	letter, err := s.service.ResolveDeadLetter(ctx, req.Id, req.Note)
+	if err != nil {
+		return nil, mapError("failed to resolve dead letter", err)
+	}
+	return toProtoDeadLetter(letter), nil
+}
+
+func (s *DeadLetterAdminGRPCServer) ReplayDeadLetter(ctx context.Context, req *pb.ReplayDeadLetterRequest) (*pb.DeadLetter, error) {
+	letter, err := s.service.ReplayDeadLetter(ctx, req.Id)
+	if err != nil {
+		return nil, mapError("failed to replay dead letter", err)
+	}
+	return toProtoDeadLetter(letter), nil
+}
+
+func (s *DeadLetterAdminGRPCServer) PurgeDeadLetters(ctx context.Context, req *pb.PurgeDeadLettersRequest) (*pb.PurgeDeadLettersResponse, error) {
+	filter := toCoreFilter(req.Filter)
+	if len(filter.Statuses) == 0 && filter.FailedBefore == nil {
+		return nil, status.Error(codes.InvalidArgument, "purge needs statuses or failed_before")
+	}
+	deleted, err := s.service.PurgeDeadLetters(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to purge dead letters: %w", err)
+	}
+	return &pb.PurgeDeadLettersResponse{Deleted: deleted}, nil
+}
+
+func (s *DeadLetterAdminGRPCServer) CountDeadLetters(ctx context.Context, req *pb.CountDeadLettersRequest) (*pb.CountDeadLettersResponse, error) {
+	counts, err := s.service.CountDeadLetters(ctx, toCoreFilter(req.Filter))
+	if err != nil {
+		return nil, fmt.Errorf("failed to count dead letters: %w", err)
+	}
+	return toProtoCounts(counts), nil
+}
+
+func mapError(msg string, err error) error {
+	switch {
+	case errors.Is(err, core.ErrDeadLetterNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, core.ErrDeadLetterNotOpen):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	}
+	return fmt.Errorf("%s: %w", msg, err)
+}
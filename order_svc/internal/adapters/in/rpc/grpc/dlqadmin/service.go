@@ -0,0 +1,17 @@
+package dlqadmin
+
+import (
+	"github.com/Anacardo89/order_svc_hex/order_svc/internal/ports"
+)
+
+type DeadLetterAdminService struct {
+	store    ports.DeadLetterStore
+	replayer ports.DeadLetterReplayer
+}
+
+func NewDeadLetterAdminService(store ports.DeadLetterStore, replayer ports.DeadLetterReplayer) ports.DeadLetterAdmin {
+	return &DeadLetterAdminService{
+		store:    store,
+		replayer: replayer,
+	}
+}
@@ -0,0 +1,43 @@
+package dlqadmin
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Anacardo89/order_svc_hex/order_svc/internal/core"
+)
+
+func (s *DeadLetterAdminService) ListDeadLetters(ctx context.Context, qry core.ListDeadLettersQry) (*core.DeadLetterPage, error) {
+	return s.store.ListDeadLetters(ctx, qry)
+}
+
+func (s *DeadLetterAdminService) GetDeadLetter(ctx context.Context, id int64) (*core.DeadLetter, error) {
+	return s.store.GetDeadLetter(ctx, id)
+}
+
+func (s *DeadLetterAdminService) ResolveDeadLetter(ctx context.Context, id int64, note string) (*core.DeadLetter, error) {
+	return s.store.ResolveDeadLetter(ctx, id, note)
+}
+
+// Marked only once the publish is acknowledged, a failed replay leaves the entry as it was
+func (s *DeadLetterAdminService) ReplayDeadLetter(ctx context.Context, id int64) (*core.DeadLetter, error) {
+	letter, err := s.store.GetDeadLetter(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if letter.Status != core.DeadLetterOpen {
+		return nil, fmt.Errorf("%w: id %d is %s", core.ErrDeadLetterNotOpen, id, letter.Status)
+	}
+	if err := s.replayer.Replay(ctx, letter); err != nil {
+		return nil, err
+	}
+	return s.store.MarkDeadLetterReplayed(ctx, id)
+}
+
+func (s *DeadLetterAdminService) PurgeDeadLetters(ctx context.Context, filter core.DeadLetterFilter) (int64, error) {
+	return s.store.PurgeDeadLetters(ctx, filter)
+}
+
+func (s *DeadLetterAdminService) CountDeadLetters(ctx context.Context, filter core.DeadLetterFilter) ([]*core.DeadLetterCount, error) {
+	return s.store.CountDeadLetters(ctx, filter)
+}
@@ -0,0 +1,125 @@
+package dlqadmin
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/Anacardo89/order_svc_hex/order_svc/internal/core"
+	"github.com/Anacardo89/order_svc_hex/order_svc/internal/ports"
+	pb "github.com/Anacardo89/order_svc_hex/order_svc/proto/orderpb"
+)
+
+type fakeStore struct {
+	ports.DeadLetterStore
+	letters map[int64]*core.DeadLetter
+	purged  bool
+}
+
+func (f *fakeStore) GetDeadLetter(ctx context.Context, id int64) (*core.DeadLetter, error) {
+	l, ok := f.letters[id]
+	if !ok {
+		return nil, core.ErrDeadLetterNotFound
+	}
+	return l, nil
+}
+
+func (f *fakeStore) MarkDeadLetterReplayed(ctx context.Context, id int64) (*core.DeadLetter, error) {
+	l := f.letters[id]
+	l.Status = core.DeadLetterReplayed
+	return l, nil
+}
+
+func (f *fakeStore) PurgeDeadLetters(ctx context.Context, filter core.DeadLetterFilter) (int64, error) {
+	f.purged = true
+	return 1, nil
+}
+
+type fakeReplayer struct {
+	err      error
+	replayed []int64
+}
+
+func (f *fakeReplayer) Replay(ctx context.Context, l *core.DeadLetter) error {
+	if f.err != nil {
+		return f.err
+	}
+	f.replayed = append(f.replayed, l.ID)
+	return nil
+}
+
+func TestDeadLetterAdmin_Replay(t *testing.T) {
+	tests := []struct {
+		name       string
+		id         int64
+		replayErr  error
+		wantCode   codes.Code
+		wantStatus core.DeadLetterStatus
+	}{
+		{
+			name:       "replays and marks the entry",
+			id:         1,
+			wantCode:   codes.OK,
+			wantStatus: core.DeadLetterReplayed,
+		},
+		{
+			name:       "failed publish leaves the entry open",
+			id:         1,
+			replayErr:  errors.New("broker down"),
+			wantCode:   codes.Unknown,
+			wantStatus: core.DeadLetterOpen,
+		},
+		{
+			name:     "unknown id",
+			id:       2,
+			wantCode: codes.NotFound,
+		},
+		{
+			name:       "resolved entry is not replayed",
+			id:         3,
+			wantCode:   codes.FailedPrecondition,
+			wantStatus: core.DeadLetterResolved,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &fakeStore{letters: map[int64]*core.DeadLetter{
+				1: {ID: 1, OriginalTopic: "orders.created", Status: core.DeadLetterOpen},
+				3: {ID: 3, OriginalTopic: "orders.created", Status: core.DeadLetterResolved},
+			}}
+			replayer := &fakeReplayer{err: tt.replayErr}
+			server := &DeadLetterAdminGRPCServer{service: NewDeadLetterAdminService(store, replayer)}
+
+			_, err := server.ReplayDeadLetter(context.Background(), &pb.ReplayDeadLetterRequest{Id: tt.id})
+			assert.Equal(t, tt.wantCode, status.Code(err))
+			if l, ok := store.letters[tt.id]; ok {
+				assert.Equal(t, tt.wantStatus, l.Status)
+			}
+			if tt.wantCode != codes.OK {
+				assert.Empty(t, replayer.replayed)
+			}
+		})
+	}
+}
+
+func TestDeadLetterAdmin_PurgeNeedsBound(t *testing.T) {
+	store := &fakeStore{}
+	server := &DeadLetterAdminGRPCServer{service: NewDeadLetterAdminService(store, &fakeReplayer{})}
+
+	_, err := server.PurgeDeadLetters(context.Background(), &pb.PurgeDeadLettersRequest{
+		Filter: &pb.DeadLetterFilter{Reasons: []string{"handler_error"}},
+	})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.False(t, store.purged)
+
+	resp, err := server.PurgeDeadLetters(context.Background(), &pb.PurgeDeadLettersRequest{
+		Filter: &pb.DeadLetterFilter{Statuses: []pb.DeadLetterStatus{pb.DeadLetterStatus_DEAD_LETTER_RESOLVED}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), resp.Deleted)
+}
@@ -1,12 +1,177 @@
 package orderserver
 
 import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
 	"github.com/Anacardo89/order_svc_hex/order_svc/internal/core"
 	"github.com/Anacardo89/order_svc_hex/order_svc/pkg/ptr"
 	pb "github.com/Anacardo89/order_svc_hex/order_svc/proto/orderpb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
+const (
+	defaultPageSize  = 50
+	maxPageSize      = 500
+	defaultBatchSize = 100
+)
+
+// Set on InvalidArgument details, clients match on the reason instead of the message
+const (
+	errorDomain            = "order_svc"
+	reasonInvalidPageToken = "INVALID_PAGE_TOKEN"
+	reasonInvalidFilter    = "INVALID_FILTER"
+)
+
+func invalidArgument(reason, msg string) error {
+	st, err := status.New(codes.InvalidArgument, msg).WithDetails(&errdetails.ErrorInfo{
+		Reason: reason,
+		Domain: errorDomain,
+	})
+	if err != nil {
+		return status.Error(codes.InvalidArgument, msg)
+	}
+	return st.Err()
+}
+
+func pageSize(limit int32) int {
+	switch {
+	case limit <= 0:
+		return defaultPageSize
+	case limit > maxPageSize:
+		return maxPageSize
+	default:
+		return int(limit)
+	}
+}
+
+func batchSize(max int) int {
+	if max <= 0 {
+		return defaultBatchSize
+	}
+	return max
+}
+
+// Drops duplicates keeping the first occurrence, so each id is reported once
+func parseBatchIDs(raw []string) ([]uuid.UUID, error) {
+	ids := make([]uuid.UUID, 0, len(raw))
+	seen := make(map[uuid.UUID]struct{}, len(raw))
+	for _, r := range raw {
+		id, err := uuid.Parse(r)
+		if err != nil {
+			return nil, fmt.Errorf("invalid id %q", r)
+		}
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+func toProtoBatch(batch *core.OrderBatch) *pb.GetOrdersByIDsResponse {
+	resp := &pb.GetOrdersByIDsResponse{
+		Orders:     make([]*pb.Order, 0, len(batch.Orders)),
+		MissingIds: make([]string, 0, len(batch.Missing)),
+	}
+	for _, o := range batch.Orders {
+		resp.Orders = append(resp.Orders, toProtoOrder(o))
+	}
+	for _, id := range batch.Missing {
+		resp.MissingIds = append(resp.MissingIds, id.String())
+	}
+	return resp
+}
+
+// Tokens are opaque to clients: base64 of "<sort>|<sort key>|<id>".
+// order_api's read model issues the same ones, contracts/orders/testdata/page_tokens.json pins the format
+func encodePageToken(cursor *core.PageCursor, sort core.OrderSort) string {
+	if cursor == nil {
+		return ""
+	}
+	raw := strings.Join([]string{
+		string(sort),
+		cursor.SortKey.UTC().Format(time.RFC3339Nano),
+		cursor.ID.String(),
+	}, "|")
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// A token only makes sense with the sort it was issued for
+func decodePageToken(token string, sort core.OrderSort) (*core.PageCursor, error) {
+	if token == "" {
+		return nil, nil
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, err
+	}
+	parts := strings.Split(string(raw), "|")
+	if len(parts) != 3 {
+		return nil, errors.New("malformed page token")
+	}
+	if core.OrderSort(parts[0]) != sort {
+		return nil, errors.New("page token issued for a different sort")
+	}
+	sortKey, err := time.Parse(time.RFC3339Nano, parts[1])
+	if err != nil {
+		return nil, err
+	}
+	orderID, err := uuid.Parse(parts[2])
+	if err != nil {
+		return nil, err
+	}
+	return &core.PageCursor{
+		SortKey: sortKey,
+		ID:      orderID,
+	}, nil
+}
+
+func mapSortToCore(sort pb.OrderSort) core.OrderSort {
+	switch sort {
+	case pb.OrderSort_SORT_CREATED_AT_DESC:
+		return core.SortCreatedAtDesc
+	case pb.OrderSort_SORT_UPDATED_AT:
+		return core.SortUpdatedAt
+	case pb.OrderSort_SORT_UPDATED_AT_DESC:
+		return core.SortUpdatedAtDesc
+	default:
+		return core.SortCreatedAt
+	}
+}
+
+func toCoreFilter(req *pb.ListOrdersRequest) (core.OrderFilter, error) {
+	filter := core.OrderFilter{
+		SKUs: req.Skus,
+	}
+	for _, s := range req.Statuses {
+		orderStatus := mapStatusToCore(s)
+		if orderStatus == "" {
+			return core.OrderFilter{}, fmt.Errorf("unknown status %d", s)
+		}
+		filter.Statuses = append(filter.Statuses, orderStatus)
+	}
+	if req.CreatedAfter != nil {
+		filter.CreatedAfter = ptr.Ptr(req.CreatedAfter.AsTime())
+	}
+	if req.CreatedBefore != nil {
+		filter.CreatedBefore = ptr.Ptr(req.CreatedBefore.AsTime())
+	}
+	if req.UpdatedSince != nil {
+		filter.UpdatedSince = ptr.Ptr(req.UpdatedSince.AsTime())
+	}
+	return filter, nil
+}
+
 func mapStatusToProto(status core.Status) pb.OrderStatus {
 	switch status {
 	case core.StatusPending:
@@ -15,6 +180,14 @@ func mapStatusToProto(status core.Status) pb.OrderStatus {
 		return pb.OrderStatus_STATUS_CONFIRMED
 	case core.StatusFailed:
 		return pb.OrderStatus_STATUS_FAILED
+	case core.StatusCancelled:
+		return pb.OrderStatus_STATUS_CANCELLED
+	case core.StatusShipped:
+		return pb.OrderStatus_STATUS_SHIPPED
+	case core.StatusDelivered:
+		return pb.OrderStatus_STATUS_DELIVERED
+	case core.StatusRefunded:
+		return pb.OrderStatus_STATUS_REFUNDED
 	default:
 		return pb.OrderStatus_STATUS_PENDING
 	}
@@ -28,6 +201,14 @@ func mapStatusToCore(s pb.OrderStatus) core.Status {
 		return core.StatusConfirmed
 	case pb.OrderStatus_STATUS_FAILED:
 		return core.StatusFailed
+	case pb.OrderStatus_STATUS_CANCELLED:
+		return core.StatusCancelled
+	case pb.OrderStatus_STATUS_SHIPPED:
+		return core.StatusShipped
+	case pb.OrderStatus_STATUS_DELIVERED:
+		return core.StatusDelivered
+	case pb.OrderStatus_STATUS_REFUNDED:
+		return core.StatusRefunded
 	default:
 		return ""
 	}
@@ -46,3 +227,33 @@ func toProtoOrder(order *core.Order) *pb.Order {
 		UpdatedAt: timestamppb.New(order.UpdatedAt),
 	}
 }
+
+func toCoreWatchQry(req *pb.WatchOrdersRequest) (core.WatchOrdersQry, error) {
+	var qry core.WatchOrdersQry
+	for _, id := range req.Ids {
+		orderID, err := uuid.Parse(id)
+		if err != nil {
+			return core.WatchOrdersQry{}, fmt.Errorf("invalid id: %s", id)
+		}
+		qry.IDs = append(qry.IDs, orderID)
+	}
+	for _, s := range req.Statuses {
+		qry.Statuses = append(qry.Statuses, mapStatusToCore(s))
+	}
+	if req.Since != nil {
+		qry.Since = ptr.Ptr(req.Since.AsTime())
+	}
+	return qry, nil
+}
+
+func toProtoChange(change core.OrderChange) *pb.OrderChange {
+	changeType := pb.ChangeType_CHANGE_UPDATED
+	if change.Type == core.ChangeCreated {
+		changeType = pb.ChangeType_CHANGE_CREATED
+	}
+	return &pb.OrderChange{
+		Type:      changeType,
+		Order:     toProtoOrder(change.Order),
+		ChangedAt: timestamppb.New(change.ChangedAt),
+	}
+}
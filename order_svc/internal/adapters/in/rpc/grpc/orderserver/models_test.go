@@ -0,0 +1,41 @@
+package orderserver
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Anacardo89/order_svc_hex/order_svc/internal/core"
+)
+
+// Shared with order_api's read model, which issues and accepts the same tokens
+const pageTokensFixture = "../../../../../../../contracts/orders/testdata/page_tokens.json"
+
+func TestPageToken_MatchesOrderAPI(t *testing.T) {
+	raw, err := os.ReadFile(pageTokensFixture)
+	require.NoError(t, err)
+	var fixtures []struct {
+		Sort    core.OrderSort `json:"sort"`
+		SortKey time.Time      `json:"sort_key"`
+		ID      uuid.UUID      `json:"id"`
+		Token   string         `json:"token"`
+	}
+	require.NoError(t, json.Unmarshal(raw, &fixtures))
+	require.NotEmpty(t, fixtures)
+
+	for _, f := range fixtures {
+		t.Run(string(f.Sort), func(t *testing.T) {
+			assert.Equal(t, f.Token, encodePageToken(&core.PageCursor{SortKey: f.SortKey, ID: f.ID}, f.Sort))
+
+			cursor, err := decodePageToken(f.Token, f.Sort)
+			require.NoError(t, err)
+			assert.True(t, f.SortKey.Equal(cursor.SortKey))
+			assert.Equal(t, f.ID, cursor.ID)
+		})
+	}
+}
@@ -14,9 +14,10 @@ type OrderGRPCServer struct {
 	Listener net.Listener
 	service  ports.OrderServer
 	metrics  *grpcMetrics
+	maxBatch int
 }
 
-func NewOrderGRPCServer(port string, service ports.OrderServer, metrics *grpcMetrics) (*OrderGRPCServer, error) {
+func NewOrderGRPCServer(port string, service ports.OrderServer, metrics *grpcMetrics, maxBatch int) (*OrderGRPCServer, error) {
 	listener, err := net.Listen("tcp", ":"+port)
 	if err != nil {
 		return nil, err
@@ -36,6 +37,7 @@ func NewOrderGRPCServer(port string, service ports.OrderServer, metrics *grpcMet
 		Listener: listener,
 		service:  service,
 		metrics:  metrics,
+		maxBatch: batchSize(maxBatch),
 	}
 	pb.RegisterOrderServiceServer(s, server)
 	return server, nil
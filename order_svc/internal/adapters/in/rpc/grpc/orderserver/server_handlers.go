@@ -2,8 +2,13 @@ package orderserver
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/Anacardo89/order_svc_hex/order_svc/internal/core"
 	pb "github.com/Anacardo89/order_svc_hex/order_svc/proto/orderpb"
 )
 
@@ -15,15 +20,93 @@ func (s *OrderGRPCServer) GetOrderByID(ctx context.Context, req *pb.GetOrderByID
 	return toProtoOrder(order), nil
 }
 
-func (s *OrderGRPCServer) ListOrdersByStatus(req *pb.ListOrdersByStatusRequest, stream pb.OrderService_ListOrdersByStatusServer) error {
-	orderCh, err := s.service.ListOrdersByStatus(stream.Context(), mapStatusToCore(req.Status))
+func (s *OrderGRPCServer) GetOrdersByIDs(ctx context.Context, req *pb.GetOrdersByIDsRequest) (*pb.GetOrdersByIDsResponse, error) {
+	if len(req.Ids) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "ids is required")
+	}
+	if len(req.Ids) > s.maxBatch {
+		return nil, status.Errorf(codes.InvalidArgument, "at most %d ids per batch", s.maxBatch)
+	}
+	ids, err := parseBatchIDs(req.Ids)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	batch, err := s.service.GetOrdersByIDs(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get orders: %w", err)
+	}
+	return toProtoBatch(batch), nil
+}
+
+func (s *OrderGRPCServer) ListOrders(req *pb.ListOrdersRequest, stream pb.OrderService_ListOrdersServer) error {
+	sort := mapSortToCore(req.Sort)
+	after, err := decodePageToken(req.PageToken, sort)
+	if err != nil {
+		return invalidArgument(reasonInvalidPageToken, "invalid page_token")
+	}
+	filter, err := toCoreFilter(req)
+	if err != nil {
+		return invalidArgument(reasonInvalidFilter, err.Error())
+	}
+	qry := core.ListOrdersQry{
+		Filter: filter,
+		Sort:   sort,
+		Limit:  pageSize(req.Limit),
+		After:  after,
+	}
+	next, err := s.service.ListOrders(stream.Context(), qry, func(order *core.Order) error {
+		return stream.Send(&pb.ListOrdersResponse{Order: toProtoOrder(order)})
+	})
 	if err != nil {
 		return err
 	}
-	for order := range orderCh {
-		if err := stream.Send(toProtoOrder(order)); err != nil {
+	if next == nil {
+		return nil
+	}
+	return stream.Send(&pb.ListOrdersResponse{NextPageToken: encodePageToken(next, sort)})
+}
+
+// Deprecated: streams every order with the status, paging internally, as before ListOrders
+func (s *OrderGRPCServer) ListOrdersByStatus(req *pb.ListOrdersByStatusRequest, stream pb.OrderService_ListOrdersByStatusServer) error {
+	orderStatus := mapStatusToCore(req.Status)
+	if orderStatus == "" {
+		return invalidArgument(reasonInvalidFilter, fmt.Sprintf("unknown status %d", req.Status))
+	}
+	qry := core.ListOrdersQry{
+		Filter: core.OrderFilter{
+			Statuses: []core.Status{orderStatus},
+		},
+		Sort:  core.SortCreatedAt,
+		Limit: maxPageSize,
+	}
+	for {
+		next, err := s.service.ListOrders(stream.Context(), qry, func(order *core.Order) error {
+			return stream.Send(toProtoOrder(order))
+		})
+		if err != nil {
 			return err
 		}
+		if next == nil {
+			return nil
+		}
+		qry.After = next
+	}
+}
+
+func (s *OrderGRPCServer) WatchOrders(req *pb.WatchOrdersRequest, stream pb.OrderService_WatchOrdersServer) error {
+	qry, err := toCoreWatchQry(req)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	err = s.service.WatchOrders(stream.Context(), qry, func(change core.OrderChange) error {
+		return stream.Send(toProtoChange(change))
+	})
+	switch {
+	case errors.Is(err, core.ErrWatchLagged):
+		return status.Error(codes.ResourceExhausted, "watcher fell behind, resume with since")
+	case stream.Context().Err() != nil:
+		return status.FromContextError(stream.Context().Err()).Err()
+	default:
+		return err
 	}
-	return nil
 }
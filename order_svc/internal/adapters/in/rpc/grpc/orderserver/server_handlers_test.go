@@ -0,0 +1,87 @@
+package orderserver
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/Anacardo89/order_svc_hex/order_svc/internal/core"
+	"github.com/Anacardo89/order_svc_hex/order_svc/internal/ports"
+	pb "github.com/Anacardo89/order_svc_hex/order_svc/proto/orderpb"
+)
+
+type fakeService struct {
+	ports.OrderServer
+	pages [][]*core.Order
+	qrys  []core.ListOrdersQry
+}
+
+func (f *fakeService) ListOrders(ctx context.Context, qry core.ListOrdersQry, send func(*core.Order) error) (*core.PageCursor, error) {
+	f.qrys = append(f.qrys, qry)
+	page := f.pages[len(f.qrys)-1]
+	for _, o := range page {
+		if err := send(o); err != nil {
+			return nil, err
+		}
+	}
+	if len(f.qrys) == len(f.pages) {
+		return nil, nil
+	}
+	last := page[len(page)-1]
+	return &core.PageCursor{SortKey: last.CreatedAt, ID: last.ID}, nil
+}
+
+type fakeOrderStream struct {
+	grpc.ServerStream
+	sent []*pb.Order
+}
+
+func (f *fakeOrderStream) Context() context.Context {
+	return context.Background()
+}
+
+func (f *fakeOrderStream) Send(o *pb.Order) error {
+	f.sent = append(f.sent, o)
+	return nil
+}
+
+func TestOrderGRPCServer_ListOrdersByStatus(t *testing.T) {
+	first := &core.Order{ID: uuid.New()}
+	second := &core.Order{ID: uuid.New()}
+	svc := &fakeService{pages: [][]*core.Order{{first}, {second}}}
+	server := &OrderGRPCServer{service: svc}
+
+	stream := &fakeOrderStream{}
+	err := server.ListOrdersByStatus(&pb.ListOrdersByStatusRequest{Status: pb.OrderStatus_STATUS_CONFIRMED}, stream)
+	require.NoError(t, err)
+
+	// Old clients get every page in one stream of bare orders
+	require.Len(t, stream.sent, 2)
+	assert.Equal(t, first.ID.String(), stream.sent[0].Id)
+	assert.Equal(t, second.ID.String(), stream.sent[1].Id)
+	require.Len(t, svc.qrys, 2)
+	assert.Equal(t, []core.Status{core.StatusConfirmed}, svc.qrys[0].Filter.Statuses)
+	assert.Nil(t, svc.qrys[0].After)
+	require.NotNil(t, svc.qrys[1].After)
+	assert.Equal(t, first.ID, svc.qrys[1].After.ID)
+}
+
+func TestOrderGRPCServer_ListOrders_UnknownStatus(t *testing.T) {
+	server := &OrderGRPCServer{service: &fakeService{}}
+
+	// Rejected before anything is streamed
+	err := server.ListOrders(&pb.ListOrdersRequest{Statuses: []pb.OrderStatus{pb.OrderStatus_STATUS_CONFIRMED, 42}}, nil)
+	st := status.Convert(err)
+	assert.Equal(t, codes.InvalidArgument, st.Code())
+	require.Len(t, st.Details(), 1)
+	info, ok := st.Details()[0].(*errdetails.ErrorInfo)
+	require.True(t, ok)
+	assert.Equal(t, reasonInvalidFilter, info.Reason)
+}
@@ -6,10 +6,12 @@ import (
 
 type OrderGRPCService struct {
 	repo ports.OrderRepo
+	feed ports.OrderFeed
 }
 
-func NewOrderGRPCService(repo ports.OrderRepo) ports.OrderServer {
+func NewOrderGRPCService(repo ports.OrderRepo, feed ports.OrderFeed) ports.OrderServer {
 	return &OrderGRPCService{
 		repo: repo,
+		feed: feed,
 	}
 }
@@ -19,21 +19,102 @@ func (s *OrderGRPCService) GetOrderByID(ctx context.Context, id string) (*core.O
 	return order, nil
 }
 
-func (s *OrderGRPCService) ListOrdersByStatus(ctx context.Context, status core.Status) (<-chan *core.Order, error) {
-	orders, err := s.repo.ListByStatus(ctx, status)
+func (s *OrderGRPCService) GetOrdersByIDs(ctx context.Context, ids []uuid.UUID) (*core.OrderBatch, error) {
+	orders, err := s.repo.GetByIDs(ctx, ids)
 	if err != nil {
 		return nil, err
 	}
-	out := make(chan *core.Order, len(orders))
-	go func() {
-		defer close(out)
-		for _, o := range orders {
-			select {
-			case <-ctx.Done():
-				return
-			case out <- o:
+	byID := make(map[uuid.UUID]*core.Order, len(orders))
+	for _, o := range orders {
+		byID[o.ID] = o
+	}
+	batch := &core.OrderBatch{
+		Orders: make([]*core.Order, 0, len(orders)),
+	}
+	for _, id := range ids {
+		if o, ok := byID[id]; ok {
+			batch.Orders = append(batch.Orders, o)
+		} else {
+			batch.Missing = append(batch.Missing, id)
+		}
+	}
+	return batch, nil
+}
+
+func (s *OrderGRPCService) ListOrders(ctx context.Context, qry core.ListOrdersQry, send func(*core.Order) error) (*core.PageCursor, error) {
+	return s.repo.Stream(ctx, qry, send)
+}
+
+// The bulk of the replay runs before subscribing, so it can't overflow the live buffer.
+// A second replay from where the first stopped covers what committed meanwhile, at the cost of duplicates
+func (s *OrderGRPCService) WatchOrders(ctx context.Context, qry core.WatchOrdersQry, send func(core.OrderChange) error) error {
+	var replayed *core.PageCursor
+	if qry.Since != nil {
+		var err error
+		if replayed, err = s.replayChanges(ctx, qry, nil, send); err != nil {
+			return err
+		}
+	}
+	changes := s.feed.Subscribe(ctx)
+	if qry.Since != nil {
+		if _, err := s.replayChanges(ctx, qry, replayed, send); err != nil {
+			return err
+		}
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case change, ok := <-changes:
+			if !ok {
+				if ctx.Err() != nil {
+					return ctx.Err()
+				}
+				return core.ErrWatchLagged
+			}
+			if !qry.Matches(change.Order) {
+				continue
+			}
+			if err := send(change); err != nil {
+				return err
 			}
 		}
-	}()
-	return out, nil
+	}
+}
+
+// Current state of every order changed since qry.Since and past after, oldest change first.
+// Returns the position of the last order sent, or after when there was none
+func (s *OrderGRPCService) replayChanges(ctx context.Context, qry core.WatchOrdersQry, after *core.PageCursor, send func(core.OrderChange) error) (*core.PageCursor, error) {
+	listQry := core.ListOrdersQry{
+		Filter: core.OrderFilter{
+			IDs:          qry.IDs,
+			Statuses:     qry.Statuses,
+			UpdatedSince: qry.Since,
+		},
+		Sort:  core.SortUpdatedAt,
+		Limit: maxPageSize,
+		After: after,
+	}
+	last := after
+	for {
+		next, err := s.repo.Stream(ctx, listQry, func(o *core.Order) error {
+			last = &core.PageCursor{SortKey: o.UpdatedAt, ID: o.ID}
+			change := core.OrderChange{
+				Type:      core.ChangeUpdated,
+				Order:     o,
+				ChangedAt: o.UpdatedAt,
+			}
+			if o.CreatedAt.Equal(o.UpdatedAt) {
+				change.Type = core.ChangeCreated
+			}
+			return send(change)
+		})
+		if err != nil {
+			return nil, err
+		}
+		if next == nil {
+			return last, nil
+		}
+		listQry.After = next
+	}
 }
@@ -0,0 +1,110 @@
+package orderserver
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Anacardo89/order_svc_hex/order_svc/internal/core"
+	"github.com/Anacardo89/order_svc_hex/order_svc/internal/ports"
+	"github.com/Anacardo89/order_svc_hex/order_svc/pkg/ptr"
+)
+
+type fakeRepo struct {
+	ports.OrderRepo
+	replay []*core.Order
+	qrys   []core.ListOrdersQry
+	found  []*core.Order
+}
+
+func (f *fakeRepo) GetByIDs(ctx context.Context, ids []uuid.UUID) ([]*core.Order, error) {
+	return f.found, nil
+}
+
+func (f *fakeRepo) Stream(ctx context.Context, qry core.ListOrdersQry, send func(*core.Order) error) (*core.PageCursor, error) {
+	f.qrys = append(f.qrys, qry)
+	// Everything in replay sorts before any cursor
+	if qry.After != nil {
+		return nil, nil
+	}
+	for _, o := range f.replay {
+		if err := send(o); err != nil {
+			return nil, err
+		}
+	}
+	return nil, nil
+}
+
+type fakeFeed struct {
+	ch   chan core.OrderChange
+	repo *fakeRepo
+	// Replay queries made before the subscription
+	subscribedAfter int
+}
+
+func (f *fakeFeed) Subscribe(ctx context.Context) <-chan core.OrderChange {
+	f.subscribedAfter = len(f.repo.qrys)
+	return f.ch
+}
+
+func TestOrderGRPCService_WatchOrders(t *testing.T) {
+	watched := uuid.MustParse("11111111-1111-1111-1111-111111111111")
+	other := uuid.MustParse("22222222-2222-2222-2222-222222222222")
+	since := time.Now().Add(-time.Hour)
+	created := time.Now().Add(-time.Minute)
+
+	repo := &fakeRepo{
+		replay: []*core.Order{
+			{ID: watched, Status: ptr.Ptr(core.StatusPending), CreatedAt: created, UpdatedAt: created},
+		},
+	}
+	feed := &fakeFeed{ch: make(chan core.OrderChange, 3), repo: repo}
+	feed.ch <- core.OrderChange{Type: core.ChangeUpdated, Order: &core.Order{ID: other, Status: ptr.Ptr(core.StatusConfirmed)}}
+	feed.ch <- core.OrderChange{Type: core.ChangeUpdated, Order: &core.Order{ID: watched, Status: ptr.Ptr(core.StatusConfirmed)}}
+	close(feed.ch)
+	svc := NewOrderGRPCService(repo, feed)
+
+	var got []core.OrderChange
+	err := svc.WatchOrders(context.Background(), core.WatchOrdersQry{IDs: []uuid.UUID{watched}, Since: &since}, func(c core.OrderChange) error {
+		got = append(got, c)
+		return nil
+	})
+
+	// A closed subscription means the watcher fell behind and has to resume
+	require.ErrorIs(t, err, core.ErrWatchLagged)
+	require.Len(t, repo.qrys, 2)
+	assert.Equal(t, []uuid.UUID{watched}, repo.qrys[0].Filter.IDs)
+	assert.Equal(t, &since, repo.qrys[0].Filter.UpdatedSince)
+	assert.Nil(t, repo.qrys[0].After)
+	// Subscribed after the first replay, the second picks up from its last order
+	assert.Equal(t, 1, feed.subscribedAfter)
+	assert.Equal(t, &core.PageCursor{SortKey: created, ID: watched}, repo.qrys[1].After)
+	require.Len(t, got, 2)
+	assert.Equal(t, core.ChangeCreated, got[0].Type)
+	assert.Equal(t, created, got[0].ChangedAt)
+	assert.Equal(t, core.ChangeUpdated, got[1].Type)
+	assert.Equal(t, core.StatusConfirmed, *got[1].Order.Status)
+}
+
+func TestOrderGRPCService_GetOrdersByIDs(t *testing.T) {
+	first := uuid.MustParse("11111111-1111-1111-1111-111111111111")
+	second := uuid.MustParse("22222222-2222-2222-2222-222222222222")
+	unknown := uuid.MustParse("aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaaa")
+
+	// The database doesn't keep the requested order
+	repo := &fakeRepo{
+		found: []*core.Order{{ID: second}, {ID: first}},
+	}
+	svc := NewOrderGRPCService(repo, nil)
+
+	batch, err := svc.GetOrdersByIDs(context.Background(), []uuid.UUID{first, unknown, second})
+	require.NoError(t, err)
+	require.Len(t, batch.Orders, 2)
+	assert.Equal(t, first, batch.Orders[0].ID)
+	assert.Equal(t, second, batch.Orders[1].ID)
+	assert.Equal(t, []uuid.UUID{unknown}, batch.Missing)
+}
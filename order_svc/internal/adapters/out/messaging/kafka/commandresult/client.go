@@ -0,0 +1,24 @@
+package commandresult
+
+import (
+	"github.com/Anacardo89/order_svc_hex/order_svc/pkg/events"
+)
+
+type ResultClient struct {
+	producerResult *Producer
+}
+
+func NewResultClient(kc *events.KafkaConnection, topic string) (*ResultClient, error) {
+	p, err := NewProducer(kc, topic)
+	if err != nil {
+		return nil, err
+	}
+	return &ResultClient{
+		producerResult: p,
+	}, nil
+}
+
+func (c *ResultClient) Close() {
+	c.producerResult.producer.Flush(5000)
+	c.producerResult.producer.Close()
+}
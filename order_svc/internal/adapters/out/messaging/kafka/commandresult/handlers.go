@@ -0,0 +1,32 @@
+package commandresult
+
+import (
+	"context"
+
+	"github.com/Anacardo89/order_svc_hex/order_svc/internal/adapters/infra/log/loki/logger"
+	"github.com/Anacardo89/order_svc_hex/order_svc/internal/core"
+	"github.com/Anacardo89/order_svc_hex/order_svc/internal/ports"
+	"github.com/google/uuid"
+)
+
+func (c *ResultClient) PublishResult(ctx context.Context, result core.CommandResult) error {
+	var orderID string
+	if result.OrderID != uuid.Nil {
+		orderID = result.OrderID.String()
+	}
+	payload := CommandResultEvent{
+		CommandID:   result.CommandID.String(),
+		OrderID:     orderID,
+		Outcome:     string(result.Outcome),
+		Reason:      result.Reason,
+		Error:       result.Error,
+		ProcessedAt: result.ProcessedAt,
+	}
+	replyTo := result.ReplyTo
+	if replyTo != "" && replyTo != c.producerResult.topic {
+		// The header comes from the command, so it can't pick an arbitrary topic to write to
+		logger.BaseLogger.Warn(ctx, "ignoring unknown reply-to topic", ports.Field{Key: "reply_to", Value: replyTo})
+		replyTo = ""
+	}
+	return c.producerResult.publish(ctx, replyTo, payload.CommandID, payload)
+}
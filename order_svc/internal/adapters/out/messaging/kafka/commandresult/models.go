@@ -0,0 +1,12 @@
+package commandresult
+
+import "time"
+
+type CommandResultEvent struct {
+	CommandID   string    `json:"command_id"`
+	OrderID     string    `json:"order_id,omitempty"`
+	Outcome     string    `json:"outcome"`
+	Reason      string    `json:"reason,omitempty"`
+	Error       string    `json:"error,omitempty"`
+	ProcessedAt time.Time `json:"processed_at"`
+}
@@ -0,0 +1,105 @@
+package commandresult
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/Anacardo89/order_svc_hex/order_svc/internal/adapters/infra/log/loki/logger"
+	"github.com/Anacardo89/order_svc_hex/order_svc/internal/ports"
+	"github.com/Anacardo89/order_svc_hex/order_svc/pkg/events"
+	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type Producer struct {
+	producer *kafka.Producer
+	topic    string
+}
+
+func NewProducer(kc *events.KafkaConnection, topic string) (*Producer, error) {
+	p, err := kc.MakeProducer()
+	if err != nil {
+		return nil, err
+	}
+	return &Producer{
+		producer: p,
+		topic:    topic,
+	}, nil
+}
+
+// Replies go to replyTo when the command asked for it, the default topic otherwise
+func (p *Producer) publish(ctx context.Context, replyTo, correlationID string, payload any) error {
+	topic := p.topic
+	if replyTo != "" {
+		topic = replyTo
+	}
+
+	// Observability
+	tracer := otel.Tracer("order_svc.kafka.results")
+	ctx, span := tracer.Start(ctx, "kafka.publish",
+		trace.WithAttributes(
+			attribute.String("messaging.system", "kafka"),
+			attribute.String("messaging.destination", topic),
+			attribute.String("messaging.operation", "publish"),
+		),
+	)
+	log := logger.BaseLogger
+	defer span.End()
+
+	// Execution
+	value, err := json.Marshal(payload)
+	if err != nil {
+		log.Error(ctx, "marshal result failed", ports.Field{Key: "error", Value: err})
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "marshal result failed")
+		return err
+	}
+	headers := append(injectTraceHeaders(ctx), kafka.Header{
+		Key:   events.HeaderCorrelationID,
+		Value: []byte(correlationID),
+	})
+	deliveryChan := make(chan kafka.Event, 1)
+	err = p.producer.Produce(&kafka.Message{
+		TopicPartition: kafka.TopicPartition{
+			Topic:     &topic,
+			Partition: kafka.PartitionAny,
+		},
+		Key:     []byte(correlationID),
+		Value:   value,
+		Headers: headers,
+	}, deliveryChan)
+	if err != nil {
+		log.Error(ctx, "publish result failed", ports.Field{Key: "error", Value: err})
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "publish result failed")
+		return err
+	}
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case e := <-deliveryChan:
+		m := e.(*kafka.Message)
+		if m.TopicPartition.Error != nil {
+			return m.TopicPartition.Error
+		}
+	}
+	return nil
+}
+
+func injectTraceHeaders(ctx context.Context) []kafka.Header {
+	headers := []kafka.Header{}
+	propagator := otel.GetTextMapPropagator()
+	carrier := propagation.MapCarrier{}
+	propagator.Inject(ctx, carrier)
+	for k, v := range carrier {
+		headers = append(headers, kafka.Header{
+			Key:   k,
+			Value: []byte(v),
+		})
+	}
+	return headers
+}
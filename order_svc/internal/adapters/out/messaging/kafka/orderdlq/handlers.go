@@ -17,14 +17,18 @@ func (c *DlqClient) PublishDLQ(ctx context.Context, msg ports.DLQMessage) error
 		key = []byte(msg.OriginalTopic)
 	}
 	payload := DlqPayload{
-		Timestamp:     time.Now().UTC(),
-		Reason:        msg.Reason,
-		Error:         err,
-		OriginalTopic: msg.OriginalTopic,
-		OriginalKey:   msg.OriginalKey,
-		OriginalValue: msg.OriginalValue,
-		Partition:     msg.Partition,
-		Offset:        msg.Offset,
+		Timestamp:       time.Now().UTC(),
+		Reason:          msg.Reason,
+		Error:           err,
+		OriginalTopic:   msg.OriginalTopic,
+		OriginalKey:     msg.OriginalKey,
+		OriginalValue:   msg.OriginalValue,
+		OriginalHeaders: msg.OriginalHeaders,
+		Partition:       msg.Partition,
+		Offset:          msg.Offset,
+		Attempts:        msg.Attempts,
+		TraceID:         msg.TraceID,
+		SpanID:          msg.SpanID,
 	}
 	return c.producerDlq.publish(ctx, string(key), payload, payload.Reason)
 }
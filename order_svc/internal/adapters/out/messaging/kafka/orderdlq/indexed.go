@@ -0,0 +1,59 @@
+package orderdlq
+
+import (
+	"context"
+	"time"
+
+	"github.com/Anacardo89/order_svc_hex/order_svc/internal/adapters/infra/log/loki/logger"
+	"github.com/Anacardo89/order_svc_hex/order_svc/internal/core"
+	"github.com/Anacardo89/order_svc_hex/order_svc/internal/ports"
+)
+
+// Indexes every entry for the admin API alongside the DLQ topic.
+// The entry is indexed even when the topic publish fails, so it is not lost,
+// and the message only counts as lost when neither write went through
+type IndexedDlq struct {
+	next  ports.OrderDLQ
+	store ports.DeadLetterStore
+}
+
+func NewIndexedDlq(next ports.OrderDLQ, store ports.DeadLetterStore) ports.OrderDLQ {
+	return &IndexedDlq{
+		next:  next,
+		store: store,
+	}
+}
+
+func (d *IndexedDlq) PublishDLQ(ctx context.Context, msg ports.DLQMessage) error {
+	log := logger.BaseLogger
+	pubErr := d.next.PublishDLQ(ctx, msg)
+	if err := d.store.InsertDeadLetter(ctx, toDeadLetter(msg)); err != nil {
+		log.Error(ctx, "failed to index dead letter", ports.Field{Key: "error", Value: err}, ports.Field{Key: "original_topic", Value: msg.OriginalTopic})
+		return pubErr
+	}
+	if pubErr != nil {
+		log.Warn(ctx, "dead letter indexed but not published to the DLQ topic", ports.Field{Key: "error", Value: pubErr}, ports.Field{Key: "original_topic", Value: msg.OriginalTopic})
+	}
+	return nil
+}
+
+func toDeadLetter(msg ports.DLQMessage) *core.DeadLetter {
+	var err string
+	if msg.Error != nil {
+		err = msg.Error.Error()
+	}
+	return &core.DeadLetter{
+		FailedAt:          time.Now().UTC(),
+		Reason:            msg.Reason,
+		Error:             err,
+		OriginalTopic:     msg.OriginalTopic,
+		OriginalKey:       msg.OriginalKey,
+		OriginalValue:     msg.OriginalValue,
+		OriginalHeaders:   msg.OriginalHeaders,
+		OriginalPartition: msg.Partition,
+		OriginalOffset:    msg.Offset,
+		Attempts:          msg.Attempts,
+		TraceID:           msg.TraceID,
+		SpanID:            msg.SpanID,
+	}
+}
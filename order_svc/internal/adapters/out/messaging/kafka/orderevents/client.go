@@ -0,0 +1,24 @@
+package orderevents
+
+import (
+	"github.com/Anacardo89/order_svc_hex/order_svc/pkg/events"
+)
+
+type EventsClient struct {
+	producerEvents *Producer
+}
+
+func NewEventsClient(kc *events.KafkaConnection, topic string) (*EventsClient, error) {
+	p, err := NewProducer(kc, topic)
+	if err != nil {
+		return nil, err
+	}
+	return &EventsClient{
+		producerEvents: p,
+	}, nil
+}
+
+func (c *EventsClient) Close() {
+	c.producerEvents.producer.Flush(5000)
+	c.producerEvents.producer.Close()
+}
@@ -0,0 +1,17 @@
+package orderevents
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+
+	"github.com/Anacardo89/order_svc_hex/order_svc/internal/core"
+)
+
+// Continues the trace of the write that stored the event
+func (c *EventsClient) PublishOrderEvent(ctx context.Context, event *core.OutboxEvent) error {
+	ctx = otel.GetTextMapPropagator().Extract(ctx, propagation.MapCarrier(event.Headers))
+	payload := toOrderEvent(event)
+	return c.producerEvents.publish(ctx, payload.ID, payload)
+}
@@ -0,0 +1,29 @@
+package orderevents
+
+import (
+	"time"
+
+	"github.com/Anacardo89/order_svc_hex/order_svc/internal/core"
+	"github.com/Anacardo89/order_svc_hex/order_svc/pkg/ptr"
+)
+
+// Full order state, so consumers can project it without calling back
+type OrderEvent struct {
+	EventType string         `json:"event_type"`
+	ID        string         `json:"id"`
+	Items     map[string]int `json:"items"`
+	Status    string         `json:"status"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+}
+
+func toOrderEvent(event *core.OutboxEvent) OrderEvent {
+	return OrderEvent{
+		EventType: string(event.Type),
+		ID:        event.Order.ID.String(),
+		Items:     event.Order.Items,
+		Status:    string(ptr.Val(event.Order.Status)),
+		CreatedAt: event.Order.CreatedAt,
+		UpdatedAt: event.Order.UpdatedAt,
+	}
+}
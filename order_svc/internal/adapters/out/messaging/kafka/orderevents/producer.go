@@ -0,0 +1,93 @@
+package orderevents
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/Anacardo89/order_svc_hex/order_svc/internal/adapters/infra/log/loki/logger"
+	"github.com/Anacardo89/order_svc_hex/order_svc/internal/ports"
+	"github.com/Anacardo89/order_svc_hex/order_svc/pkg/events"
+	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type Producer struct {
+	producer *kafka.Producer
+	topic    string
+}
+
+func NewProducer(kc *events.KafkaConnection, topic string) (*Producer, error) {
+	p, err := kc.MakeProducer()
+	if err != nil {
+		return nil, err
+	}
+	return &Producer{
+		producer: p,
+		topic:    topic,
+	}, nil
+}
+
+// Keyed by order id so every change of an order lands on the same partition
+func (p *Producer) publish(ctx context.Context, key string, payload any) error {
+	// Observability
+	tracer := otel.Tracer("order_svc.kafka.events")
+	ctx, span := tracer.Start(ctx, "kafka.publish",
+		trace.WithAttributes(
+			attribute.String("messaging.system", "kafka"),
+			attribute.String("messaging.destination", p.topic),
+			attribute.String("messaging.operation", "publish"),
+		),
+	)
+	log := logger.BaseLogger
+	defer span.End()
+
+	// Execution
+	value, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	deliveryChan := make(chan kafka.Event, 1)
+	err = p.producer.Produce(&kafka.Message{
+		TopicPartition: kafka.TopicPartition{
+			Topic:     &p.topic,
+			Partition: kafka.PartitionAny,
+		},
+		Key:     []byte(key),
+		Value:   value,
+		Headers: injectTraceHeaders(ctx),
+	}, deliveryChan)
+	if err != nil {
+		log.Error(ctx, "publish event failed", ports.Field{Key: "error", Value: err})
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "publish event failed")
+		return err
+	}
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case e := <-deliveryChan:
+		m := e.(*kafka.Message)
+		if m.TopicPartition.Error != nil {
+			return m.TopicPartition.Error
+		}
+	}
+	return nil
+}
+
+func injectTraceHeaders(ctx context.Context) []kafka.Header {
+	headers := []kafka.Header{}
+	propagator := otel.GetTextMapPropagator()
+	carrier := propagation.MapCarrier{}
+	propagator.Inject(ctx, carrier)
+	for k, v := range carrier {
+		headers = append(headers, kafka.Header{
+			Key:   k,
+			Value: []byte(v),
+		})
+	}
+	return headers
+}
@@ -0,0 +1,94 @@
+package orderevents
+
+import (
+	"context"
+	"time"
+
+	"github.com/Anacardo89/order_svc_hex/order_svc/internal/adapters/infra/log/loki/logger"
+	"github.com/Anacardo89/order_svc_hex/order_svc/internal/ports"
+)
+
+const (
+	defaultPollInterval    = time.Second
+	defaultRelayBatch      = 100
+	defaultRetention       = 24 * time.Hour
+	defaultCleanupInterval = time.Hour
+)
+
+// Moves outbox rows to Kafka. Delivery is at least once:
+// an event published right before a crash is published again on restart
+type OutboxRelay struct {
+	outbox          ports.OutboxRepo
+	publisher       ports.OrderEventPublisher
+	pollInterval    time.Duration
+	batchSize       int
+	retention       time.Duration
+	cleanupInterval time.Duration
+}
+
+func NewOutboxRelay(outbox ports.OutboxRepo, publisher ports.OrderEventPublisher, pollInterval time.Duration, batchSize int, retention, cleanupInterval time.Duration) *OutboxRelay {
+	if pollInterval <= 0 {
+		pollInterval = defaultPollInterval
+	}
+	if batchSize <= 0 {
+		batchSize = defaultRelayBatch
+	}
+	if retention <= 0 {
+		retention = defaultRetention
+	}
+	if cleanupInterval <= 0 {
+		cleanupInterval = defaultCleanupInterval
+	}
+	return &OutboxRelay{
+		outbox:          outbox,
+		publisher:       publisher,
+		pollInterval:    pollInterval,
+		batchSize:       batchSize,
+		retention:       retention,
+		cleanupInterval: cleanupInterval,
+	}
+}
+
+func (r *OutboxRelay) Run(ctx context.Context) {
+	poll := time.NewTicker(r.pollInterval)
+	defer poll.Stop()
+	cleanup := time.NewTicker(r.cleanupInterval)
+	defer cleanup.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-poll.C:
+			r.drain(ctx)
+		case <-cleanup.C:
+			r.purge(ctx)
+		}
+	}
+}
+
+// Keeps relaying while full batches come back so a backlog doesn't wait for the next tick
+func (r *OutboxRelay) drain(ctx context.Context) {
+	log := logger.BaseLogger
+	for ctx.Err() == nil {
+		n, err := r.outbox.RelayOutbox(ctx, r.batchSize, r.publisher.PublishOrderEvent)
+		if err != nil {
+			log.Error(ctx, "failed to relay outbox", ports.Field{Key: "published", Value: n}, ports.Field{Key: "error", Value: err})
+			return
+		}
+		if n < r.batchSize {
+			return
+		}
+	}
+}
+
+func (r *OutboxRelay) purge(ctx context.Context) {
+	log := logger.BaseLogger
+	n, err := r.outbox.PurgeOutbox(ctx, time.Now().Add(-r.retention))
+	if err != nil {
+		log.Error(ctx, "failed to purge outbox", ports.Field{Key: "error", Value: err})
+		return
+	}
+	if n > 0 {
+		log.Info(ctx, "purged published outbox events", ports.Field{Key: "deleted", Value: n})
+	}
+}
@@ -0,0 +1,24 @@
+package orderreplay
+
+import (
+	"github.com/Anacardo89/order_svc_hex/order_svc/pkg/events"
+)
+
+type ReplayClient struct {
+	producerReplay *Producer
+}
+
+func NewReplayClient(kc *events.KafkaConnection) (*ReplayClient, error) {
+	p, err := NewProducer(kc)
+	if err != nil {
+		return nil, err
+	}
+	return &ReplayClient{
+		producerReplay: p,
+	}, nil
+}
+
+func (c *ReplayClient) Close() {
+	c.producerReplay.producer.Flush(5000)
+	c.producerReplay.producer.Close()
+}
@@ -0,0 +1,16 @@
+package orderreplay
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Anacardo89/order_svc_hex/order_svc/internal/core"
+)
+
+// Goes out as first published: same key, value and headers, so the message ID still deduplicates
+func (c *ReplayClient) Replay(ctx context.Context, letter *core.DeadLetter) error {
+	if letter.OriginalTopic == "" {
+		return errors.New("dead letter has no original topic")
+	}
+	return c.producerReplay.publish(ctx, letter.OriginalTopic, letter.OriginalKey, letter.OriginalValue, letter.OriginalHeaders)
+}
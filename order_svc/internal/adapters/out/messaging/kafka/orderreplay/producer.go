@@ -0,0 +1,52 @@
+package orderreplay
+
+import (
+	"context"
+
+	"github.com/Anacardo89/order_svc_hex/order_svc/internal/adapters/infra/log/loki/logger"
+	"github.com/Anacardo89/order_svc_hex/order_svc/internal/ports"
+	"github.com/Anacardo89/order_svc_hex/order_svc/pkg/events"
+	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type Producer struct {
+	producer *kafka.Producer
+}
+
+func NewProducer(kc *events.KafkaConnection) (*Producer, error) {
+	p, err := kc.MakeProducer()
+	if err != nil {
+		return nil, err
+	}
+	return &Producer{
+		producer: p,
+	}, nil
+}
+
+// Trace headers of the replay replace the ones of the failed attempt
+func (p *Producer) publish(ctx context.Context, topic string, key, value []byte, headers map[string]string) error {
+	// Observability
+	tracer := otel.Tracer("order_svc.kafka.replay")
+	ctx, span := tracer.Start(ctx, "kafka.publish",
+		trace.WithAttributes(
+			attribute.String("messaging.system", "kafka"),
+			attribute.String("messaging.destination", topic),
+			attribute.String("messaging.operation", "publish"),
+		),
+	)
+	log := logger.BaseLogger
+	defer span.End()
+
+	// Execution
+	if err := events.Publish(ctx, p.producer, topic, key, value, headers); err != nil {
+		log.Error(ctx, "publish replay failed", ports.Field{Key: "error", Value: err})
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "publish replay failed")
+		return err
+	}
+	return nil
+}
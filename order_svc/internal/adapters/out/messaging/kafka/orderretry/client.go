@@ -0,0 +1,26 @@
+package orderretry
+
+import (
+	"github.com/Anacardo89/order_svc_hex/order_svc/pkg/events"
+)
+
+type RetryClient struct {
+	producerRetry *Producer
+	tiers         []Tier
+}
+
+func NewRetryClient(kc *events.KafkaConnection, tiers []Tier) (*RetryClient, error) {
+	p, err := NewProducer(kc)
+	if err != nil {
+		return nil, err
+	}
+	return &RetryClient{
+		producerRetry: p,
+		tiers:         tiers,
+	}, nil
+}
+
+func (c *RetryClient) Close() {
+	c.producerRetry.producer.Flush(5000)
+	c.producerRetry.producer.Close()
+}
@@ -0,0 +1,30 @@
+package orderretry
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/Anacardo89/order_svc_hex/order_svc/internal/ports"
+	"github.com/Anacardo89/order_svc_hex/order_svc/pkg/events"
+)
+
+func (c *RetryClient) PublishRetry(ctx context.Context, msg ports.RetryMessage) (bool, error) {
+	if msg.Attempt < 1 || msg.Attempt > len(c.tiers) {
+		return false, nil
+	}
+	tier := c.tiers[msg.Attempt-1]
+	headers := make(map[string]string, len(msg.Headers)+5)
+	for k, v := range msg.Headers {
+		headers[k] = v
+	}
+	headers[events.HeaderRetryAttempt] = strconv.Itoa(msg.Attempt)
+	headers[events.HeaderNotBefore] = time.Now().Add(tier.Delay).UTC().Format(time.RFC3339Nano)
+	headers[events.HeaderOriginalTopic] = msg.OriginalTopic
+	headers[events.HeaderOriginalPartition] = strconv.Itoa(int(msg.Partition))
+	headers[events.HeaderOriginalOffset] = strconv.FormatInt(msg.Offset, 10)
+	if err := c.producerRetry.publish(ctx, tier.Topic, msg.Key, msg.Value, headers); err != nil {
+		return false, err
+	}
+	return true, nil
+}
@@ -0,0 +1,9 @@
+package orderretry
+
+import "time"
+
+// A message that failed n times goes to the nth tier and is not handled again before Delay has passed
+type Tier struct {
+	Topic string
+	Delay time.Duration
+}
@@ -0,0 +1,209 @@
+package orderfeed
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"github.com/Anacardo89/order_svc_hex/order_svc/internal/adapters/infra/log/loki/logger"
+	"github.com/Anacardo89/order_svc_hex/order_svc/internal/core"
+	"github.com/Anacardo89/order_svc_hex/order_svc/internal/ports"
+)
+
+const (
+	// Must match the channel in the notify_order_change trigger
+	notifyChannel    = "orders_changes"
+	subscriberBuffer = 256
+	reconnectDelay   = 2 * time.Second
+	// Notifications arriving within gatherWait of each other load in one query
+	gatherWait    = 10 * time.Millisecond
+	maxGatherSize = 100
+)
+
+// Payload sent by the notify_order_change trigger
+type notification struct {
+	Op        string    `json:"op"`
+	ID        uuid.UUID `json:"id"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type OrderFeed struct {
+	dsn  string
+	repo ports.OrderRepo
+	// Told whenever the listener connects or drops, may be nil
+	onListening func(bool)
+	mu          sync.Mutex
+	subs        map[chan core.OrderChange]struct{}
+}
+
+func NewFeed(dsn string, repo ports.OrderRepo, onListening func(bool)) *OrderFeed {
+	if onListening == nil {
+		onListening = func(bool) {}
+	}
+	return &OrderFeed{
+		dsn:         dsn,
+		repo:        repo,
+		onListening: onListening,
+		subs:        make(map[chan core.OrderChange]struct{}),
+	}
+}
+
+func (f *OrderFeed) Subscribe(ctx context.Context) <-chan core.OrderChange {
+	ch := make(chan core.OrderChange, subscriberBuffer)
+	f.mu.Lock()
+	f.subs[ch] = struct{}{}
+	f.mu.Unlock()
+	go func() {
+		<-ctx.Done()
+		f.unsubscribe(ch)
+	}()
+	return ch
+}
+
+// Listens until ctx is done, reconnecting on failure
+func (f *OrderFeed) Run(ctx context.Context) error {
+	log := logger.BaseLogger
+	for {
+		err := f.listen(ctx)
+		f.onListening(false)
+		if ctx.Err() != nil {
+			f.dropAll()
+			return ctx.Err()
+		}
+		// Notifications sent while disconnected are lost, subscribers resume from their last change
+		log.Error(ctx, "order feed listener failed", ports.Field{Key: "error", Value: err})
+		f.dropAll()
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(reconnectDelay):
+		}
+	}
+}
+
+func (f *OrderFeed) listen(ctx context.Context) error {
+	// Dedicated connection, pooled ones get recycled
+	conn, err := pgx.Connect(ctx, f.dsn)
+	if err != nil {
+		return err
+	}
+	defer conn.Close(context.Background())
+	if _, err := conn.Exec(ctx, "LISTEN "+notifyChannel); err != nil {
+		return err
+	}
+	logger.BaseLogger.Info(ctx, "order feed listening", ports.Field{Key: "channel", Value: notifyChannel})
+	f.onListening(true)
+	for {
+		n, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+		payloads, err := gather(ctx, conn, []string{n.Payload})
+		if err != nil {
+			return err
+		}
+		f.dispatch(ctx, payloads)
+	}
+}
+
+// Collects the notifications that follow closely on the first one
+func gather(ctx context.Context, conn *pgx.Conn, payloads []string) ([]string, error) {
+	for len(payloads) < maxGatherSize {
+		waitCtx, cancel := context.WithTimeout(ctx, gatherWait)
+		n, err := conn.WaitForNotification(waitCtx)
+		cancel()
+		if err != nil {
+			// A quiet wait leaves the connection usable
+			if ctx.Err() == nil && pgconn.Timeout(err) {
+				return payloads, nil
+			}
+			return nil, err
+		}
+		payloads = append(payloads, n.Payload)
+	}
+	return payloads, nil
+}
+
+// Notifications stay small, each changed row is loaded once for every subscriber
+func (f *OrderFeed) dispatch(ctx context.Context, payloads []string) {
+	log := logger.BaseLogger
+	// One change per order, an insert followed by updates is still a creation
+	var (
+		ids     []uuid.UUID
+		changes = make(map[uuid.UUID]*core.OrderChange, len(payloads))
+	)
+	for _, payload := range payloads {
+		var n notification
+		if err := json.Unmarshal([]byte(payload), &n); err != nil {
+			log.Error(ctx, "failed to unmarshal notification", ports.Field{Key: "error", Value: err})
+			continue
+		}
+		change, ok := changes[n.ID]
+		if !ok {
+			change = &core.OrderChange{Type: core.ChangeUpdated}
+			changes[n.ID] = change
+			ids = append(ids, n.ID)
+		}
+		change.ChangedAt = n.UpdatedAt
+		if n.Op == "INSERT" {
+			change.Type = core.ChangeCreated
+		}
+	}
+	if len(ids) == 0 {
+		return
+	}
+	orders, err := f.repo.GetByIDs(ctx, ids)
+	if err != nil {
+		// The changes are lost for everyone, subscribers reconnect and resume from their last change
+		log.Error(ctx, "failed to load changed orders", ports.Field{Key: "error", Value: err}, ports.Field{Key: "order_ids", Value: ids})
+		f.dropAll()
+		return
+	}
+	byID := make(map[uuid.UUID]*core.Order, len(orders))
+	for _, o := range orders {
+		byID[o.ID] = o
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, id := range ids {
+		// Deleted since the notification
+		order, ok := byID[id]
+		if !ok {
+			continue
+		}
+		change := *changes[id]
+		change.Order = order
+		for ch := range f.subs {
+			select {
+			case ch <- change:
+			default:
+				log.Warn(ctx, "dropping slow order feed subscriber")
+				delete(f.subs, ch)
+				close(ch)
+			}
+		}
+	}
+}
+
+func (f *OrderFeed) unsubscribe(ch chan core.OrderChange) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, ok := f.subs[ch]; ok {
+		delete(f.subs, ch)
+		close(ch)
+	}
+}
+
+func (f *OrderFeed) dropAll() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for ch := range f.subs {
+		delete(f.subs, ch)
+		close(ch)
+	}
+}
@@ -0,0 +1,62 @@
+package orderfeed
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Anacardo89/order_svc_hex/order_svc/internal/adapters/infra/log/loki/logger"
+	"github.com/Anacardo89/order_svc_hex/order_svc/internal/core"
+	"github.com/Anacardo89/order_svc_hex/order_svc/internal/ports"
+)
+
+type fakeRepo struct {
+	ports.OrderRepo
+	err error
+}
+
+func (f *fakeRepo) GetByIDs(ctx context.Context, ids []uuid.UUID) ([]*core.Order, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	orders := make([]*core.Order, 0, len(ids))
+	for _, id := range ids {
+		orders = append(orders, &core.Order{ID: id})
+	}
+	return orders, nil
+}
+
+func TestOrderFeed_Dispatch(t *testing.T) {
+	logger.BaseLogger = logger.NopLogger{}
+	id := uuid.New()
+	payload := fmt.Sprintf(`{"op": "INSERT", "id": %q, "updated_at": %q}`, id, time.Now().Format(time.RFC3339Nano))
+
+	t.Run("changes reach every subscriber", func(t *testing.T) {
+		feed := NewFeed("", &fakeRepo{}, nil)
+		ch := feed.Subscribe(t.Context())
+
+		feed.dispatch(context.Background(), []string{payload})
+
+		change, ok := <-ch
+		require.True(t, ok)
+		assert.Equal(t, core.ChangeCreated, change.Type)
+		assert.Equal(t, id, change.Order.ID)
+	})
+
+	t.Run("failed load drops the subscribers", func(t *testing.T) {
+		feed := NewFeed("", &fakeRepo{err: errors.New("connection reset")}, nil)
+		ch := feed.Subscribe(t.Context())
+
+		feed.dispatch(context.Background(), []string{payload})
+
+		// Closed without a change, so the watcher resumes from its last one
+		_, ok := <-ch
+		assert.False(t, ok)
+	})
+}
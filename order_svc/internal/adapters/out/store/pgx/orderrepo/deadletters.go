@@ -0,0 +1,438 @@
+package orderrepo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/Anacardo89/order_svc_hex/order_svc/internal/adapters/infra/log/loki/logger"
+	"github.com/Anacardo89/order_svc_hex/order_svc/internal/core"
+	"github.com/Anacardo89/order_svc_hex/order_svc/internal/ports"
+)
+
+const deadLetterColumns = `
+			id,
+			failed_at,
+			reason,
+			error,
+			original_topic,
+			original_key,
+			original_value,
+			original_headers,
+			original_partition,
+			original_offset,
+			attempts,
+			trace_id,
+			span_id,
+			status,
+			note,
+			resolved_at,
+			replayed_at`
+
+func (r *OrderRepo) InsertDeadLetter(ctx context.Context, l *core.DeadLetter) error {
+	// Observability
+	ctx, span := tracer.Start(ctx, "db.dead_letters.insert",
+		trace.WithAttributes(
+			attribute.String("db.system", "postgresql"),
+			attribute.String("db.operation", "INSERT"),
+			attribute.String("db.sql.table", "dead_letters"),
+		),
+	)
+	log := logger.BaseLogger
+	defer span.End()
+
+	// Execution
+	query := `
+		INSERT INTO dead_letters (
+			failed_at,
+			reason,
+			error,
+			original_topic,
+			original_key,
+			original_value,
+			original_headers,
+			original_partition,
+			original_offset,
+			attempts,
+			trace_id,
+			span_id
+		)
+		VALUES (
+			$1,
+			$2,
+			$3,
+			$4,
+			$5,
+			$6,
+			$7,
+			$8,
+			$9,
+			$10,
+			$11,
+			$12
+		)
+		RETURNING id
+	;`
+	headers := l.OriginalHeaders
+	if headers == nil {
+		headers = map[string]string{}
+	}
+	rawHeaders, err := json.Marshal(headers)
+	if err != nil {
+		log.Error(ctx, "marshal headers failed", ports.Field{Key: "error", Value: err})
+		return failExec(span, "marshal headers failed", err)
+	}
+	if err := r.pool.QueryRow(ctx, query,
+		l.FailedAt,
+		l.Reason,
+		l.Error,
+		l.OriginalTopic,
+		l.OriginalKey,
+		l.OriginalValue,
+		rawHeaders,
+		l.OriginalPartition,
+		l.OriginalOffset,
+		l.Attempts,
+		l.TraceID,
+		l.SpanID,
+	).Scan(&l.ID); err != nil {
+		log.Error(ctx, "query failed", ports.Field{Key: "error", Value: err})
+		return failExec(span, "query failed", err)
+	}
+	l.Status = core.DeadLetterOpen
+	return nil
+}
+
+func (r *OrderRepo) GetDeadLetter(ctx context.Context, id int64) (*core.DeadLetter, error) {
+	// Observability
+	ctx, span := tracer.Start(ctx, "db.dead_letters.get",
+		trace.WithAttributes(
+			attribute.String("db.system", "postgresql"),
+			attribute.String("db.operation", "SELECT"),
+			attribute.String("db.sql.table", "dead_letters"),
+		),
+	)
+	log := logger.BaseLogger
+	defer span.End()
+
+	// Execution
+	query := `
+		SELECT` + deadLetterColumns + `
+		FROM dead_letters
+		WHERE id = $1
+	;`
+	l, err := scanDeadLetter(r.pool.QueryRow(ctx, query, id))
+	if err != nil {
+		log.Error(ctx, "scan failed", ports.Field{Key: "error", Value: err})
+		return failQueryRow[core.DeadLetter](span, "scan failed", err)
+	}
+	return l, nil
+}
+
+// A message dead-lettered again after a replay comes back from another offset,
+// so one source position has one entry. The newest wins should that not hold
+func (r *OrderRepo) FindDeadLetter(ctx context.Context, topic string, partition int32, offset int64) (*core.DeadLetter, error) {
+	// Observability
+	ctx, span := tracer.Start(ctx, "db.dead_letters.find",
+		trace.WithAttributes(
+			attribute.String("db.system", "postgresql"),
+			attribute.String("db.operation", "SELECT"),
+			attribute.String("db.sql.table", "dead_letters"),
+		),
+	)
+	log := logger.BaseLogger
+	defer span.End()
+
+	// Execution
+	query := `
+		SELECT` + deadLetterColumns + `
+		FROM dead_letters
+		WHERE original_topic = $1
+		AND original_partition = $2
+		AND original_offset = $3
+		ORDER BY id DESC
+		LIMIT 1
+	;`
+	l, err := scanDeadLetter(r.pool.QueryRow(ctx, query, topic, partition, offset))
+	if err != nil {
+		log.Error(ctx, "scan failed", ports.Field{Key: "error", Value: err})
+		return failQueryRow[core.DeadLetter](span, "scan failed", err)
+	}
+	return l, nil
+}
+
+func (r *OrderRepo) ListDeadLetters(ctx context.Context, qry core.ListDeadLettersQry) (*core.DeadLetterPage, error) {
+	// Observability
+	ctx, span := tracer.Start(ctx, "db.dead_letters.list",
+		trace.WithAttributes(
+			attribute.String("db.system", "postgresql"),
+			attribute.String("db.operation", "SELECT"),
+			attribute.String("db.sql.table", "dead_letters"),
+		),
+	)
+	log := logger.BaseLogger
+	defer span.End()
+
+	// Execution
+	var args queryArgs
+	conds := deadLetterConds(qry.Filter, &args)
+	if qry.AfterID != nil {
+		conds = append(conds, "id < "+args.add(*qry.AfterID))
+	}
+	// One extra row tells us whether there is a next page
+	query := `
+		SELECT` + deadLetterColumns + `
+		FROM dead_letters` + whereClause(conds) + `
+		ORDER BY id DESC
+		LIMIT ` + args.add(qry.Limit+1) + `
+	;`
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		log.Error(ctx, "query failed", ports.Field{Key: "error", Value: err})
+		return failQueryRow[core.DeadLetterPage](span, "query failed", err)
+	}
+	defer rows.Close()
+	page := &core.DeadLetterPage{}
+	for rows.Next() {
+		l, err := scanDeadLetter(rows)
+		if err != nil {
+			log.Error(ctx, "scan failed", ports.Field{Key: "error", Value: err})
+			return failQueryRow[core.DeadLetterPage](span, "scan failed", err)
+		}
+		page.DeadLetters = append(page.DeadLetters, l)
+	}
+	if err := rows.Err(); err != nil {
+		log.Error(ctx, "rows loop failed", ports.Field{Key: "error", Value: err})
+		return failQueryRow[core.DeadLetterPage](span, "rows loop failed", err)
+	}
+	if len(page.DeadLetters) > qry.Limit {
+		page.DeadLetters = page.DeadLetters[:qry.Limit]
+		next := page.DeadLetters[qry.Limit-1].ID
+		page.Next = &next
+	}
+	span.SetAttributes(attribute.Int("db.rows_returned", len(page.DeadLetters)))
+	return page, nil
+}
+
+func (r *OrderRepo) ResolveDeadLetter(ctx context.Context, id int64, note string) (*core.DeadLetter, error) {
+	query := `
+		UPDATE dead_letters
+		SET status = 'resolved',
+			note = $2,
+			resolved_at = NOW()
+		WHERE id = $1
+		AND status = 'open'
+		RETURNING` + deadLetterColumns + `
+	;`
+	return r.updateDeadLetter(ctx, "db.dead_letters.resolve", query, id, note)
+}
+
+func (r *OrderRepo) MarkDeadLetterReplayed(ctx context.Context, id int64) (*core.DeadLetter, error) {
+	query := `
+		UPDATE dead_letters
+		SET status = 'replayed',
+			replayed_at = NOW()
+		WHERE id = $1
+		AND status = 'open'
+		RETURNING` + deadLetterColumns + `
+	;`
+	return r.updateDeadLetter(ctx, "db.dead_letters.mark_replayed", query, id)
+}
+
+func (r *OrderRepo) updateDeadLetter(ctx context.Context, spanName, query string, args ...any) (*core.DeadLetter, error) {
+	// Observability
+	ctx, span := tracer.Start(ctx, spanName,
+		trace.WithAttributes(
+			attribute.String("db.system", "postgresql"),
+			attribute.String("db.operation", "UPDATE"),
+			attribute.String("db.sql.table", "dead_letters"),
+		),
+	)
+	log := logger.BaseLogger
+	defer span.End()
+
+	// Execution
+	l, err := scanDeadLetter(r.pool.QueryRow(ctx, query, args...))
+	if errors.Is(err, core.ErrDeadLetterNotFound) {
+		// An entry never goes back to open, so checking afterwards is not racy
+		var exists bool
+		existsQuery := `
+			SELECT EXISTS (
+				SELECT 1
+				FROM dead_letters
+				WHERE id = $1
+			)
+		;`
+		if err := r.pool.QueryRow(ctx, existsQuery, args[0]).Scan(&exists); err != nil {
+			log.Error(ctx, "query failed", ports.Field{Key: "error", Value: err})
+			return failQueryRow[core.DeadLetter](span, "query failed", err)
+		}
+		if exists {
+			err = fmt.Errorf("%w: id %d", core.ErrDeadLetterNotOpen, args[0])
+		}
+		log.Warn(ctx, "dead letter not updated", ports.Field{Key: "error", Value: err})
+		return failQueryRow[core.DeadLetter](span, "dead letter not updated", err)
+	}
+	if err != nil {
+		log.Error(ctx, "scan failed", ports.Field{Key: "error", Value: err})
+		return failQueryRow[core.DeadLetter](span, "scan failed", err)
+	}
+	return l, nil
+}
+
+func (r *OrderRepo) PurgeDeadLetters(ctx context.Context, filter core.DeadLetterFilter) (int64, error) {
+	// Observability
+	ctx, span := tracer.Start(ctx, "db.dead_letters.purge",
+		trace.WithAttributes(
+			attribute.String("db.system", "postgresql"),
+			attribute.String("db.operation", "DELETE"),
+			attribute.String("db.sql.table", "dead_letters"),
+		),
+	)
+	log := logger.BaseLogger
+	defer span.End()
+
+	// Execution
+	var args queryArgs
+	query := `
+		DELETE FROM dead_letters` + whereClause(deadLetterConds(filter, &args)) + `
+	;`
+	tag, err := r.pool.Exec(ctx, query, args...)
+	if err != nil {
+		log.Error(ctx, "query failed", ports.Field{Key: "error", Value: err})
+		return 0, failExec(span, "query failed", err)
+	}
+	span.SetAttributes(attribute.Int64("db.rows_affected", tag.RowsAffected()))
+	log.Info(ctx, "dead letters purged", ports.Field{Key: "deleted", Value: tag.RowsAffected()})
+	return tag.RowsAffected(), nil
+}
+
+func (r *OrderRepo) CountDeadLetters(ctx context.Context, filter core.DeadLetterFilter) ([]*core.DeadLetterCount, error) {
+	// Observability
+	ctx, span := tracer.Start(ctx, "db.dead_letters.count",
+		trace.WithAttributes(
+			attribute.String("db.system", "postgresql"),
+			attribute.String("db.operation", "SELECT"),
+			attribute.String("db.sql.table", "dead_letters"),
+		),
+	)
+	log := logger.BaseLogger
+	defer span.End()
+
+	// Execution
+	var args queryArgs
+	query := `
+		SELECT
+			reason,
+			COUNT(*) FILTER (WHERE status = 'open'),
+			COUNT(*) FILTER (WHERE status = 'resolved'),
+			COUNT(*) FILTER (WHERE status = 'replayed'),
+			COUNT(*)
+		FROM dead_letters` + whereClause(deadLetterConds(filter, &args)) + `
+		GROUP BY reason
+		ORDER BY COUNT(*) DESC, reason
+	;`
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		log.Error(ctx, "query failed", ports.Field{Key: "error", Value: err})
+		return failQuery[core.DeadLetterCount](span, "query failed", err)
+	}
+	defer rows.Close()
+	var counts []*core.DeadLetterCount
+	for rows.Next() {
+		var c core.DeadLetterCount
+		if err := rows.Scan(&c.Reason, &c.Open, &c.Resolved, &c.Replayed, &c.Total); err != nil {
+			log.Error(ctx, "scan failed", ports.Field{Key: "error", Value: err})
+			return failQuery[core.DeadLetterCount](span, "scan failed", err)
+		}
+		counts = append(counts, &c)
+	}
+	if err := rows.Err(); err != nil {
+		log.Error(ctx, "rows loop failed", ports.Field{Key: "error", Value: err})
+		return failQuery[core.DeadLetterCount](span, "rows loop failed", err)
+	}
+	return counts, nil
+}
+
+func deadLetterConds(f core.DeadLetterFilter, args *queryArgs) []string {
+	var conds []string
+	if len(f.Reasons) > 0 {
+		conds = append(conds, "reason = ANY("+args.add(f.Reasons)+"::text[])")
+	}
+	if len(f.OriginalTopics) > 0 {
+		conds = append(conds, "original_topic = ANY("+args.add(f.OriginalTopics)+"::text[])")
+	}
+	if f.ErrorContains != "" {
+		conds = append(conds, "error ILIKE '%' || "+args.add(escapeLike(f.ErrorContains))+" || '%'")
+	}
+	if len(f.Statuses) > 0 {
+		statuses := make([]string, 0, len(f.Statuses))
+		for _, s := range f.Statuses {
+			statuses = append(statuses, string(s))
+		}
+		conds = append(conds, "status = ANY("+args.add(statuses)+"::dead_letter_status[])")
+	}
+	if f.FailedAfter != nil {
+		conds = append(conds, "failed_at >= "+args.add(*f.FailedAfter))
+	}
+	if f.FailedBefore != nil {
+		conds = append(conds, "failed_at < "+args.add(*f.FailedBefore))
+	}
+	return conds
+}
+
+func whereClause(conds []string) string {
+	if len(conds) == 0 {
+		return ""
+	}
+	return "\n\t\tWHERE " + strings.Join(conds, "\n\t\tAND ")
+}
+
+// The search text is matched literally
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
+
+func scanDeadLetter(row pgx.Row) (*core.DeadLetter, error) {
+	var (
+		l       core.DeadLetter
+		headers []byte
+		status  string
+	)
+	err := row.Scan(
+		&l.ID,
+		&l.FailedAt,
+		&l.Reason,
+		&l.Error,
+		&l.OriginalTopic,
+		&l.OriginalKey,
+		&l.OriginalValue,
+		&headers,
+		&l.OriginalPartition,
+		&l.OriginalOffset,
+		&l.Attempts,
+		&l.TraceID,
+		&l.SpanID,
+		&status,
+		&l.Note,
+		&l.ResolvedAt,
+		&l.ReplayedAt,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, core.ErrDeadLetterNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(headers, &l.OriginalHeaders); err != nil {
+		return nil, fmt.Errorf("unmarshal headers: %w", err)
+	}
+	l.Status = core.DeadLetterStatus(status)
+	return &l, nil
+}
@@ -0,0 +1,104 @@
+package orderrepo
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Anacardo89/order_svc_hex/order_svc/internal/core"
+	"github.com/Anacardo89/order_svc_hex/order_svc/pkg/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrderRepo_DeadLetters(t *testing.T) {
+	ctx := context.Background()
+	dbConn, err := testutils.ConnectTestDB(ctx, dsn)
+	require.NoError(t, err)
+	err = testutils.SeedTestDB(ctx, dbConn, seedPath)
+	require.NoError(t, err)
+	store := repo.(*OrderRepo)
+
+	now := time.Now().UTC()
+	letters := []*core.DeadLetter{
+		{FailedAt: now.Add(-3 * time.Minute), Reason: "handler_error", Error: "connection reset", OriginalTopic: "orders.created", OriginalValue: []byte(`{}`), OriginalPartition: 1, OriginalOffset: 10},
+		{FailedAt: now.Add(-2 * time.Minute), Reason: "unmarshal_failed", Error: "bad json", OriginalTopic: "orders.created", OriginalValue: []byte(`{`)},
+		{FailedAt: now.Add(-time.Minute), Reason: "handler_error", Error: "100% full", OriginalTopic: "orders.status_updated", OriginalValue: []byte(`{}`), OriginalHeaders: map[string]string{"message-id": "m-3"}},
+	}
+	for _, l := range letters {
+		require.NoError(t, store.InsertDeadLetter(ctx, l))
+		assert.NotZero(t, l.ID)
+	}
+
+	got, err := store.GetDeadLetter(ctx, letters[2].ID)
+	require.NoError(t, err)
+	assert.Equal(t, "m-3", got.OriginalHeaders["message-id"])
+	assert.Equal(t, core.DeadLetterOpen, got.Status)
+
+	_, err = store.GetDeadLetter(ctx, -1)
+	assert.ErrorIs(t, err, core.ErrDeadLetterNotFound)
+
+	got, err = store.FindDeadLetter(ctx, "orders.created", 1, 10)
+	require.NoError(t, err)
+	assert.Equal(t, letters[0].ID, got.ID)
+	_, err = store.FindDeadLetter(ctx, "orders.created", 1, 11)
+	assert.ErrorIs(t, err, core.ErrDeadLetterNotFound)
+
+	// Newest first, over two pages
+	page, err := store.ListDeadLetters(ctx, core.ListDeadLettersQry{
+		Filter: core.DeadLetterFilter{Reasons: []string{"handler_error"}},
+		Limit:  1,
+	})
+	require.NoError(t, err)
+	require.Len(t, page.DeadLetters, 1)
+	assert.Equal(t, letters[2].ID, page.DeadLetters[0].ID)
+	require.NotNil(t, page.Next)
+	page, err = store.ListDeadLetters(ctx, core.ListDeadLettersQry{
+		Filter:  core.DeadLetterFilter{Reasons: []string{"handler_error"}},
+		Limit:   1,
+		AfterID: page.Next,
+	})
+	require.NoError(t, err)
+	require.Len(t, page.DeadLetters, 1)
+	assert.Equal(t, letters[0].ID, page.DeadLetters[0].ID)
+	assert.Nil(t, page.Next)
+
+	// % is matched literally
+	page, err = store.ListDeadLetters(ctx, core.ListDeadLettersQry{
+		Filter: core.DeadLetterFilter{ErrorContains: "0% F"},
+		Limit:  10,
+	})
+	require.NoError(t, err)
+	require.Len(t, page.DeadLetters, 1)
+	assert.Equal(t, letters[2].ID, page.DeadLetters[0].ID)
+
+	resolved, err := store.ResolveDeadLetter(ctx, letters[1].ID, "producer fixed")
+	require.NoError(t, err)
+	assert.Equal(t, core.DeadLetterResolved, resolved.Status)
+	assert.Equal(t, "producer fixed", resolved.Note)
+	assert.NotNil(t, resolved.ResolvedAt)
+
+	replayed, err := store.MarkDeadLetterReplayed(ctx, letters[0].ID)
+	require.NoError(t, err)
+	assert.Equal(t, core.DeadLetterReplayed, replayed.Status)
+
+	// Settled entries stay as they are
+	_, err = store.MarkDeadLetterReplayed(ctx, letters[1].ID)
+	assert.ErrorIs(t, err, core.ErrDeadLetterNotOpen)
+	_, err = store.ResolveDeadLetter(ctx, letters[0].ID, "again")
+	assert.ErrorIs(t, err, core.ErrDeadLetterNotOpen)
+	_, err = store.ResolveDeadLetter(ctx, -1, "")
+	assert.ErrorIs(t, err, core.ErrDeadLetterNotFound)
+
+	counts, err := store.CountDeadLetters(ctx, core.DeadLetterFilter{})
+	require.NoError(t, err)
+	require.Len(t, counts, 2)
+	assert.Equal(t, core.DeadLetterCount{Reason: "handler_error", Open: 1, Replayed: 1, Total: 2}, *counts[0])
+	assert.Equal(t, core.DeadLetterCount{Reason: "unmarshal_failed", Resolved: 1, Total: 1}, *counts[1])
+
+	deleted, err := store.PurgeDeadLetters(ctx, core.DeadLetterFilter{
+		Statuses: []core.DeadLetterStatus{core.DeadLetterResolved, core.DeadLetterReplayed},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), deleted)
+}
@@ -1,8 +1,15 @@
 package orderrepo
 
 import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgconn"
 	"go.opentelemetry.io/otel/codes"
 	"go.opentelemetry.io/otel/trace"
+
+	"github.com/Anacardo89/order_svc_hex/order_svc/internal/core"
 )
 
 func failExec(span trace.Span, reason string, err error) error {
@@ -22,3 +29,12 @@ func failQuery[T any](span trace.Span, reason string, err error) ([]*T, error) {
 	span.SetStatus(codes.Error, reason)
 	return nil, err
 }
+
+// Data exceptions and constraint violations fail the same way on every attempt
+func classify(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && (strings.HasPrefix(pgErr.Code, "22") || strings.HasPrefix(pgErr.Code, "23")) {
+		return fmt.Errorf("%w: %w", core.ErrInvalidCommand, err)
+	}
+	return err
+}
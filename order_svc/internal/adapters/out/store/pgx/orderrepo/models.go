@@ -44,3 +44,45 @@ func (o *Order) toCore() *core.Order {
 		UpdatedAt: o.UpdatedAt,
 	}
 }
+
+func statusesToStr(statuses []core.Status) []string {
+	out := make([]string, 0, len(statuses))
+	for _, s := range statuses {
+		out = append(out, string(s))
+	}
+	return out
+}
+
+// Order snapshot stored as the outbox payload
+type outboxOrder struct {
+	ID        uuid.UUID      `json:"id"`
+	Items     map[string]int `json:"items"`
+	Status    string         `json:"status"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+}
+
+func toOutboxOrder(o *Order) outboxOrder {
+	var status string
+	if o.Status != nil {
+		status = *o.Status
+	}
+	return outboxOrder{
+		ID:        o.ID,
+		Items:     o.Items,
+		Status:    status,
+		CreatedAt: o.CreatedAt,
+		UpdatedAt: o.UpdatedAt,
+	}
+}
+
+func (o outboxOrder) toCore() *core.Order {
+	status := core.Status(o.Status)
+	return &core.Order{
+		ID:        o.ID,
+		Items:     o.Items,
+		Status:    &status,
+		CreatedAt: o.CreatedAt,
+		UpdatedAt: o.UpdatedAt,
+	}
+}
@@ -3,23 +3,54 @@ package orderrepo
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
-	"go.opentelemetry.io/otel/codes"
 	"go.opentelemetry.io/otel/trace"
 
 	"github.com/Anacardo89/order_svc_hex/order_svc/internal/adapters/infra/log/loki/logger"
 	"github.com/Anacardo89/order_svc_hex/order_svc/internal/core"
 	"github.com/Anacardo89/order_svc_hex/order_svc/internal/ports"
+	"github.com/Anacardo89/order_svc_hex/order_svc/pkg/ptr"
 )
 
 var (
 	tracer = otel.Tracer("order_svc.postgres")
 )
 
+// Shared by single and batched creates
+const createOrderQuery = `
+	INSERT INTO orders (
+		id,
+		items,
+		status,
+		created_at,
+		updated_at
+	)
+	VALUES (
+		$1,
+		$2,
+		COALESCE($3::order_status, 'pending'::order_status),
+		COALESCE($4::timestamptz, NOW()),
+		COALESCE($4::timestamptz, NOW())
+	)
+	RETURNING
+		status,
+		created_at,
+		updated_at
+;`
+
 func (r *OrderRepo) Create(ctx context.Context, order *core.Order) error {
+	return r.CreateFromMessage(ctx, "", order)
+}
+
+// An empty msgID skips deduplication
+func (r *OrderRepo) CreateFromMessage(ctx context.Context, msgID string, order *core.Order) error {
 	// Observability
 	ctx, span := tracer.Start(ctx, "db.orders.create",
 		trace.WithAttributes(
@@ -32,18 +63,6 @@ func (r *OrderRepo) Create(ctx context.Context, order *core.Order) error {
 	defer span.End()
 
 	// Execution
-	query := `
-		INSERT INTO orders (
-			id,
-			items,
-			status
-		)
-		VALUES (
-			$1, 
-			$2,
-			COALESCE($3::order_status, 'pending'::order_status)
-		)
-	;`
 	dbOrder := fromCore(order)
 	if dbOrder.ID == uuid.Nil {
 		dbOrder.ID = uuid.New()
@@ -53,16 +72,138 @@ func (r *OrderRepo) Create(ctx context.Context, order *core.Order) error {
 		log.Error(ctx, "marshal items failed", ports.Field{Key: "error", Value: err})
 		return failExec(span, "marshal items failed", err)
 	}
-	tag, err := r.pool.Exec(ctx, query, dbOrder.ID, items, dbOrder.Status)
+	tx, err := r.pool.Begin(ctx)
 	if err != nil {
+		log.Error(ctx, "begin tx failed", ports.Field{Key: "error", Value: err})
+		return failExec(span, "begin tx failed", err)
+	}
+	defer tx.Rollback(ctx)
+	if err := markProcessed(ctx, tx, msgID); err != nil {
+		return r.failMark(ctx, span, msgID, err)
+	}
+	// When the command happened, not when it got here
+	var occurredAt *time.Time
+	if !order.CreatedAt.IsZero() {
+		occurredAt = &order.CreatedAt
+	}
+	var status string
+	if err := tx.QueryRow(ctx, createOrderQuery, dbOrder.ID, items, dbOrder.Status, occurredAt).Scan(
+		&status,
+		&dbOrder.CreatedAt,
+		&dbOrder.UpdatedAt,
+	); err != nil {
 		log.Error(ctx, "query failed", ports.Field{Key: "error", Value: err})
-		return failExec(span, "query failed", err)
+		return failExec(span, "query failed", classify(err))
+	}
+	dbOrder.Status = &status
+	if err := insertOutbox(ctx, tx, core.EventOrderCreated, dbOrder); err != nil {
+		log.Error(ctx, "outbox insert failed", ports.Field{Key: "error", Value: err})
+		return failExec(span, "outbox insert failed", err)
+	}
+	if err := tx.Commit(ctx); err != nil {
+		log.Error(ctx, "commit failed", ports.Field{Key: "error", Value: err})
+		return failExec(span, "commit failed", err)
 	}
-	span.SetAttributes(attribute.Int64("db.rows_affected", tag.RowsAffected()))
 	log.Info(ctx, "order created", ports.Field{Key: "order_id", Value: dbOrder.ID})
 	return nil
 }
 
+// Two round trips whatever the size: the orders, then their outbox events.
+// Any error rolls back the whole batch, callers retry the messages one by one
+func (r *OrderRepo) CreateBatchFromMessages(ctx context.Context, msgs []ports.OrderMessage) ([]int, error) {
+	// Observability
+	ctx, span := tracer.Start(ctx, "db.orders.create_batch",
+		trace.WithAttributes(
+			attribute.String("db.system", "postgresql"),
+			attribute.String("db.operation", "INSERT"),
+			attribute.String("db.sql.table", "orders"),
+			attribute.Int("db.batch_size", len(msgs)),
+		),
+	)
+	log := logger.BaseLogger
+	defer span.End()
+
+	// Execution
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		log.Error(ctx, "begin tx failed", ports.Field{Key: "error", Value: err})
+		return nil, failExec(span, "begin tx failed", err)
+	}
+	defer tx.Rollback(ctx)
+	msgIDs := make([]string, 0, len(msgs))
+	for _, m := range msgs {
+		if m.MsgID != "" {
+			msgIDs = append(msgIDs, m.MsgID)
+		}
+	}
+	fresh, err := markProcessedBatch(ctx, tx, msgIDs)
+	if err != nil {
+		log.Error(ctx, "mark processed failed", ports.Field{Key: "error", Value: err})
+		return nil, failExec(span, "mark processed failed", err)
+	}
+	var duplicates []int
+	created := make([]*Order, 0, len(msgs))
+	orderBatch := &pgx.Batch{}
+	for i, m := range msgs {
+		if m.MsgID != "" {
+			// Taken out once used, so a message twice in the batch is a duplicate too
+			if _, ok := fresh[m.MsgID]; !ok {
+				duplicates = append(duplicates, i)
+				continue
+			}
+			delete(fresh, m.MsgID)
+		}
+		dbOrder := fromCore(m.Order)
+		if dbOrder.ID == uuid.Nil {
+			dbOrder.ID = uuid.New()
+		}
+		items, err := json.Marshal(dbOrder.Items)
+		if err != nil {
+			log.Error(ctx, "marshal items failed", ports.Field{Key: "error", Value: err})
+			return nil, failExec(span, "marshal items failed", err)
+		}
+		var occurredAt *time.Time
+		if !m.Order.CreatedAt.IsZero() {
+			occurredAt = &m.Order.CreatedAt
+		}
+		orderBatch.Queue(createOrderQuery, dbOrder.ID, items, dbOrder.Status, occurredAt).QueryRow(func(row pgx.Row) error {
+			var status string
+			if err := row.Scan(&status, &dbOrder.CreatedAt, &dbOrder.UpdatedAt); err != nil {
+				return classify(err)
+			}
+			dbOrder.Status = &status
+			return nil
+		})
+		created = append(created, dbOrder)
+	}
+	span.SetAttributes(attribute.Int("messaging.duplicates", len(duplicates)))
+	if len(created) > 0 {
+		if err := tx.SendBatch(ctx, orderBatch).Close(); err != nil {
+			log.Error(ctx, "query failed", ports.Field{Key: "error", Value: err})
+			return nil, failExec(span, "query failed", err)
+		}
+		outboxBatch := &pgx.Batch{}
+		for _, o := range created {
+			args, err := outboxArgs(ctx, core.EventOrderCreated, o)
+			if err != nil {
+				log.Error(ctx, "outbox insert failed", ports.Field{Key: "error", Value: err})
+				return nil, failExec(span, "outbox insert failed", err)
+			}
+			outboxBatch.Queue(insertOutboxQuery, args...)
+		}
+		if err := tx.SendBatch(ctx, outboxBatch).Close(); err != nil {
+			log.Error(ctx, "outbox insert failed", ports.Field{Key: "error", Value: err})
+			return nil, failExec(span, "outbox insert failed", err)
+		}
+	}
+	if err := tx.Commit(ctx); err != nil {
+		log.Error(ctx, "commit failed", ports.Field{Key: "error", Value: err})
+		return nil, failExec(span, "commit failed", err)
+	}
+	log.Info(ctx, "orders created", ports.Field{Key: "count", Value: len(created)})
+	return duplicates, nil
+}
+
 func (r *OrderRepo) GetByID(ctx context.Context, id uuid.UUID) (*core.Order, error) {
 	// Observability
 	ctx, span := tracer.Start(ctx, "db.orders.get_by_id",
@@ -109,13 +250,15 @@ func (r *OrderRepo) GetByID(ctx context.Context, id uuid.UUID) (*core.Order, err
 	return dbOrder.toCore(), nil
 }
 
-func (r *OrderRepo) ListByStatus(ctx context.Context, status core.Status) ([]*core.Order, error) {
+// Unknown ids are left out, the result is in no particular order
+func (r *OrderRepo) GetByIDs(ctx context.Context, ids []uuid.UUID) ([]*core.Order, error) {
 	// Observability
-	ctx, span := tracer.Start(ctx, "db.orders.list_by_status",
+	ctx, span := tracer.Start(ctx, "db.orders.get_by_ids",
 		trace.WithAttributes(
 			attribute.String("db.system", "postgresql"),
 			attribute.String("db.operation", "SELECT"),
 			attribute.String("db.sql.table", "orders"),
+			attribute.Int("db.ids_requested", len(ids)),
 		),
 	)
 	log := logger.BaseLogger
@@ -130,20 +273,16 @@ func (r *OrderRepo) ListByStatus(ctx context.Context, status core.Status) ([]*co
 			created_at,
 			updated_at
 		FROM orders
-		WHERE status = $1
+		WHERE id = ANY($1::uuid[])
 	;`
-	rows, err := r.pool.Query(ctx, query, status)
+	rows, err := r.pool.Query(ctx, query, ids)
 	if err != nil {
 		log.Error(ctx, "query failed", ports.Field{Key: "error", Value: err})
 		return failQuery[core.Order](span, "query failed", err)
 	}
 	defer rows.Close()
-	var (
-		count  int
-		orders []*core.Order
-	)
+	orders := make([]*core.Order, 0, len(ids))
 	for rows.Next() {
-		count++
 		var dbOrder Order
 		var items []byte
 		var status string
@@ -168,11 +307,95 @@ func (r *OrderRepo) ListByStatus(ctx context.Context, status core.Status) ([]*co
 		log.Error(ctx, "rows loop failed", ports.Field{Key: "error", Value: err})
 		return failQuery[core.Order](span, "rows loop failed", err)
 	}
-	span.SetAttributes(attribute.Int("db.rows_returned", count))
+	span.SetAttributes(attribute.Int("db.rows_returned", len(orders)))
 	return orders, nil
 }
 
+// Calls send for each row as it is scanned, returns the cursor for the next page if there is one
+func (r *OrderRepo) Stream(ctx context.Context, qry core.ListOrdersQry, send func(*core.Order) error) (*core.PageCursor, error) {
+	// Observability
+	ctx, span := tracer.Start(ctx, "db.orders.stream",
+		trace.WithAttributes(
+			attribute.String("db.system", "postgresql"),
+			attribute.String("db.operation", "SELECT"),
+			attribute.String("db.sql.table", "orders"),
+		),
+	)
+	log := logger.BaseLogger
+	defer span.End()
+
+	// Execution
+	query, args := buildListQuery(qry)
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		log.Error(ctx, "query failed", ports.Field{Key: "error", Value: err})
+		return failQueryRow[core.PageCursor](span, "query failed", err)
+	}
+	defer rows.Close()
+	var (
+		count int
+		last  *core.Order
+		next  *core.PageCursor
+	)
+	for rows.Next() {
+		count++
+		// The extra row only tells us there is a next page
+		if count > qry.Limit {
+			next = pageCursor(last, qry.Sort)
+			break
+		}
+		var dbOrder Order
+		var items []byte
+		var status string
+		if err := rows.Scan(
+			&dbOrder.ID,
+			&items,
+			&status,
+			&dbOrder.CreatedAt,
+			&dbOrder.UpdatedAt,
+		); err != nil {
+			log.Error(ctx, "scan failed", ports.Field{Key: "error", Value: err})
+			return failQueryRow[core.PageCursor](span, "scan failed", err)
+		}
+		if err := json.Unmarshal(items, &dbOrder.Items); err != nil {
+			log.Error(ctx, "unmarshal items failed", ports.Field{Key: "error", Value: err})
+			return failQueryRow[core.PageCursor](span, "unmarshal items failed", err)
+		}
+		dbOrder.Status = &status
+		last = dbOrder.toCore()
+		if err := send(last); err != nil {
+			log.Error(ctx, "send failed", ports.Field{Key: "error", Value: err})
+			return failQueryRow[core.PageCursor](span, "send failed", err)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		log.Error(ctx, "rows loop failed", ports.Field{Key: "error", Value: err})
+		return failQueryRow[core.PageCursor](span, "rows loop failed", err)
+	}
+	span.SetAttributes(attribute.Int("db.rows_returned", count))
+	return next, nil
+}
+
+// Collects a whole page, for callers that don't need streaming
+func (r *OrderRepo) List(ctx context.Context, qry core.ListOrdersQry) (*core.OrderPage, error) {
+	page := &core.OrderPage{}
+	next, err := r.Stream(ctx, qry, func(o *core.Order) error {
+		page.Orders = append(page.Orders, o)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	page.Next = next
+	return page, nil
+}
+
 func (r *OrderRepo) UpdateStatus(ctx context.Context, id uuid.UUID, status core.Status) error {
+	return r.UpdateStatusFromMessage(ctx, "", id, status)
+}
+
+// An empty msgID skips deduplication
+func (r *OrderRepo) UpdateStatusFromMessage(ctx context.Context, msgID string, id uuid.UUID, status core.Status) error {
 	// Observability
 	ctx, span := tracer.Start(ctx, "db.orders.update_status",
 		trace.WithAttributes(
@@ -185,20 +408,93 @@ func (r *OrderRepo) UpdateStatus(ctx context.Context, id uuid.UUID, status core.
 	defer span.End()
 
 	// Execution
+	// One statement locks the order, updates it only from an allowed status and reports
+	// what it found, so a missing order and a refused transition are told apart atomically
 	query := `
-		UPDATE orders
-		SET status = $2
-		WHERE id = $1
+		WITH target AS (
+			SELECT
+				id,
+				status
+			FROM orders
+			WHERE id = $1
+			FOR UPDATE
+		), updated AS (
+			UPDATE orders o
+			SET status = $2
+			FROM target t
+			WHERE o.id = t.id
+			AND t.status = ANY($3::order_status[])
+			RETURNING
+				o.items,
+				o.created_at,
+				o.updated_at
+		)
+		SELECT
+			t.status,
+			u.items,
+			u.created_at,
+			u.updated_at
+		FROM target t
+		LEFT JOIN updated u ON true
 	;`
-	tag, err := r.pool.Exec(ctx, query, id, status)
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		log.Error(ctx, "begin tx failed", ports.Field{Key: "error", Value: err})
+		return failExec(span, "begin tx failed", err)
+	}
+	// Rolling back also forgets msgID, so a refused command or one for an order that
+	// does not exist yet is not recorded and its redelivery still applies
+	defer tx.Rollback(ctx)
+	if err := markProcessed(ctx, tx, msgID); err != nil {
+		return r.failMark(ctx, span, msgID, err)
+	}
+	var (
+		dbOrder   = Order{ID: id, Status: ptr.Ptr(string(status))}
+		current   string
+		items     []byte
+		createdAt *time.Time
+		updatedAt *time.Time
+	)
+	err = tx.QueryRow(ctx, query, id, status, statusesToStr(core.SourceStatuses(status))).Scan(
+		&current,
+		&items,
+		&createdAt,
+		&updatedAt,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		span.SetAttributes(attribute.Int64("db.rows_affected", 0))
+		err = fmt.Errorf("order %s: %w", id, core.ErrOrderNotFound)
+		log.Warn(ctx, "order not found for status update", ports.Field{Key: "order_id", Value: id})
+		return failExec(span, "order not found", err)
+	}
 	if err != nil {
 		log.Error(ctx, "query failed", ports.Field{Key: "error", Value: err})
-		return failExec(span, "query failed", err)
+		return failExec(span, "query failed", classify(err))
+	}
+	if updatedAt == nil {
+		span.SetAttributes(attribute.Int64("db.rows_affected", 0))
+		err = &core.TransitionError{
+			OrderID: id,
+			From:    core.Status(current),
+			To:      status,
+		}
+		log.Warn(ctx, "invalid status transition", ports.Field{Key: "error", Value: err})
+		return failExec(span, "invalid status transition", err)
+	}
+	dbOrder.CreatedAt = *createdAt
+	dbOrder.UpdatedAt = *updatedAt
+	span.SetAttributes(attribute.Int64("db.rows_affected", 1))
+	if err := json.Unmarshal(items, &dbOrder.Items); err != nil {
+		log.Error(ctx, "unmarshal items failed", ports.Field{Key: "error", Value: err})
+		return failExec(span, "unmarshal items failed", err)
+	}
+	if err := insertOutbox(ctx, tx, core.EventOrderStatusChanged, &dbOrder); err != nil {
+		log.Error(ctx, "outbox insert failed", ports.Field{Key: "error", Value: err})
+		return failExec(span, "outbox insert failed", err)
 	}
-	affected := tag.RowsAffected()
-	span.SetAttributes(attribute.Int64("db.rows_affected", affected))
-	if affected == 0 {
-		span.SetStatus(codes.Error, "no rows updated")
+	if err := tx.Commit(ctx); err != nil {
+		log.Error(ctx, "commit failed", ports.Field{Key: "error", Value: err})
+		return failExec(span, "commit failed", err)
 	}
 	log.Info(ctx, "order status updated", ports.Field{Key: "order_id", Value: id}, ports.Field{Key: "updated_to", Value: string(status)})
 	return nil
@@ -2,7 +2,9 @@ package orderrepo
 
 import (
 	"context"
+	"errors"
 	"testing"
+	"time"
 
 	"github.com/Anacardo89/order_svc_hex/order_svc/internal/core"
 	"github.com/Anacardo89/order_svc_hex/order_svc/pkg/ptr"
@@ -40,6 +42,15 @@ func TestOrderRepo_Create(t *testing.T) {
 				Status: ptr.Ptr(core.StatusConfirmed),
 			},
 		},
+		{
+			name: "create order with occurred at",
+			order: &core.Order{
+				ID:        uuid.MustParse("cccccccc-cccc-cccc-cccc-cccccccccccc"),
+				Items:     map[string]int{"sku_4": 1},
+				Status:    ptr.Ptr(core.StatusPending),
+				CreatedAt: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -55,6 +66,9 @@ func TestOrderRepo_Create(t *testing.T) {
 			assert.Equal(t, *tt.order.Status, core.Status(ptr.Val(savedOrder.Status)))
 			assert.False(t, savedOrder.CreatedAt.IsZero(), "CreatedAt should be set")
 			assert.False(t, savedOrder.UpdatedAt.IsZero(), "UpdatedAt should be set")
+			if !tt.order.CreatedAt.IsZero() {
+				assert.True(t, tt.order.CreatedAt.Equal(savedOrder.CreatedAt))
+			}
 		})
 	}
 }
@@ -99,6 +113,15 @@ func TestOrderRepo_GetByID(t *testing.T) {
 				Status: ptr.Ptr(core.StatusFailed),
 			},
 		},
+		{
+			name: "get shipped order",
+			id:   uuid.MustParse("55555555-5555-5555-5555-555555555555"),
+			expected: &core.Order{
+				ID:     uuid.MustParse("55555555-5555-5555-5555-555555555555"),
+				Items:  map[string]int{"sku_7": 2},
+				Status: ptr.Ptr(core.StatusShipped),
+			},
+		},
 		{
 			name:        "order not found",
 			id:          uuid.MustParse("aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaaa"),
@@ -129,7 +152,63 @@ func TestOrderRepo_GetByID(t *testing.T) {
 	}
 }
 
-func TestOrderRepo_ListByStatus(t *testing.T) {
+func TestOrderRepo_GetByIDs(t *testing.T) {
+	ctx := context.Background()
+	dbConn, err := testutils.ConnectTestDB(ctx, dsn)
+	require.NoError(t, err)
+	err = testutils.SeedTestDB(ctx, dbConn, seedPath)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		ids      []uuid.UUID
+		expected []uuid.UUID
+	}{
+		{
+			name: "all found",
+			ids: []uuid.UUID{
+				uuid.MustParse("11111111-1111-1111-1111-111111111111"),
+				uuid.MustParse("22222222-2222-2222-2222-222222222222"),
+			},
+			expected: []uuid.UUID{
+				uuid.MustParse("11111111-1111-1111-1111-111111111111"),
+				uuid.MustParse("22222222-2222-2222-2222-222222222222"),
+			},
+		},
+		{
+			name: "unknown ids are left out",
+			ids: []uuid.UUID{
+				uuid.MustParse("33333333-3333-3333-3333-333333333333"),
+				uuid.MustParse("aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaaa"),
+			},
+			expected: []uuid.UUID{
+				uuid.MustParse("33333333-3333-3333-3333-333333333333"),
+			},
+		},
+		{
+			name:     "none found",
+			ids:      []uuid.UUID{uuid.MustParse("aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaaa")},
+			expected: []uuid.UUID{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			orders, err := repo.GetByIDs(ctx, tt.ids)
+			require.NoError(t, err)
+
+			got := make([]uuid.UUID, 0, len(orders))
+			for _, o := range orders {
+				got = append(got, o.ID)
+				assert.NotNil(t, o.Status)
+				assert.NotEmpty(t, o.Items)
+			}
+			assert.ElementsMatch(t, tt.expected, got)
+		})
+	}
+}
+
+func TestOrderRepo_List(t *testing.T) {
 	ctx := context.Background()
 	dbConn, err := testutils.ConnectTestDB(ctx, dsn)
 	require.NoError(t, err)
@@ -175,6 +254,39 @@ func TestOrderRepo_ListByStatus(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:   "cancelled orders",
+			status: core.StatusCancelled,
+			expected: []*core.Order{
+				{
+					ID:     uuid.MustParse("44444444-4444-4444-4444-444444444444"),
+					Items:  map[string]int{"sku_6": 1},
+					Status: ptr.Ptr(core.StatusCancelled),
+				},
+			},
+		},
+		{
+			name:   "delivered orders",
+			status: core.StatusDelivered,
+			expected: []*core.Order{
+				{
+					ID:     uuid.MustParse("66666666-6666-6666-6666-666666666666"),
+					Items:  map[string]int{"sku_8": 4},
+					Status: ptr.Ptr(core.StatusDelivered),
+				},
+			},
+		},
+		{
+			name:   "refunded orders",
+			status: core.StatusRefunded,
+			expected: []*core.Order{
+				{
+					ID:     uuid.MustParse("77777777-7777-7777-7777-777777777777"),
+					Items:  map[string]int{"sku_9": 1, "sku_1": 1},
+					Status: ptr.Ptr(core.StatusRefunded),
+				},
+			},
+		},
 		{
 			name:        "invalid status",
 			status:      "archived",
@@ -185,15 +297,21 @@ func TestOrderRepo_ListByStatus(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			orders, err := repo.ListByStatus(ctx, tt.status)
+			qry := core.ListOrdersQry{
+				Filter: core.OrderFilter{Statuses: []core.Status{tt.status}},
+				Limit:  10,
+			}
+			page, err := repo.List(ctx, qry)
 			if tt.expectError {
 				require.Error(t, err)
-				assert.Nil(t, orders)
+				assert.Nil(t, page)
 				return
 			} else {
 				require.NoError(t, err)
-				require.Len(t, orders, len(tt.expected))
+				require.Len(t, page.Orders, len(tt.expected))
+				assert.Nil(t, page.Next)
 			}
+			orders := page.Orders
 
 			for i, expectedOrder := range tt.expected {
 				got := orders[i]
@@ -207,6 +325,141 @@ func TestOrderRepo_ListByStatus(t *testing.T) {
 	}
 }
 
+func TestOrderRepo_List_Filters(t *testing.T) {
+	ctx := context.Background()
+	dbConn, err := testutils.ConnectTestDB(ctx, dsn)
+	require.NoError(t, err)
+	err = testutils.SeedTestDB(ctx, dbConn, seedPath)
+	require.NoError(t, err)
+
+	ago := func(d time.Duration) *time.Time {
+		return ptr.Ptr(time.Now().Add(-d))
+	}
+
+	tests := []struct {
+		name     string
+		qry      core.ListOrdersQry
+		expected []uuid.UUID
+	}{
+		{
+			name: "multiple statuses",
+			qry: core.ListOrdersQry{
+				Filter: core.OrderFilter{Statuses: []core.Status{core.StatusPending, core.StatusConfirmed}},
+			},
+			expected: []uuid.UUID{uuid.MustParse("11111111-1111-1111-1111-111111111111"), uuid.MustParse("22222222-2222-2222-2222-222222222222")},
+		},
+		{
+			name: "sku containment",
+			qry: core.ListOrdersQry{
+				Filter: core.OrderFilter{SKUs: []string{"sku_1"}},
+			},
+			expected: []uuid.UUID{uuid.MustParse("77777777-7777-7777-7777-777777777777"), uuid.MustParse("11111111-1111-1111-1111-111111111111")},
+		},
+		{
+			name: "sku containment newest first",
+			qry: core.ListOrdersQry{
+				Filter: core.OrderFilter{SKUs: []string{"sku_1"}},
+				Sort:   core.SortCreatedAtDesc,
+			},
+			expected: []uuid.UUID{uuid.MustParse("11111111-1111-1111-1111-111111111111"), uuid.MustParse("77777777-7777-7777-7777-777777777777")},
+		},
+		{
+			name: "created after",
+			qry: core.ListOrdersQry{
+				Filter: core.OrderFilter{CreatedAfter: ago(15 * time.Minute)},
+			},
+			expected: []uuid.UUID{uuid.MustParse("11111111-1111-1111-1111-111111111111"), uuid.MustParse("22222222-2222-2222-2222-222222222222"), uuid.MustParse("33333333-3333-3333-3333-333333333333")},
+		},
+		{
+			name: "created before",
+			qry: core.ListOrdersQry{
+				Filter: core.OrderFilter{CreatedBefore: ago(35 * time.Minute)},
+			},
+			expected: []uuid.UUID{uuid.MustParse("44444444-4444-4444-4444-444444444444"), uuid.MustParse("55555555-5555-5555-5555-555555555555")},
+		},
+		{
+			name: "updated since, most recently updated first",
+			qry: core.ListOrdersQry{
+				Filter: core.OrderFilter{UpdatedSince: ago(6 * time.Minute)},
+				Sort:   core.SortUpdatedAtDesc,
+			},
+			expected: []uuid.UUID{uuid.MustParse("33333333-3333-3333-3333-333333333333"), uuid.MustParse("22222222-2222-2222-2222-222222222222")},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.qry.Limit = 10
+			page, err := repo.List(ctx, tt.qry)
+			require.NoError(t, err)
+			got := make([]uuid.UUID, 0, len(page.Orders))
+			for _, o := range page.Orders {
+				got = append(got, o.ID)
+			}
+			assert.Equal(t, tt.expected, got)
+		})
+	}
+}
+
+func TestOrderRepo_List_Pagination(t *testing.T) {
+	ctx := context.Background()
+	dbConn, err := testutils.ConnectTestDB(ctx, dsn)
+	require.NoError(t, err)
+	err = testutils.SeedTestDB(ctx, dbConn, seedPath)
+	require.NoError(t, err)
+
+	created := []uuid.UUID{
+		uuid.MustParse("88888888-8888-8888-8888-888888888888"),
+		uuid.MustParse("99999999-9999-9999-9999-999999999999"),
+	}
+	for _, id := range created {
+		err := repo.Create(ctx, &core.Order{
+			ID:     id,
+			Items:  map[string]int{"sku_1": 1},
+			Status: ptr.Ptr(core.StatusPending),
+		})
+		require.NoError(t, err)
+	}
+
+	// Seeded pending order is the oldest, created ones follow in insert order
+	expected := append([]uuid.UUID{uuid.MustParse("11111111-1111-1111-1111-111111111111")}, created...)
+	var (
+		got   []uuid.UUID
+		after *core.PageCursor
+	)
+	for range expected {
+		page, err := repo.List(ctx, core.ListOrdersQry{
+			Filter: core.OrderFilter{Statuses: []core.Status{core.StatusPending}},
+			Limit:  1,
+			After:  after,
+		})
+		require.NoError(t, err)
+		require.Len(t, page.Orders, 1)
+		got = append(got, page.Orders[0].ID)
+		after = page.Next
+	}
+	assert.Equal(t, expected, got)
+	assert.Nil(t, after)
+}
+
+func TestOrderRepo_Stream_SendError(t *testing.T) {
+	ctx := context.Background()
+	dbConn, err := testutils.ConnectTestDB(ctx, dsn)
+	require.NoError(t, err)
+	err = testutils.SeedTestDB(ctx, dbConn, seedPath)
+	require.NoError(t, err)
+
+	sendErr := errors.New("client gone")
+	var sent int
+	next, err := repo.Stream(ctx, core.ListOrdersQry{Limit: 10}, func(o *core.Order) error {
+		sent++
+		return sendErr
+	})
+	require.ErrorIs(t, err, sendErr)
+	assert.Nil(t, next)
+	assert.Equal(t, 1, sent)
+}
+
 func TestOrderRepo_UpdateStatus(t *testing.T) {
 	ctx := context.Background()
 	dbConn, err := testutils.ConnectTestDB(ctx, dsn)
@@ -230,6 +483,7 @@ func TestOrderRepo_UpdateStatus(t *testing.T) {
 		id          uuid.UUID
 		newStatus   core.Status
 		expectError bool
+		expectIs    error
 	}{
 		{
 			name:      "update pending → confirmed",
@@ -237,15 +491,47 @@ func TestOrderRepo_UpdateStatus(t *testing.T) {
 			newStatus: core.StatusConfirmed,
 		},
 		{
-			name:      "update confirmed → failed",
+			name:      "update confirmed → shipped",
 			id:        uuid.MustParse("22222222-2222-2222-2222-222222222222"),
-			newStatus: core.StatusFailed,
+			newStatus: core.StatusShipped,
+		},
+		{
+			name:      "update shipped → delivered",
+			id:        uuid.MustParse("55555555-5555-5555-5555-555555555555"),
+			newStatus: core.StatusDelivered,
+		},
+		{
+			name:      "update delivered → refunded",
+			id:        uuid.MustParse("66666666-6666-6666-6666-666666666666"),
+			newStatus: core.StatusRefunded,
+		},
+		{
+			name:        "update cancelled → confirmed",
+			id:          uuid.MustParse("44444444-4444-4444-4444-444444444444"),
+			newStatus:   core.StatusConfirmed,
+			expectError: true,
+			expectIs:    core.ErrInvalidTransition,
+		},
+		{
+			name:        "update confirmed → failed",
+			id:          uuid.MustParse("22222222-2222-2222-2222-222222222222"),
+			newStatus:   core.StatusFailed,
+			expectError: true,
+			expectIs:    core.ErrInvalidTransition,
+		},
+		{
+			name:        "update failed → pending",
+			id:          uuid.MustParse("33333333-3333-3333-3333-333333333333"),
+			newStatus:   core.StatusPending,
+			expectError: true,
+			expectIs:    core.ErrInvalidTransition,
 		},
 		{
 			name:        "non-existent order",
 			id:          uuid.MustParse("aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaaa"),
-			newStatus:   core.StatusPending,
-			expectError: false,
+			newStatus:   core.StatusConfirmed,
+			expectError: true,
+			expectIs:    core.ErrOrderNotFound,
 		},
 		{
 			name:        "invalid status enum",
@@ -260,6 +546,9 @@ func TestOrderRepo_UpdateStatus(t *testing.T) {
 			err := repo.UpdateStatus(ctx, tt.id, tt.newStatus)
 			if tt.expectError {
 				require.Error(t, err)
+				if tt.expectIs != nil {
+					assert.ErrorIs(t, err, tt.expectIs)
+				}
 				return
 			} else {
 				require.NoError(t, err)
@@ -273,13 +562,8 @@ func TestOrderRepo_UpdateStatus(t *testing.T) {
 				&updated.CreatedAt,
 				&updated.UpdatedAt,
 			)
-			if tt.name == "non-existent order" {
-				require.Error(t, err)
-				return
-			} else {
-				require.NoError(t, err)
-				assert.Equal(t, string(tt.newStatus), ptr.Val(updated.Status))
-			}
+			require.NoError(t, err)
+			assert.Equal(t, string(tt.newStatus), ptr.Val(updated.Status))
 		})
 	}
 }
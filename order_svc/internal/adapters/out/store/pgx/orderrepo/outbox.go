@@ -0,0 +1,235 @@
+package orderrepo
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/Anacardo89/order_svc_hex/order_svc/internal/adapters/infra/log/loki/logger"
+	"github.com/Anacardo89/order_svc_hex/order_svc/internal/core"
+	"github.com/Anacardo89/order_svc_hex/order_svc/internal/ports"
+)
+
+const insertOutboxQuery = `
+	INSERT INTO outbox (
+		aggregate_id,
+		event_type,
+		payload,
+		headers
+	)
+	VALUES (
+		$1,
+		$2,
+		$3,
+		$4
+	)
+;`
+
+// Runs inside the caller's transaction so the event exists only if the change does
+func insertOutbox(ctx context.Context, tx pgx.Tx, eventType core.EventType, o *Order) error {
+	args, err := outboxArgs(ctx, eventType, o)
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec(ctx, insertOutboxQuery, args...)
+	return err
+}
+
+func outboxArgs(ctx context.Context, eventType core.EventType, o *Order) ([]any, error) {
+	payload, err := json.Marshal(toOutboxOrder(o))
+	if err != nil {
+		return nil, err
+	}
+	carrier := propagation.MapCarrier{}
+	otel.GetTextMapPropagator().Inject(ctx, carrier)
+	headers, err := json.Marshal(carrier)
+	if err != nil {
+		return nil, err
+	}
+	return []any{o.ID, string(eventType), payload, headers}, nil
+}
+
+// Longest one relay run may publish for before another instance can take over
+const outboxLeaseTTL = 30 * time.Second
+
+// Only one instance relays at a time, holding a lease row while it publishes,
+// otherwise two relays could publish events of the same order out of order.
+// No transaction stays open while publishing, rows are marked in a short one after,
+// so a crash in between publishes them again
+func (r *OrderRepo) RelayOutbox(ctx context.Context, limit int, publish func(context.Context, *core.OutboxEvent) error) (int, error) {
+	// Observability
+	ctx, span := tracer.Start(ctx, "db.outbox.relay",
+		trace.WithAttributes(
+			attribute.String("db.system", "postgresql"),
+			attribute.String("db.operation", "SELECT"),
+			attribute.String("db.sql.table", "outbox"),
+		),
+	)
+	log := logger.BaseLogger
+	defer span.End()
+
+	// Execution
+	leaseQuery := `
+		INSERT INTO outbox_lease (
+			name,
+			holder,
+			expires_at
+		)
+		VALUES (
+			'relay',
+			$1,
+			NOW() + make_interval(secs => $2)
+		)
+		ON CONFLICT (name) DO UPDATE
+		SET
+			holder = EXCLUDED.holder,
+			expires_at = EXCLUDED.expires_at
+		WHERE outbox_lease.expires_at < NOW()
+	;`
+	selectQuery := `
+		SELECT
+			id,
+			event_type,
+			payload,
+			headers,
+			created_at
+		FROM outbox
+		WHERE published_at IS NULL
+		ORDER BY id
+		LIMIT $1
+	;`
+	markQuery := `
+		UPDATE outbox
+		SET published_at = NOW()
+		WHERE id = ANY($1::bigint[])
+	;`
+	releaseQuery := `
+		DELETE FROM outbox_lease
+		WHERE name = 'relay'
+		AND holder = $1
+	;`
+	holder := uuid.NewString()
+	tag, err := r.pool.Exec(ctx, leaseQuery, holder, outboxLeaseTTL.Seconds())
+	if err != nil {
+		log.Error(ctx, "lease failed", ports.Field{Key: "error", Value: err})
+		return 0, failExec(span, "lease failed", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return 0, nil
+	}
+	events, err := r.scanOutbox(ctx, selectQuery, limit)
+	if err != nil {
+		log.Error(ctx, "query failed", ports.Field{Key: "error", Value: err})
+		r.releaseOutboxLease(ctx, releaseQuery, holder)
+		return 0, failExec(span, "query failed", err)
+	}
+	// Stops before the lease runs out and another instance starts publishing
+	pubCtx, cancel := context.WithTimeout(ctx, outboxLeaseTTL)
+	defer cancel()
+	published := make([]int64, 0, len(events))
+	var pubErr error
+	for _, e := range events {
+		if pubErr = publish(pubCtx, e); pubErr != nil {
+			break
+		}
+		published = append(published, e.ID)
+	}
+	span.SetAttributes(attribute.Int("outbox.published", len(published)))
+	if len(published) > 0 {
+		tx, err := r.pool.Begin(ctx)
+		if err != nil {
+			log.Error(ctx, "begin tx failed", ports.Field{Key: "error", Value: err})
+			return 0, failExec(span, "begin tx failed", err)
+		}
+		defer tx.Rollback(ctx)
+		if _, err := tx.Exec(ctx, markQuery, published); err != nil {
+			log.Error(ctx, "mark published failed", ports.Field{Key: "error", Value: err})
+			return 0, failExec(span, "mark published failed", err)
+		}
+		if _, err := tx.Exec(ctx, releaseQuery, holder); err != nil {
+			log.Error(ctx, "release lease failed", ports.Field{Key: "error", Value: err})
+			return 0, failExec(span, "release lease failed", err)
+		}
+		if err := tx.Commit(ctx); err != nil {
+			log.Error(ctx, "commit failed", ports.Field{Key: "error", Value: err})
+			return 0, failExec(span, "commit failed", err)
+		}
+	} else {
+		r.releaseOutboxLease(ctx, releaseQuery, holder)
+	}
+	if pubErr != nil {
+		return len(published), failExec(span, "publish failed", pubErr)
+	}
+	return len(published), nil
+}
+
+// A lease that fails to release just runs out
+func (r *OrderRepo) releaseOutboxLease(ctx context.Context, query, holder string) {
+	if _, err := r.pool.Exec(ctx, query, holder); err != nil {
+		logger.BaseLogger.Warn(ctx, "release lease failed", ports.Field{Key: "error", Value: err})
+	}
+}
+
+func (r *OrderRepo) scanOutbox(ctx context.Context, query string, limit int) ([]*core.OutboxEvent, error) {
+	rows, err := r.pool.Query(ctx, query, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var events []*core.OutboxEvent
+	for rows.Next() {
+		var (
+			e         core.OutboxEvent
+			eventType string
+			payload   []byte
+			headers   []byte
+			order     outboxOrder
+		)
+		if err := rows.Scan(&e.ID, &eventType, &payload, &headers, &e.CreatedAt); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(payload, &order); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(headers, &e.Headers); err != nil {
+			return nil, err
+		}
+		e.Type = core.EventType(eventType)
+		e.Order = order.toCore()
+		events = append(events, &e)
+	}
+	return events, rows.Err()
+}
+
+func (r *OrderRepo) PurgeOutbox(ctx context.Context, before time.Time) (int64, error) {
+	// Observability
+	ctx, span := tracer.Start(ctx, "db.outbox.purge",
+		trace.WithAttributes(
+			attribute.String("db.system", "postgresql"),
+			attribute.String("db.operation", "DELETE"),
+			attribute.String("db.sql.table", "outbox"),
+		),
+	)
+	log := logger.BaseLogger
+	defer span.End()
+
+	// Execution
+	query := `
+		DELETE FROM outbox
+		WHERE published_at < $1
+	;`
+	tag, err := r.pool.Exec(ctx, query, before)
+	if err != nil {
+		log.Error(ctx, "query failed", ports.Field{Key: "error", Value: err})
+		return 0, failExec(span, "query failed", err)
+	}
+	span.SetAttributes(attribute.Int64("db.rows_affected", tag.RowsAffected()))
+	return tag.RowsAffected(), nil
+}
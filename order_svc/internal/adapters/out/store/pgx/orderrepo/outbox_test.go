@@ -0,0 +1,79 @@
+package orderrepo
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Anacardo89/order_svc_hex/order_svc/internal/core"
+	"github.com/Anacardo89/order_svc_hex/order_svc/pkg/ptr"
+	"github.com/Anacardo89/order_svc_hex/order_svc/pkg/testutils"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrderRepo_Outbox(t *testing.T) {
+	ctx := context.Background()
+	dbConn, err := testutils.ConnectTestDB(ctx, dsn)
+	require.NoError(t, err)
+	err = testutils.SeedTestDB(ctx, dbConn, seedPath)
+	require.NoError(t, err)
+	outbox := repo.(*OrderRepo)
+
+	id := uuid.MustParse("cccccccc-cccc-cccc-cccc-cccccccccccc")
+	require.NoError(t, repo.Create(ctx, &core.Order{
+		ID:     id,
+		Items:  map[string]int{"sku_1": 1},
+		Status: ptr.Ptr(core.StatusPending),
+	}))
+	require.NoError(t, repo.UpdateStatus(ctx, id, core.StatusConfirmed))
+	// A rejected transition writes no event
+	require.Error(t, repo.UpdateStatus(ctx, id, core.StatusPending))
+
+	// The first publish fails, nothing is marked
+	n, err := outbox.RelayOutbox(ctx, 10, func(ctx context.Context, e *core.OutboxEvent) error {
+		return errors.New("broker down")
+	})
+	require.Error(t, err)
+	assert.Equal(t, 0, n)
+
+	// Another instance is relaying
+	_, err = dbConn.Exec(ctx, `INSERT INTO outbox_lease (name, holder, expires_at) VALUES ('relay', 'other', NOW() + INTERVAL '1 minute')`)
+	require.NoError(t, err)
+	n, err = outbox.RelayOutbox(ctx, 10, func(ctx context.Context, e *core.OutboxEvent) error {
+		t.Fatalf("unexpected event %d", e.ID)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 0, n)
+	_, err = dbConn.Exec(ctx, `UPDATE outbox_lease SET expires_at = NOW() - INTERVAL '1 second'`)
+	require.NoError(t, err)
+
+	var got []*core.OutboxEvent
+	n, err = outbox.RelayOutbox(ctx, 10, func(ctx context.Context, e *core.OutboxEvent) error {
+		got = append(got, e)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+	require.Len(t, got, 2)
+	assert.Equal(t, core.EventOrderCreated, got[0].Type)
+	assert.Equal(t, core.StatusPending, ptr.Val(got[0].Order.Status))
+	assert.Equal(t, core.EventOrderStatusChanged, got[1].Type)
+	assert.Equal(t, core.StatusConfirmed, ptr.Val(got[1].Order.Status))
+	assert.Equal(t, map[string]int{"sku_1": 1}, got[1].Order.Items)
+
+	// Published rows are not handed out again
+	n, err = outbox.RelayOutbox(ctx, 10, func(ctx context.Context, e *core.OutboxEvent) error {
+		t.Fatalf("unexpected event %d", e.ID)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 0, n)
+
+	deleted, err := outbox.PurgeOutbox(ctx, time.Now().Add(time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), deleted)
+}
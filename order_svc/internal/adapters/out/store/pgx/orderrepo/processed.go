@@ -0,0 +1,100 @@
+package orderrepo
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/Anacardo89/order_svc_hex/order_svc/internal/adapters/infra/log/loki/logger"
+	"github.com/Anacardo89/order_svc_hex/order_svc/internal/core"
+	"github.com/Anacardo89/order_svc_hex/order_svc/internal/ports"
+)
+
+// Runs first in the caller's transaction: a concurrent delivery of the same message
+// waits on the primary key and then sees the conflict
+func markProcessed(ctx context.Context, tx pgx.Tx, msgID string) error {
+	if msgID == "" {
+		return nil
+	}
+	query := `
+		INSERT INTO processed_messages (message_id)
+		VALUES ($1)
+		ON CONFLICT (message_id) DO NOTHING
+	;`
+	tag, err := tx.Exec(ctx, query, msgID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return core.ErrDuplicateMessage
+	}
+	return nil
+}
+
+// Batched markProcessed, returns the ids that were not recorded yet
+func markProcessedBatch(ctx context.Context, tx pgx.Tx, msgIDs []string) (map[string]struct{}, error) {
+	fresh := make(map[string]struct{}, len(msgIDs))
+	if len(msgIDs) == 0 {
+		return fresh, nil
+	}
+	query := `
+		INSERT INTO processed_messages (message_id)
+		SELECT unnest($1::text[])
+		ON CONFLICT (message_id) DO NOTHING
+		RETURNING message_id
+	;`
+	rows, err := tx.Query(ctx, query, msgIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var msgID string
+		if err := rows.Scan(&msgID); err != nil {
+			return nil, err
+		}
+		fresh[msgID] = struct{}{}
+	}
+	return fresh, rows.Err()
+}
+
+func (r *OrderRepo) failMark(ctx context.Context, span trace.Span, msgID string, err error) error {
+	log := logger.BaseLogger
+	if errors.Is(err, core.ErrDuplicateMessage) {
+		span.SetAttributes(attribute.Bool("messaging.duplicate", true))
+		log.Info(ctx, "message already processed", ports.Field{Key: "message_id", Value: msgID})
+		return err
+	}
+	log.Error(ctx, "mark processed failed", ports.Field{Key: "error", Value: err})
+	return failExec(span, "mark processed failed", err)
+}
+
+func (r *OrderRepo) PruneProcessed(ctx context.Context, before time.Time) (int64, error) {
+	// Observability
+	ctx, span := tracer.Start(ctx, "db.processed_messages.prune",
+		trace.WithAttributes(
+			attribute.String("db.system", "postgresql"),
+			attribute.String("db.operation", "DELETE"),
+			attribute.String("db.sql.table", "processed_messages"),
+		),
+	)
+	log := logger.BaseLogger
+	defer span.End()
+
+	// Execution
+	query := `
+		DELETE FROM processed_messages
+		WHERE processed_at < $1
+	;`
+	tag, err := r.pool.Exec(ctx, query, before)
+	if err != nil {
+		log.Error(ctx, "query failed", ports.Field{Key: "error", Value: err})
+		return 0, failExec(span, "query failed", err)
+	}
+	span.SetAttributes(attribute.Int64("db.rows_affected", tag.RowsAffected()))
+	return tag.RowsAffected(), nil
+}
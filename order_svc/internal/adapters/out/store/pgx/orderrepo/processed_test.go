@@ -0,0 +1,117 @@
+package orderrepo
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Anacardo89/order_svc_hex/order_svc/internal/core"
+	"github.com/Anacardo89/order_svc_hex/order_svc/internal/ports"
+	"github.com/Anacardo89/order_svc_hex/order_svc/pkg/ptr"
+	"github.com/Anacardo89/order_svc_hex/order_svc/pkg/testutils"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrderRepo_Deduplication(t *testing.T) {
+	ctx := context.Background()
+	dbConn, err := testutils.ConnectTestDB(ctx, dsn)
+	require.NoError(t, err)
+	err = testutils.SeedTestDB(ctx, dbConn, seedPath)
+	require.NoError(t, err)
+
+	// No id on the order, so a second create would insert a second order
+	order := &core.Order{
+		Items:  map[string]int{"sku_dedup": 1},
+		Status: ptr.Ptr(core.StatusPending),
+	}
+	require.NoError(t, repo.CreateFromMessage(ctx, "msg-create", order))
+	err = repo.CreateFromMessage(ctx, "msg-create", order)
+	assert.ErrorIs(t, err, core.ErrDuplicateMessage)
+
+	id := uuid.MustParse("11111111-1111-1111-1111-111111111111")
+	require.NoError(t, repo.UpdateStatusFromMessage(ctx, "msg-update", id, core.StatusConfirmed))
+	// The replay is skipped instead of being rejected as confirmed -> confirmed
+	err = repo.UpdateStatusFromMessage(ctx, "msg-update", id, core.StatusConfirmed)
+	assert.ErrorIs(t, err, core.ErrDuplicateMessage)
+
+	page, err := repo.List(ctx, core.ListOrdersQry{
+		Filter: core.OrderFilter{SKUs: []string{"sku_dedup"}},
+		Limit:  10,
+	})
+	require.NoError(t, err)
+	assert.Len(t, page.Orders, 1)
+
+	pruned, err := repo.(*OrderRepo).PruneProcessed(ctx, time.Now().Add(time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), pruned)
+	require.NoError(t, repo.CreateFromMessage(ctx, "msg-create", order))
+}
+
+func TestOrderRepo_DeduplicationMissingOrder(t *testing.T) {
+	ctx := context.Background()
+	dbConn, err := testutils.ConnectTestDB(ctx, dsn)
+	require.NoError(t, err)
+	err = testutils.SeedTestDB(ctx, dbConn, seedPath)
+	require.NoError(t, err)
+
+	// The update overtakes the create, it must not be recorded as processed
+	id := uuid.MustParse("cdcdcdcd-0000-0000-0000-000000000001")
+	err = repo.UpdateStatusFromMessage(ctx, "msg-early", id, core.StatusConfirmed)
+	assert.ErrorIs(t, err, core.ErrOrderNotFound)
+
+	require.NoError(t, repo.CreateFromMessage(ctx, "msg-late-create", &core.Order{
+		ID:    id,
+		Items: map[string]int{"sku_early": 1},
+	}))
+	// The redelivery is applied instead of being dropped as a duplicate
+	require.NoError(t, repo.UpdateStatusFromMessage(ctx, "msg-early", id, core.StatusConfirmed))
+	order, err := repo.GetByID(ctx, id)
+	require.NoError(t, err)
+	assert.Equal(t, core.StatusConfirmed, ptr.Val(order.Status))
+}
+
+func TestOrderRepo_CreateBatch(t *testing.T) {
+	ctx := context.Background()
+	dbConn, err := testutils.ConnectTestDB(ctx, dsn)
+	require.NoError(t, err)
+	err = testutils.SeedTestDB(ctx, dbConn, seedPath)
+	require.NoError(t, err)
+
+	first := uuid.MustParse("abababab-0000-0000-0000-000000000001")
+	second := uuid.MustParse("abababab-0000-0000-0000-000000000002")
+	msgs := []ports.OrderMessage{
+		{MsgID: "msg-batch-1", Order: &core.Order{ID: first, Items: map[string]int{"sku_batch": 1}}},
+		{MsgID: "msg-batch-2", Order: &core.Order{ID: second, Items: map[string]int{"sku_batch": 2}}},
+		// Redelivered within the same batch
+		{MsgID: "msg-batch-1", Order: &core.Order{ID: first, Items: map[string]int{"sku_batch": 1}}},
+	}
+	duplicates, err := repo.CreateBatchFromMessages(ctx, msgs)
+	require.NoError(t, err)
+	// The first copy is applied, the repeat is skipped
+	assert.Equal(t, []int{2}, duplicates)
+
+	orders, err := repo.GetByIDs(ctx, []uuid.UUID{first, second})
+	require.NoError(t, err)
+	assert.Len(t, orders, 2)
+	for _, o := range orders {
+		assert.Equal(t, core.StatusPending, ptr.Val(o.Status))
+	}
+
+	// Replaying the batch writes nothing
+	duplicates, err = repo.CreateBatchFromMessages(ctx, msgs[:2])
+	require.NoError(t, err)
+	assert.Equal(t, []int{0, 1}, duplicates)
+
+	// One bad record rolls back the others, the fresh message stays unrecorded
+	third := uuid.MustParse("abababab-0000-0000-0000-000000000003")
+	_, err = repo.CreateBatchFromMessages(ctx, []ports.OrderMessage{
+		{MsgID: "msg-batch-3", Order: &core.Order{ID: third, Items: map[string]int{"sku_batch": 3}}},
+		{MsgID: "msg-batch-4", Order: &core.Order{ID: first, Items: map[string]int{"sku_batch": 4}}},
+	})
+	assert.ErrorIs(t, err, core.ErrInvalidCommand)
+	_, err = repo.GetByID(ctx, third)
+	assert.Error(t, err)
+	require.NoError(t, repo.CreateFromMessage(ctx, "msg-batch-3", &core.Order{ID: third, Items: map[string]int{"sku_batch": 3}}))
+}
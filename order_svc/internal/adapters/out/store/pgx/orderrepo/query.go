@@ -0,0 +1,99 @@
+package orderrepo
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Anacardo89/order_svc_hex/order_svc/internal/core"
+)
+
+// The SKUs of an order as a JSON array, must match idx_orders_item_skus to use it
+const itemSKUs = "jsonb_path_query_array(items, '$.keyvalue().key')"
+
+// Collects positional args while the query is built
+type queryArgs []any
+
+func (a *queryArgs) add(v any) string {
+	*a = append(*a, v)
+	return fmt.Sprintf("$%d", len(*a))
+}
+
+func sortColumn(sort core.OrderSort) (column string, desc bool) {
+	switch sort {
+	case core.SortCreatedAtDesc:
+		return "created_at", true
+	case core.SortUpdatedAt:
+		return "updated_at", false
+	case core.SortUpdatedAtDesc:
+		return "updated_at", true
+	default:
+		return "created_at", false
+	}
+}
+
+func pageCursor(last *core.Order, sort core.OrderSort) *core.PageCursor {
+	cursor := &core.PageCursor{
+		SortKey: last.CreatedAt,
+		ID:      last.ID,
+	}
+	if column, _ := sortColumn(sort); column == "updated_at" {
+		cursor.SortKey = last.UpdatedAt
+	}
+	return cursor
+}
+
+// Only adds the conditions that were asked for so the planner can pick the matching index
+func buildListQuery(qry core.ListOrdersQry) (string, []any) {
+	var (
+		args  queryArgs
+		conds []string
+	)
+	f := qry.Filter
+	if len(f.IDs) > 0 {
+		conds = append(conds, "id = ANY("+args.add(f.IDs)+"::uuid[])")
+	}
+	if len(f.Statuses) > 0 {
+		conds = append(conds, "status = ANY("+args.add(statusesToStr(f.Statuses))+"::order_status[])")
+	}
+	if f.CreatedAfter != nil {
+		conds = append(conds, "created_at > "+args.add(*f.CreatedAfter))
+	}
+	if f.CreatedBefore != nil {
+		conds = append(conds, "created_at < "+args.add(*f.CreatedBefore))
+	}
+	if f.UpdatedSince != nil {
+		conds = append(conds, "updated_at >= "+args.add(*f.UpdatedSince))
+	}
+	if len(f.SKUs) > 0 {
+		// Containment on the SKU keys, served by the GIN jsonb_path_ops index on itemSKUs
+		conds = append(conds, itemSKUs+" @> "+args.add(f.SKUs)+"::jsonb")
+	}
+	column, desc := sortColumn(qry.Sort)
+	op, dir := ">", "ASC"
+	if desc {
+		op, dir = "<", "DESC"
+	}
+	if qry.After != nil {
+		conds = append(conds, fmt.Sprintf("(%s, id) %s (%s::timestamptz, %s::uuid)", column, op, args.add(qry.After.SortKey), args.add(qry.After.ID)))
+	}
+
+	var b strings.Builder
+	b.WriteString(`
+		SELECT
+			id,
+			items,
+			status,
+			created_at,
+			updated_at
+		FROM orders`)
+	for i, cond := range conds {
+		if i == 0 {
+			b.WriteString("\n\t\tWHERE " + cond)
+		} else {
+			b.WriteString("\n\t\tAND " + cond)
+		}
+	}
+	// One extra row tells us whether there is a next page
+	fmt.Fprintf(&b, "\n\t\tORDER BY %s %s, id %s\n\t\tLIMIT %s\n\t;", column, dir, dir, args.add(qry.Limit+1))
+	return b.String(), args
+}
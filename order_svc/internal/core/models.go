@@ -6,14 +6,6 @@ import (
 	"github.com/google/uuid"
 )
 
-type Status string
-
-const (
-	StatusPending   Status = "pending"
-	StatusConfirmed Status = "confirmed"
-	StatusFailed    Status = "failed"
-)
-
 type Order struct {
 	ID        uuid.UUID
 	Items     map[string]int
@@ -21,3 +13,47 @@ type Order struct {
 	CreatedAt time.Time
 	UpdatedAt time.Time
 }
+
+type OrderSort string
+
+const (
+	SortCreatedAt     OrderSort = "created_at"
+	SortCreatedAtDesc OrderSort = "-created_at"
+	SortUpdatedAt     OrderSort = "updated_at"
+	SortUpdatedAtDesc OrderSort = "-updated_at"
+)
+
+// Keyset position on the sort column, ties broken by id
+type PageCursor struct {
+	SortKey time.Time
+	ID      uuid.UUID
+}
+
+// Zero values mean no filtering on that field
+type OrderFilter struct {
+	IDs           []uuid.UUID
+	Statuses      []Status
+	CreatedAfter  *time.Time
+	CreatedBefore *time.Time
+	UpdatedSince  *time.Time
+	SKUs          []string
+}
+
+// Queries
+type ListOrdersQry struct {
+	Filter OrderFilter
+	Sort   OrderSort
+	Limit  int
+	After  *PageCursor
+}
+
+type OrderPage struct {
+	Orders []*Order
+	Next   *PageCursor
+}
+
+// Orders follow the requested order, Missing holds ids with no order
+type OrderBatch struct {
+	Orders  []*Order
+	Missing []uuid.UUID
+}
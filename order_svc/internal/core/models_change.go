@@ -0,0 +1,42 @@
+package core
+
+import (
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Subscriber couldn't keep up with the feed and has to resume
+var ErrWatchLagged = errors.New("watcher fell behind")
+
+type ChangeType string
+
+const (
+	ChangeCreated ChangeType = "created"
+	ChangeUpdated ChangeType = "updated"
+)
+
+type OrderChange struct {
+	Type      ChangeType
+	Order     *Order
+	ChangedAt time.Time
+}
+
+// Queries
+type WatchOrdersQry struct {
+	IDs      []uuid.UUID
+	Statuses []Status
+	Since    *time.Time
+}
+
+func (q WatchOrdersQry) Matches(o *Order) bool {
+	if len(q.IDs) > 0 && !slices.Contains(q.IDs, o.ID) {
+		return false
+	}
+	if len(q.Statuses) > 0 && (o.Status == nil || !slices.Contains(q.Statuses, *o.Status)) {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,31 @@
+package core
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// The message was already applied, redelivery must not apply it again
+var ErrDuplicateMessage = errors.New("message already processed")
+
+// The command can never be applied as sent, retrying it is pointless
+var ErrInvalidCommand = errors.New("invalid command")
+
+type CommandOutcome string
+
+const (
+	CommandApplied  CommandOutcome = "applied"
+	CommandRejected CommandOutcome = "rejected"
+)
+
+type CommandResult struct {
+	CommandID   uuid.UUID
+	ReplyTo     string
+	OrderID     uuid.UUID
+	Outcome     CommandOutcome
+	Reason      string
+	Error       string
+	ProcessedAt time.Time
+}
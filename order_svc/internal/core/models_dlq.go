@@ -0,0 +1,109 @@
+package core
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"time"
+)
+
+var (
+	ErrDeadLetterNotFound = errors.New("dead letter not found")
+	// Resolving or replaying only applies to open entries
+	ErrDeadLetterNotOpen = errors.New("dead letter is not open")
+)
+
+type DeadLetterStatus string
+
+const (
+	DeadLetterOpen     DeadLetterStatus = "open"
+	DeadLetterResolved DeadLetterStatus = "resolved"
+	DeadLetterReplayed DeadLetterStatus = "replayed"
+)
+
+type DeadLetter struct {
+	// Set once indexed
+	ID int64
+	// Position in the DLQ topic, set when read back from it
+	Partition int32
+	Offset    int64
+
+	FailedAt          time.Time
+	Reason            string
+	Error             string
+	OriginalTopic     string
+	OriginalKey       []byte
+	OriginalValue     []byte
+	OriginalHeaders   map[string]string
+	OriginalPartition int32
+	OriginalOffset    int64
+	Attempts          int
+	TraceID           string
+	SpanID            string
+
+	Status     DeadLetterStatus
+	Note       string
+	ResolvedAt *time.Time
+	ReplayedAt *time.Time
+}
+
+// Empty fields match everything
+type DeadLetterFilter struct {
+	Reasons        []string
+	OriginalTopics []string
+	ErrorContains  string
+	Statuses       []DeadLetterStatus
+	FailedAfter    *time.Time
+	FailedBefore   *time.Time
+}
+
+func (f DeadLetterFilter) Matches(l *DeadLetter) bool {
+	if len(f.Reasons) > 0 && !slices.Contains(f.Reasons, l.Reason) {
+		return false
+	}
+	if len(f.OriginalTopics) > 0 && !slices.Contains(f.OriginalTopics, l.OriginalTopic) {
+		return false
+	}
+	if f.ErrorContains != "" && !strings.Contains(strings.ToLower(l.Error), strings.ToLower(f.ErrorContains)) {
+		return false
+	}
+	if len(f.Statuses) > 0 && !slices.Contains(f.Statuses, l.Status) {
+		return false
+	}
+	if f.FailedAfter != nil && l.FailedAt.Before(*f.FailedAfter) {
+		return false
+	}
+	if f.FailedBefore != nil && !l.FailedAt.Before(*f.FailedBefore) {
+		return false
+	}
+	return true
+}
+
+// Bounds are inclusive, unset ones leave that side open
+type DeadLetterRange struct {
+	FromOffset *int64
+	ToOffset   *int64
+	Since      *time.Time
+	Until      *time.Time
+}
+
+// Queries
+// Newest first, AfterID continues below the last id of the previous page
+type ListDeadLettersQry struct {
+	Filter  DeadLetterFilter
+	Limit   int
+	AfterID *int64
+}
+
+type DeadLetterPage struct {
+	DeadLetters []*DeadLetter
+	Next        *int64
+}
+
+type DeadLetterCount struct {
+	Reason   string
+	Open     int64
+	Resolved int64
+	Replayed int64
+	Total    int64
+}
@@ -0,0 +1,19 @@
+package core
+
+import "time"
+
+type EventType string
+
+const (
+	EventOrderCreated       EventType = "OrderCreated"
+	EventOrderStatusChanged EventType = "OrderStatusChanged"
+)
+
+// Domain event written in the same transaction as the order change it describes
+type OutboxEvent struct {
+	ID        int64
+	Type      EventType
+	Order     *Order
+	Headers   map[string]string // trace context of the write
+	CreatedAt time.Time
+}
@@ -0,0 +1,75 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+type Status string
+
+const (
+	StatusPending   Status = "pending"
+	StatusConfirmed Status = "confirmed"
+	StatusFailed    Status = "failed"
+	StatusCancelled Status = "cancelled"
+	StatusShipped   Status = "shipped"
+	StatusDelivered Status = "delivered"
+	StatusRefunded  Status = "refunded"
+)
+
+// Allowed transitions, statuses with no entry are terminal
+var statusTransitions = map[Status][]Status{
+	StatusPending:   {StatusConfirmed, StatusFailed, StatusCancelled},
+	StatusConfirmed: {StatusShipped, StatusCancelled},
+	StatusShipped:   {StatusDelivered},
+	StatusDelivered: {StatusRefunded},
+}
+
+func (s Status) CanTransitionTo(next Status) bool {
+	for _, allowed := range statusTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+func (s Status) IsTerminal() bool {
+	return len(statusTransitions[s]) == 0
+}
+
+// Statuses an order must currently be in to move to next
+func SourceStatuses(next Status) []Status {
+	var sources []Status
+	for from, targets := range statusTransitions {
+		for _, to := range targets {
+			if to == next {
+				sources = append(sources, from)
+				break
+			}
+		}
+	}
+	return sources
+}
+
+// Errors
+var (
+	ErrInvalidTransition = errors.New("invalid status transition")
+	ErrOrderNotFound     = errors.New("order not found")
+)
+
+type TransitionError struct {
+	OrderID uuid.UUID
+	From    Status
+	To      Status
+}
+
+func (e *TransitionError) Error() string {
+	return fmt.Sprintf("order %s: %s -> %s: %s", e.OrderID, e.From, e.To, ErrInvalidTransition)
+}
+
+func (e *TransitionError) Unwrap() error {
+	return ErrInvalidTransition
+}
@@ -0,0 +1,11 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/Anacardo89/order_svc_hex/order_svc/internal/core"
+)
+
+type CommandResultPublisher interface {
+	PublishResult(ctx context.Context, result core.CommandResult) error
+}
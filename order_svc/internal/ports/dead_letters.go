@@ -0,0 +1,42 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/Anacardo89/order_svc_hex/order_svc/internal/core"
+)
+
+type DeadLetterSource interface {
+	// Calls fn for each entry in the range, stops at the first error fn returns.
+	// An entry that can't be decoded comes with its error and a letter holding only its position
+	Read(ctx context.Context, rng core.DeadLetterRange, fn func(*core.DeadLetter, error) error) error
+}
+
+type DeadLetterReplayer interface {
+	// Republishes the original message to the topic it was first published to
+	Replay(ctx context.Context, letter *core.DeadLetter) error
+}
+
+type DeadLetterStore interface {
+	InsertDeadLetter(ctx context.Context, letter *core.DeadLetter) error
+	ListDeadLetters(ctx context.Context, qry core.ListDeadLettersQry) (*core.DeadLetterPage, error)
+	// Returns core.ErrDeadLetterNotFound for unknown ids, as do the updates below
+	GetDeadLetter(ctx context.Context, id int64) (*core.DeadLetter, error)
+	// Looks an entry up by where its message was first consumed
+	FindDeadLetter(ctx context.Context, topic string, partition int32, offset int64) (*core.DeadLetter, error)
+	ResolveDeadLetter(ctx context.Context, id int64, note string) (*core.DeadLetter, error)
+	MarkDeadLetterReplayed(ctx context.Context, id int64) (*core.DeadLetter, error)
+	PurgeDeadLetters(ctx context.Context, filter core.DeadLetterFilter) (int64, error)
+	// One row per reason, most frequent first
+	CountDeadLetters(ctx context.Context, filter core.DeadLetterFilter) ([]*core.DeadLetterCount, error)
+}
+
+type DeadLetterAdmin interface {
+	ListDeadLetters(ctx context.Context, qry core.ListDeadLettersQry) (*core.DeadLetterPage, error)
+	GetDeadLetter(ctx context.Context, id int64) (*core.DeadLetter, error)
+	ResolveDeadLetter(ctx context.Context, id int64, note string) (*core.DeadLetter, error)
+	// Republishes the original message and marks the entry replayed
+	ReplayDeadLetter(ctx context.Context, id int64) (*core.DeadLetter, error)
+	PurgeDeadLetters(ctx context.Context, filter core.DeadLetterFilter) (int64, error)
+	CountDeadLetters(ctx context.Context, filter core.DeadLetterFilter) ([]*core.DeadLetterCount, error)
+}
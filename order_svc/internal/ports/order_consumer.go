@@ -7,6 +7,8 @@ import (
 )
 
 type OrderConsumer interface {
-	OnOrderCreated(ctx context.Context, order core.Order) error
-	OnOrderStatusUpdated(ctx context.Context, order core.Order) error
+	OnOrderCreated(ctx context.Context, msgID string, order core.Order) error
+	// All or nothing, returns the indexes in msgs of messages that were already applied
+	OnOrdersCreated(ctx context.Context, msgs []OrderMessage) ([]int, error)
+	OnOrderStatusUpdated(ctx context.Context, msgID string, order core.Order) error
 }
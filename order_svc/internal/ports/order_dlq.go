@@ -10,10 +10,13 @@ type DLQMessage struct {
 	OriginalTopic string
 	OriginalKey   []byte
 	OriginalValue []byte
-	Partition     int32
-	Offset        int64
-	TraceID       string
-	SpanID        string
+	// Without the retry headers, a replay starts over at the first tier
+	OriginalHeaders map[string]string
+	Partition       int32
+	Offset          int64
+	Attempts        int
+	TraceID         string
+	SpanID          string
 }
 
 type OrderDLQ interface {
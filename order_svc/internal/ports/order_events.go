@@ -0,0 +1,11 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/Anacardo89/order_svc_hex/order_svc/internal/core"
+)
+
+type OrderEventPublisher interface {
+	PublishOrderEvent(ctx context.Context, event *core.OutboxEvent) error
+}
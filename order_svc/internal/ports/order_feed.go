@@ -0,0 +1,12 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/Anacardo89/order_svc_hex/order_svc/internal/core"
+)
+
+type OrderFeed interface {
+	// Changes stop when ctx is done, the channel is closed early if the subscriber falls behind
+	Subscribe(ctx context.Context) <-chan core.OrderChange
+}
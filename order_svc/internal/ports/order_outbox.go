@@ -0,0 +1,16 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/Anacardo89/order_svc_hex/order_svc/internal/core"
+)
+
+type OutboxRepo interface {
+	// Hands up to limit unpublished events to publish, oldest first, and marks the accepted ones published.
+	// Stops at the first failure so an order's events never go out of order
+	RelayOutbox(ctx context.Context, limit int, publish func(context.Context, *core.OutboxEvent) error) (int, error)
+	// Deletes events published before the given time
+	PurgeOutbox(ctx context.Context, before time.Time) (int64, error)
+}
@@ -8,9 +8,26 @@ import (
 	"github.com/Anacardo89/order_svc_hex/order_svc/internal/core"
 )
 
+// An order as carried by a command message
+type OrderMessage struct {
+	MsgID string
+	Order *core.Order
+}
+
 type OrderRepo interface {
 	Create(ctx context.Context, order *core.Order) error
+	// Records msgID with the write and returns core.ErrDuplicateMessage if it was already recorded
+	CreateFromMessage(ctx context.Context, msgID string, order *core.Order) error
+	// Writes all orders in one transaction, skipping messages already recorded and returning their indexes in msgs.
+	// A message repeated in the batch is applied at its first index and skipped at the others
+	CreateBatchFromMessages(ctx context.Context, msgs []OrderMessage) ([]int, error)
 	GetByID(ctx context.Context, id uuid.UUID) (*core.Order, error)
-	ListByStatus(ctx context.Context, status core.Status) ([]*core.Order, error)
+	// Unknown ids are left out of the result
+	GetByIDs(ctx context.Context, ids []uuid.UUID) ([]*core.Order, error)
+	List(ctx context.Context, qry core.ListOrdersQry) (*core.OrderPage, error)
+	// Returns the cursor for the next page, nil on the last one
+	Stream(ctx context.Context, qry core.ListOrdersQry, send func(*core.Order) error) (*core.PageCursor, error)
 	UpdateStatus(ctx context.Context, id uuid.UUID, status core.Status) error
+	// Records msgID with the write and returns core.ErrDuplicateMessage if it was already recorded
+	UpdateStatusFromMessage(ctx context.Context, msgID string, id uuid.UUID, status core.Status) error
 }
@@ -0,0 +1,23 @@
+package ports
+
+import (
+	"context"
+)
+
+type RetryMessage struct {
+	OriginalTopic string
+	Key           []byte
+	Value         []byte
+	// Position in OriginalTopic, kept through every tier for the DLQ entry
+	Partition int32
+	Offset    int64
+	// Carried over so the retry keeps its message ID and reply address
+	Headers map[string]string
+	// Attempts made so far, including the one that just failed
+	Attempt int
+}
+
+type OrderRetry interface {
+	// Schedules another attempt, false means the tiers are used up and the message belongs in the DLQ
+	PublishRetry(ctx context.Context, msg RetryMessage) (bool, error)
+}
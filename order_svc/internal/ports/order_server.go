@@ -3,10 +3,15 @@ package ports
 import (
 	"context"
 
+	"github.com/google/uuid"
+
 	"github.com/Anacardo89/order_svc_hex/order_svc/internal/core"
 )
 
 type OrderServer interface {
 	GetOrderByID(ctx context.Context, id string) (*core.Order, error)
-	ListOrdersByStatus(ctx context.Context, status core.Status) (<-chan *core.Order, error)
+	GetOrdersByIDs(ctx context.Context, ids []uuid.UUID) (*core.OrderBatch, error)
+	ListOrders(ctx context.Context, qry core.ListOrdersQry, send func(*core.Order) error) (*core.PageCursor, error)
+	// Runs until ctx is done, the feed drops the watcher or send fails
+	WatchOrders(ctx context.Context, qry core.WatchOrdersQry, send func(core.OrderChange) error) error
 }
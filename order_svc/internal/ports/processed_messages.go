@@ -0,0 +1,11 @@
+package ports
+
+import (
+	"context"
+	"time"
+)
+
+type ProcessedMessages interface {
+	// Forgets messages processed before the given time, their redelivery is no longer detected
+	PruneProcessed(ctx context.Context, before time.Time) (int64, error)
+}
@@ -0,0 +1,60 @@
+package events
+
+import (
+	"fmt"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/Anacardo89/order_svc_hex/order_svc/proto/orderpb"
+)
+
+const (
+	ContentTypeJSON     = "application/json"
+	ContentTypeProtobuf = "application/x-protobuf"
+)
+
+// Either encoding comes back as the JSON envelope, so upcasting and payload
+// decoding don't depend on how the command travelled
+func DecodeCommand(contentType string, value []byte, fallbackType string) (*Envelope, error) {
+	switch contentType {
+	case "", ContentTypeJSON:
+		return DecodeEnvelope(value, fallbackType)
+	case ContentTypeProtobuf:
+		return decodeProtoEnvelope(value)
+	default:
+		return nil, fmt.Errorf("unsupported content type: %s", contentType)
+	}
+}
+
+func decodeProtoEnvelope(value []byte) (*Envelope, error) {
+	var pbEnv orderpb.CommandEnvelope
+	if err := proto.Unmarshal(value, &pbEnv); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal envelope: %w", err)
+	}
+	if pbEnv.Data == nil {
+		return nil, fmt.Errorf("%s envelope has no data", pbEnv.EventType)
+	}
+	msg, err := pbEnv.Data.UnmarshalNew()
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal %s data: %w", pbEnv.EventType, err)
+	}
+	// Proto names are the JSON field names
+	data, err := protojson.MarshalOptions{UseProtoNames: true}.Marshal(msg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert %s data: %w", pbEnv.EventType, err)
+	}
+	var occurredAt time.Time
+	if pbEnv.OccurredAt != nil {
+		occurredAt = pbEnv.OccurredAt.AsTime()
+	}
+	return &Envelope{
+		EventID:       pbEnv.EventId,
+		EventType:     pbEnv.EventType,
+		SchemaVersion: int(pbEnv.SchemaVersion),
+		OccurredAt:    occurredAt,
+		Producer:      pbEnv.Producer,
+		Data:          data,
+	}, nil
+}
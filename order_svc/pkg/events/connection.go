@@ -49,22 +49,37 @@ func EnsureTopics(brokers string, topics []string, partition int) error {
 	return nil
 }
 
-func (c *KafkaConnection) MakeConsumer(groupID string, topics []string) (*kafka.Consumer, error) {
+// Runs on the polling goroutine before the partitions are unassigned,
+// last chance to commit what was processed on them
+type RevokeHook func(c *kafka.Consumer, partitions []kafka.TopicPartition)
+
+// Offsets are only committed once stored, callers store them as messages finish
+func (c *KafkaConnection) MakeConsumer(groupID string, topics []string, onRevoke RevokeHook) (*kafka.Consumer, error) {
 	consumer, err := kafka.NewConsumer(&kafka.ConfigMap{
-		"bootstrap.servers": c.Brokers,
-		"group.id":          groupID,
-		"auto.offset.reset": "earliest",
+		"bootstrap.servers":        c.Brokers,
+		"group.id":                 groupID,
+		"auto.offset.reset":        "earliest",
+		"enable.auto.offset.store": false,
 	})
 	if err != nil {
 		return nil, err
 	}
-	err = consumer.SubscribeTopics(topics, rebalanceCb)
+	err = consumer.SubscribeTopics(topics, rebalanceCb(onRevoke))
 	if err != nil {
 		return nil, fmt.Errorf("failed to subscribe to topics: %w", err)
 	}
 	return consumer, nil
 }
 
+// Not subscribed to anything and never commits, callers assign the partitions and offsets to read
+func (c *KafkaConnection) MakeReader(groupID string) (*kafka.Consumer, error) {
+	return kafka.NewConsumer(&kafka.ConfigMap{
+		"bootstrap.servers":  c.Brokers,
+		"group.id":           groupID,
+		"enable.auto.commit": false,
+	})
+}
+
 func (c *KafkaConnection) MakeProducer() (*kafka.Producer, error) {
 	producer, err := kafka.NewProducer(&kafka.ConfigMap{
 		"bootstrap.servers":  c.Brokers,
@@ -76,14 +91,19 @@ func (c *KafkaConnection) MakeProducer() (*kafka.Producer, error) {
 	return producer, nil
 }
 
-func rebalanceCb(c *kafka.Consumer, e kafka.Event) error {
-	switch ev := e.(type) {
-	case kafka.AssignedPartitions:
-		fmt.Println("Partitions assigned:", ev.Partitions)
-		c.Assign(ev.Partitions)
-	case kafka.RevokedPartitions:
-		fmt.Println("Partitions revoked:", ev.Partitions)
-		c.Unassign()
+func rebalanceCb(onRevoke RevokeHook) kafka.RebalanceCb {
+	return func(c *kafka.Consumer, e kafka.Event) error {
+		switch ev := e.(type) {
+		case kafka.AssignedPartitions:
+			fmt.Println("Partitions assigned:", ev.Partitions)
+			c.Assign(ev.Partitions)
+		case kafka.RevokedPartitions:
+			fmt.Println("Partitions revoked:", ev.Partitions)
+			if onRevoke != nil {
+				onRevoke(c, ev.Partitions)
+			}
+			c.Unassign()
+		}
+		return nil
 	}
-	return nil
 }
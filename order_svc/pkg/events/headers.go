@@ -0,0 +1,27 @@
+package events
+
+import "github.com/confluentinc/confluent-kafka-go/v2/kafka"
+
+const (
+	HeaderCorrelationID = "correlation-id"
+	HeaderReplyTo       = "reply-to"
+	HeaderMessageID     = "message-id"
+	// Encoding of the value, JSON when missing
+	HeaderContentType = "content-type"
+	// Set on messages in a retry tier
+	HeaderRetryAttempt  = "retry-attempt"
+	HeaderNotBefore     = "retry-not-before"
+	HeaderOriginalTopic = "original-topic"
+	// Where the message was first consumed from in the original topic
+	HeaderOriginalPartition = "original-partition"
+	HeaderOriginalOffset    = "original-offset"
+)
+
+func HeaderValue(headers []kafka.Header, key string) string {
+	for _, h := range headers {
+		if h.Key == key {
+			return string(h.Value)
+		}
+	}
+	return ""
+}
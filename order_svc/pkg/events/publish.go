@@ -0,0 +1,46 @@
+package events
+
+import (
+	"context"
+
+	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+// Produces one message and waits for its delivery report. The trace context of
+// ctx is written over any trace headers the message already carried
+func Publish(ctx context.Context, producer *kafka.Producer, topic string, key, value []byte, headers map[string]string) error {
+	carrier := make(propagation.MapCarrier, len(headers))
+	for k, v := range headers {
+		carrier[k] = v
+	}
+	otel.GetTextMapPropagator().Inject(ctx, carrier)
+	kafkaHeaders := make([]kafka.Header, 0, len(carrier))
+	for k, v := range carrier {
+		kafkaHeaders = append(kafkaHeaders, kafka.Header{
+			Key:   k,
+			Value: []byte(v),
+		})
+	}
+	deliveryChan := make(chan kafka.Event, 1)
+	err := producer.Produce(&kafka.Message{
+		TopicPartition: kafka.TopicPartition{
+			Topic:     &topic,
+			Partition: kafka.PartitionAny,
+		},
+		Key:     key,
+		Value:   value,
+		Headers: kafkaHeaders,
+	}, deliveryChan)
+	if err != nil {
+		return err
+	}
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case e := <-deliveryChan:
+		m := e.(*kafka.Message)
+		return m.TopicPartition.Error
+	}
+}
@@ -0,0 +1,27 @@
+package events
+
+import (
+	"context"
+	"errors"
+)
+
+var ErrSchemaNotFound = errors.New("schema not found")
+
+// Schema types as named by the registry REST API
+const (
+	SchemaTypeJSON     = "JSON"
+	SchemaTypeProtobuf = "PROTOBUF"
+)
+
+type Schema struct {
+	ID      int    `json:"id,omitempty"`
+	Subject string `json:"subject,omitempty"`
+	Version int    `json:"version,omitempty"`
+	Type    string `json:"schemaType,omitempty"`
+	Schema  string `json:"schema"`
+}
+
+// Read side of the Confluent Schema Registry API, order_api registers the schemas
+type SchemaRegistry interface {
+	SchemaByID(ctx context.Context, id int) (*Schema, error)
+}
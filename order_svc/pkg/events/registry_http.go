@@ -0,0 +1,85 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const registryContentType = "application/vnd.schemaregistry.v1+json"
+
+// Registry error codes for a missing subject, version or schema
+const (
+	errCodeSubjectNotFound = 40401
+	errCodeVersionNotFound = 40402
+	errCodeSchemaNotFound  = 40403
+)
+
+type registryError struct {
+	Code    int    `json:"error_code"`
+	Message string `json:"message"`
+}
+
+func (e *registryError) Error() string {
+	return fmt.Sprintf("schema registry error %d: %s", e.Code, e.Message)
+}
+
+type HTTPRegistry struct {
+	baseURL string
+	client  *http.Client
+}
+
+func NewHTTPRegistry(baseURL string, timeout time.Duration) *HTTPRegistry {
+	return &HTTPRegistry{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		client:  &http.Client{Timeout: timeout},
+	}
+}
+
+func (r *HTTPRegistry) SchemaByID(ctx context.Context, id int) (*Schema, error) {
+	var schema Schema
+	if err := r.get(ctx, fmt.Sprintf("/schemas/ids/%d", id), &schema); err != nil {
+		return nil, notFound(err)
+	}
+	schema.ID = id
+	return &schema, nil
+}
+
+func notFound(err error) error {
+	var regErr *registryError
+	if errors.As(err, &regErr) {
+		switch regErr.Code {
+		case errCodeSubjectNotFound, errCodeVersionNotFound, errCodeSchemaNotFound:
+			return ErrSchemaNotFound
+		}
+	}
+	return err
+}
+
+func (r *HTTPRegistry) get(ctx context.Context, path string, out any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, r.baseURL+path, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", registryContentType)
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("schema registry request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= http.StatusBadRequest {
+		regErr := &registryError{Code: resp.StatusCode}
+		if err := json.NewDecoder(resp.Body).Decode(regErr); err != nil {
+			regErr.Message = resp.Status
+		}
+		return regErr
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode registry response: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+)
+
+type localRegistryFile struct {
+	Subjects map[string][]*Schema `json:"subjects"`
+}
+
+// Reads the JSON file order_api's local registry writes. The file is read
+// again on every call so schemas registered after start are found
+type LocalRegistry struct {
+	path string
+}
+
+func NewLocalRegistry(path string) *LocalRegistry {
+	return &LocalRegistry{
+		path: path,
+	}
+}
+
+func (r *LocalRegistry) SchemaByID(ctx context.Context, id int) (*Schema, error) {
+	f, err := r.load()
+	if err != nil {
+		return nil, err
+	}
+	for _, versions := range f.Subjects {
+		for _, s := range versions {
+			if s.ID == id {
+				return s, nil
+			}
+		}
+	}
+	return nil, ErrSchemaNotFound
+}
+
+func (r *LocalRegistry) load() (*localRegistryFile, error) {
+	f := &localRegistryFile{Subjects: make(map[string][]*Schema)}
+	raw, err := os.ReadFile(r.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return f, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read local registry: %w", err)
+	}
+	if err := json.Unmarshal(raw, f); err != nil {
+		return nil, fmt.Errorf("failed to parse local registry: %w", err)
+	}
+	if f.Subjects == nil {
+		f.Subjects = make(map[string][]*Schema)
+	}
+	return f, nil
+}
@@ -0,0 +1,96 @@
+package events
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// Confluent wire format: magic byte, 4 byte big endian schema ID, then for
+// protobuf the message indexes, then the payload
+const (
+	magicByte    = 0
+	headerLength = 5
+)
+
+func IsFramed(value []byte) bool {
+	return len(value) >= headerLength && value[0] == magicByte
+}
+
+// Resolves the schema ID of framed values, caching what the registry returns
+type Deserializer struct {
+	registry SchemaRegistry
+	mu       sync.Mutex
+	schemas  map[int]*Schema
+}
+
+func NewDeserializer(registry SchemaRegistry) *Deserializer {
+	return &Deserializer{
+		registry: registry,
+		schemas:  make(map[int]*Schema),
+	}
+}
+
+// Returns the writer's schema and the payload without the framing
+func (d *Deserializer) Deserialize(ctx context.Context, value []byte) (*Schema, []byte, error) {
+	if !IsFramed(value) {
+		return nil, nil, errors.New("missing schema registry header")
+	}
+	id := int(binary.BigEndian.Uint32(value[1:headerLength]))
+	schema, err := d.schema(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	payload := value[headerLength:]
+	if schema.Type == SchemaTypeProtobuf {
+		if payload, err = skipMessageIndexes(payload); err != nil {
+			return nil, nil, err
+		}
+	}
+	return schema, payload, nil
+}
+
+func (d *Deserializer) schema(ctx context.Context, id int) (*Schema, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if schema, ok := d.schemas[id]; ok {
+		return schema, nil
+	}
+	schema, err := d.registry.SchemaByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up schema %d: %w", id, err)
+	}
+	d.schemas[id] = schema
+	return schema, nil
+}
+
+// A zigzag varint count followed by that many indexes, a lone 0 stands for
+// the first message
+func skipMessageIndexes(payload []byte) ([]byte, error) {
+	count, n := binary.Varint(payload)
+	if n <= 0 || count < 0 {
+		return nil, errors.New("invalid protobuf message indexes")
+	}
+	payload = payload[n:]
+	for range count {
+		if _, n = binary.Varint(payload); n <= 0 {
+			return nil, errors.New("invalid protobuf message indexes")
+		}
+		payload = payload[n:]
+	}
+	return payload, nil
+}
+
+// Encoding the writer used, as the content-type header would name it
+func ContentTypeFor(schema *Schema) (string, error) {
+	switch schema.Type {
+	case SchemaTypeJSON:
+		return ContentTypeJSON, nil
+	case SchemaTypeProtobuf:
+		return ContentTypeProtobuf, nil
+	default:
+		return "", fmt.Errorf("unsupported schema type: %s", schema.Type)
+	}
+}
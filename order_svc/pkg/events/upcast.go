@@ -0,0 +1,64 @@
+package events
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Lifts data from one schema version to the next
+type Upcaster func(data json.RawMessage) (json.RawMessage, error)
+
+// For a version bump that only changed the envelope
+func SameData(data json.RawMessage) (json.RawMessage, error) {
+	return data, nil
+}
+
+type upcastStep struct {
+	eventType string
+	from      int
+}
+
+// Brings older envelopes up to the version decoders expect, one step at a time
+type UpcasterRegistry struct {
+	current map[string]int
+	steps   map[upcastStep]Upcaster
+}
+
+func NewUpcasterRegistry() *UpcasterRegistry {
+	return &UpcasterRegistry{
+		current: make(map[string]int),
+		steps:   make(map[upcastStep]Upcaster),
+	}
+}
+
+func (r *UpcasterRegistry) SetCurrent(eventType string, version int) {
+	r.current[eventType] = version
+}
+
+// up turns version from into from+1
+func (r *UpcasterRegistry) Register(eventType string, from int, up Upcaster) {
+	r.steps[upcastStep{eventType: eventType, from: from}] = up
+}
+
+func (r *UpcasterRegistry) Upcast(env *Envelope) error {
+	current, ok := r.current[env.EventType]
+	if !ok {
+		return fmt.Errorf("unknown event type: %s", env.EventType)
+	}
+	if env.SchemaVersion > current {
+		return fmt.Errorf("%s version %d is newer than supported %d", env.EventType, env.SchemaVersion, current)
+	}
+	for env.SchemaVersion < current {
+		up, ok := r.steps[upcastStep{eventType: env.EventType, from: env.SchemaVersion}]
+		if !ok {
+			return fmt.Errorf("no upcaster for %s version %d", env.EventType, env.SchemaVersion)
+		}
+		data, err := up(env.Data)
+		if err != nil {
+			return fmt.Errorf("failed to upcast %s version %d: %w", env.EventType, env.SchemaVersion, err)
+		}
+		env.Data = data
+		env.SchemaVersion++
+	}
+	return nil
+}
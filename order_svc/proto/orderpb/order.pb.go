@@ -29,6 +29,10 @@ const (
 	OrderStatus_STATUS_PENDING   OrderStatus = 0
 	OrderStatus_STATUS_CONFIRMED OrderStatus = 1
 	OrderStatus_STATUS_FAILED    OrderStatus = 2
+	OrderStatus_STATUS_CANCELLED OrderStatus = 3
+	OrderStatus_STATUS_SHIPPED   OrderStatus = 4
+	OrderStatus_STATUS_DELIVERED OrderStatus = 5
+	OrderStatus_STATUS_REFUNDED  OrderStatus = 6
 )
 
 // Enum value maps for OrderStatus.
@@ -37,11 +41,19 @@ var (
 		0: "STATUS_PENDING",
 		1: "STATUS_CONFIRMED",
 		2: "STATUS_FAILED",
+		3: "STATUS_CANCELLED",
+		4: "STATUS_SHIPPED",
+		5: "STATUS_DELIVERED",
+		6: "STATUS_REFUNDED",
 	}
 	OrderStatus_value = map[string]int32{
 		"STATUS_PENDING":   0,
 		"STATUS_CONFIRMED": 1,
 		"STATUS_FAILED":    2,
+		"STATUS_CANCELLED": 3,
+		"STATUS_SHIPPED":   4,
+		"STATUS_DELIVERED": 5,
+		"STATUS_REFUNDED":  6,
 	}
 )
 
@@ -72,6 +84,106 @@ func (OrderStatus) EnumDescriptor() ([]byte, []int) {
 	return file_contracts_orders_order_proto_rawDescGZIP(), []int{0}
 }
 
+// Listing sort order, ties are broken by id
+type OrderSort int32
+
+const (
+	OrderSort_SORT_CREATED_AT      OrderSort = 0
+	OrderSort_SORT_CREATED_AT_DESC OrderSort = 1
+	OrderSort_SORT_UPDATED_AT      OrderSort = 2
+	OrderSort_SORT_UPDATED_AT_DESC OrderSort = 3
+)
+
+// Enum value maps for OrderSort.
+var (
+	OrderSort_name = map[int32]string{
+		0: "SORT_CREATED_AT",
+		1: "SORT_CREATED_AT_DESC",
+		2: "SORT_UPDATED_AT",
+		3: "SORT_UPDATED_AT_DESC",
+	}
+	OrderSort_value = map[string]int32{
+		"SORT_CREATED_AT":      0,
+		"SORT_CREATED_AT_DESC": 1,
+		"SORT_UPDATED_AT":      2,
+		"SORT_UPDATED_AT_DESC": 3,
+	}
+)
+
+func (x OrderSort) Enum() *OrderSort {
+	p := new(OrderSort)
+	*p = x
+	return p
+}
+
+func (x OrderSort) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (OrderSort) Descriptor() protoreflect.EnumDescriptor {
+	return file_contracts_orders_order_proto_enumTypes[1].Descriptor()
+}
+
+func (OrderSort) Type() protoreflect.EnumType {
+	return &file_contracts_orders_order_proto_enumTypes[1]
+}
+
+func (x OrderSort) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use OrderSort.Descriptor instead.
+func (OrderSort) EnumDescriptor() ([]byte, []int) {
+	return file_contracts_orders_order_proto_rawDescGZIP(), []int{1}
+}
+
+// Kind of change reported by WatchOrders
+type ChangeType int32
+
+const (
+	ChangeType_CHANGE_CREATED ChangeType = 0
+	ChangeType_CHANGE_UPDATED ChangeType = 1
+)
+
+// Enum value maps for ChangeType.
+var (
+	ChangeType_name = map[int32]string{
+		0: "CHANGE_CREATED",
+		1: "CHANGE_UPDATED",
+	}
+	ChangeType_value = map[string]int32{
+		"CHANGE_CREATED": 0,
+		"CHANGE_UPDATED": 1,
+	}
+)
+
+func (x ChangeType) Enum() *ChangeType {
+	p := new(ChangeType)
+	*p = x
+	return p
+}
+
+func (x ChangeType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ChangeType) Descriptor() protoreflect.EnumDescriptor {
+	return file_contracts_orders_order_proto_enumTypes[2].Descriptor()
+}
+
+func (ChangeType) Type() protoreflect.EnumType {
+	return &file_contracts_orders_order_proto_enumTypes[2]
+}
+
+func (x ChangeType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ChangeType.Descriptor instead.
+func (ChangeType) EnumDescriptor() ([]byte, []int) {
+	return file_contracts_orders_order_proto_rawDescGZIP(), []int{2}
+}
+
 // Order message
 type Order struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -194,7 +306,106 @@ func (x *GetOrderByIDRequest) GetId() string {
 	return ""
 }
 
-// Request by status
+// Batch lookup, capped by the server's max batch size
+type GetOrdersByIDsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ids           []string               `protobuf:"bytes,1,rep,name=ids,proto3" json:"ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOrdersByIDsRequest) Reset() {
+	*x = GetOrdersByIDsRequest{}
+	mi := &file_contracts_orders_order_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOrdersByIDsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOrdersByIDsRequest) ProtoMessage() {}
+
+func (x *GetOrdersByIDsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_contracts_orders_order_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOrdersByIDsRequest.ProtoReflect.Descriptor instead.
+func (*GetOrdersByIDsRequest) Descriptor() ([]byte, []int) {
+	return file_contracts_orders_order_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetOrdersByIDsRequest) GetIds() []string {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+// Found orders follow the request order, ids with no order are listed in missing_ids
+type GetOrdersByIDsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Orders        []*Order               `protobuf:"bytes,1,rep,name=orders,proto3" json:"orders,omitempty"`
+	MissingIds    []string               `protobuf:"bytes,2,rep,name=missing_ids,json=missingIds,proto3" json:"missing_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOrdersByIDsResponse) Reset() {
+	*x = GetOrdersByIDsResponse{}
+	mi := &file_contracts_orders_order_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOrdersByIDsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOrdersByIDsResponse) ProtoMessage() {}
+
+func (x *GetOrdersByIDsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_contracts_orders_order_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOrdersByIDsResponse.ProtoReflect.Descriptor instead.
+func (*GetOrdersByIDsResponse) Descriptor() ([]byte, []int) {
+	return file_contracts_orders_order_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetOrdersByIDsResponse) GetOrders() []*Order {
+	if x != nil {
+		return x.Orders
+	}
+	return nil
+}
+
+func (x *GetOrdersByIDsResponse) GetMissingIds() []string {
+	if x != nil {
+		return x.MissingIds
+	}
+	return nil
+}
+
+// Request by status. Left without limit and page_token on purpose, changing it
+// would break callers mid deploy, ListOrders is the paged replacement
 type ListOrdersByStatusRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Status        OrderStatus            `protobuf:"varint,1,opt,name=status,proto3,enum=order.OrderStatus" json:"status,omitempty"`
@@ -204,7 +415,7 @@ type ListOrdersByStatusRequest struct {
 
 func (x *ListOrdersByStatusRequest) Reset() {
 	*x = ListOrdersByStatusRequest{}
-	mi := &file_contracts_orders_order_proto_msgTypes[2]
+	mi := &file_contracts_orders_order_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -216,7 +427,7 @@ func (x *ListOrdersByStatusRequest) String() string {
 func (*ListOrdersByStatusRequest) ProtoMessage() {}
 
 func (x *ListOrdersByStatusRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_contracts_orders_order_proto_msgTypes[2]
+	mi := &file_contracts_orders_order_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -229,7 +440,7 @@ func (x *ListOrdersByStatusRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListOrdersByStatusRequest.ProtoReflect.Descriptor instead.
 func (*ListOrdersByStatusRequest) Descriptor() ([]byte, []int) {
-	return file_contracts_orders_order_proto_rawDescGZIP(), []int{2}
+	return file_contracts_orders_order_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *ListOrdersByStatusRequest) GetStatus() OrderStatus {
@@ -239,6 +450,283 @@ func (x *ListOrdersByStatusRequest) GetStatus() OrderStatus {
 	return OrderStatus_STATUS_PENDING
 }
 
+// Filtered listing, all filters are optional and combined with AND
+type ListOrdersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Statuses      []OrderStatus          `protobuf:"varint,1,rep,packed,name=statuses,proto3,enum=order.OrderStatus" json:"statuses,omitempty"`
+	CreatedAfter  *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=created_after,json=createdAfter,proto3" json:"created_after,omitempty"`
+	CreatedBefore *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=created_before,json=createdBefore,proto3" json:"created_before,omitempty"`
+	UpdatedSince  *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=updated_since,json=updatedSince,proto3" json:"updated_since,omitempty"`
+	// Orders must contain every listed SKU
+	Skus          []string  `protobuf:"bytes,5,rep,name=skus,proto3" json:"skus,omitempty"`
+	Sort          OrderSort `protobuf:"varint,6,opt,name=sort,proto3,enum=order.OrderSort" json:"sort,omitempty"`
+	Limit         int32     `protobuf:"varint,7,opt,name=limit,proto3" json:"limit,omitempty"`
+	PageToken     string    `protobuf:"bytes,8,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListOrdersRequest) Reset() {
+	*x = ListOrdersRequest{}
+	mi := &file_contracts_orders_order_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListOrdersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListOrdersRequest) ProtoMessage() {}
+
+func (x *ListOrdersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_contracts_orders_order_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListOrdersRequest.ProtoReflect.Descriptor instead.
+func (*ListOrdersRequest) Descriptor() ([]byte, []int) {
+	return file_contracts_orders_order_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ListOrdersRequest) GetStatuses() []OrderStatus {
+	if x != nil {
+		return x.Statuses
+	}
+	return nil
+}
+
+func (x *ListOrdersRequest) GetCreatedAfter() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAfter
+	}
+	return nil
+}
+
+func (x *ListOrdersRequest) GetCreatedBefore() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedBefore
+	}
+	return nil
+}
+
+func (x *ListOrdersRequest) GetUpdatedSince() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedSince
+	}
+	return nil
+}
+
+func (x *ListOrdersRequest) GetSkus() []string {
+	if x != nil {
+		return x.Skus
+	}
+	return nil
+}
+
+func (x *ListOrdersRequest) GetSort() OrderSort {
+	if x != nil {
+		return x.Sort
+	}
+	return OrderSort_SORT_CREATED_AT
+}
+
+func (x *ListOrdersRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListOrdersRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+// One order per message, when more pages follow a final message carries only next_page_token
+type ListOrdersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Order         *Order                 `protobuf:"bytes,1,opt,name=order,proto3" json:"order,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListOrdersResponse) Reset() {
+	*x = ListOrdersResponse{}
+	mi := &file_contracts_orders_order_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListOrdersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListOrdersResponse) ProtoMessage() {}
+
+func (x *ListOrdersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_contracts_orders_order_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListOrdersResponse.ProtoReflect.Descriptor instead.
+func (*ListOrdersResponse) Descriptor() ([]byte, []int) {
+	return file_contracts_orders_order_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ListOrdersResponse) GetOrder() *Order {
+	if x != nil {
+		return x.Order
+	}
+	return nil
+}
+
+func (x *ListOrdersResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+// Empty filters watch every order, since replays changes made from then on before going live
+type WatchOrdersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ids           []string               `protobuf:"bytes,1,rep,name=ids,proto3" json:"ids,omitempty"`
+	Statuses      []OrderStatus          `protobuf:"varint,2,rep,packed,name=statuses,proto3,enum=order.OrderStatus" json:"statuses,omitempty"`
+	Since         *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=since,proto3" json:"since,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchOrdersRequest) Reset() {
+	*x = WatchOrdersRequest{}
+	mi := &file_contracts_orders_order_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchOrdersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchOrdersRequest) ProtoMessage() {}
+
+func (x *WatchOrdersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_contracts_orders_order_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchOrdersRequest.ProtoReflect.Descriptor instead.
+func (*WatchOrdersRequest) Descriptor() ([]byte, []int) {
+	return file_contracts_orders_order_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *WatchOrdersRequest) GetIds() []string {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+func (x *WatchOrdersRequest) GetStatuses() []OrderStatus {
+	if x != nil {
+		return x.Statuses
+	}
+	return nil
+}
+
+func (x *WatchOrdersRequest) GetSince() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Since
+	}
+	return nil
+}
+
+// changed_at is the order's updated_at, pass the last one seen as since to resume
+type OrderChange struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Type          ChangeType             `protobuf:"varint,1,opt,name=type,proto3,enum=order.ChangeType" json:"type,omitempty"`
+	Order         *Order                 `protobuf:"bytes,2,opt,name=order,proto3" json:"order,omitempty"`
+	ChangedAt     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=changed_at,json=changedAt,proto3" json:"changed_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrderChange) Reset() {
+	*x = OrderChange{}
+	mi := &file_contracts_orders_order_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrderChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderChange) ProtoMessage() {}
+
+func (x *OrderChange) ProtoReflect() protoreflect.Message {
+	mi := &file_contracts_orders_order_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderChange.ProtoReflect.Descriptor instead.
+func (*OrderChange) Descriptor() ([]byte, []int) {
+	return file_contracts_orders_order_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *OrderChange) GetType() ChangeType {
+	if x != nil {
+		return x.Type
+	}
+	return ChangeType_CHANGE_CREATED
+}
+
+func (x *OrderChange) GetOrder() *Order {
+	if x != nil {
+		return x.Order
+	}
+	return nil
+}
+
+func (x *OrderChange) GetChangedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ChangedAt
+	}
+	return nil
+}
+
 var File_contracts_orders_order_proto protoreflect.FileDescriptor
 
 const file_contracts_orders_order_proto_rawDesc = "" +
@@ -257,16 +745,61 @@ const file_contracts_orders_order_proto_rawDesc = "" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
 	"\x05value\x18\x02 \x01(\x05R\x05value:\x028\x01\"%\n" +
 	"\x13GetOrderByIDRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\"G\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\")\n" +
+	"\x15GetOrdersByIDsRequest\x12\x10\n" +
+	"\x03ids\x18\x01 \x03(\tR\x03ids\"_\n" +
+	"\x16GetOrdersByIDsResponse\x12$\n" +
+	"\x06orders\x18\x01 \x03(\v2\f.order.OrderR\x06orders\x12\x1f\n" +
+	"\vmissing_ids\x18\x02 \x03(\tR\n" +
+	"missingIds\"G\n" +
 	"\x19ListOrdersByStatusRequest\x12*\n" +
-	"\x06status\x18\x01 \x01(\x0e2\x12.order.OrderStatusR\x06status*J\n" +
+	"\x06status\x18\x01 \x01(\x0e2\x12.order.OrderStatusR\x06status\"\xf7\x02\n" +
+	"\x11ListOrdersRequest\x12.\n" +
+	"\bstatuses\x18\x01 \x03(\x0e2\x12.order.OrderStatusR\bstatuses\x12?\n" +
+	"\rcreated_after\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\fcreatedAfter\x12A\n" +
+	"\x0ecreated_before\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\rcreatedBefore\x12?\n" +
+	"\rupdated_since\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\fupdatedSince\x12\x12\n" +
+	"\x04skus\x18\x05 \x03(\tR\x04skus\x12$\n" +
+	"\x04sort\x18\x06 \x01(\x0e2\x10.order.OrderSortR\x04sort\x12\x14\n" +
+	"\x05limit\x18\a \x01(\x05R\x05limit\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\b \x01(\tR\tpageToken\"`\n" +
+	"\x12ListOrdersResponse\x12\"\n" +
+	"\x05order\x18\x01 \x01(\v2\f.order.OrderR\x05order\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"\x88\x01\n" +
+	"\x12WatchOrdersRequest\x12\x10\n" +
+	"\x03ids\x18\x01 \x03(\tR\x03ids\x12.\n" +
+	"\bstatuses\x18\x02 \x03(\x0e2\x12.order.OrderStatusR\bstatuses\x120\n" +
+	"\x05since\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x05since\"\x93\x01\n" +
+	"\vOrderChange\x12%\n" +
+	"\x04type\x18\x01 \x01(\x0e2\x11.order.ChangeTypeR\x04type\x12\"\n" +
+	"\x05order\x18\x02 \x01(\v2\f.order.OrderR\x05order\x129\n" +
+	"\n" +
+	"changed_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tchangedAt*\x9f\x01\n" +
 	"\vOrderStatus\x12\x12\n" +
 	"\x0eSTATUS_PENDING\x10\x00\x12\x14\n" +
 	"\x10STATUS_CONFIRMED\x10\x01\x12\x11\n" +
-	"\rSTATUS_FAILED\x10\x022\x90\x01\n" +
+	"\rSTATUS_FAILED\x10\x02\x12\x14\n" +
+	"\x10STATUS_CANCELLED\x10\x03\x12\x12\n" +
+	"\x0eSTATUS_SHIPPED\x10\x04\x12\x14\n" +
+	"\x10STATUS_DELIVERED\x10\x05\x12\x13\n" +
+	"\x0fSTATUS_REFUNDED\x10\x06*i\n" +
+	"\tOrderSort\x12\x13\n" +
+	"\x0fSORT_CREATED_AT\x10\x00\x12\x18\n" +
+	"\x14SORT_CREATED_AT_DESC\x10\x01\x12\x13\n" +
+	"\x0fSORT_UPDATED_AT\x10\x02\x12\x18\n" +
+	"\x14SORT_UPDATED_AT_DESC\x10\x03*4\n" +
+	"\n" +
+	"ChangeType\x12\x12\n" +
+	"\x0eCHANGE_CREATED\x10\x00\x12\x12\n" +
+	"\x0eCHANGE_UPDATED\x10\x012\xe9\x02\n" +
 	"\fOrderService\x128\n" +
-	"\fGetOrderByID\x12\x1a.order.GetOrderByIDRequest\x1a\f.order.Order\x12F\n" +
-	"\x12ListOrdersByStatus\x12 .order.ListOrdersByStatusRequest\x1a\f.order.Order0\x01B>Z<github.com/Anacardo89/order_svc_hex/contracts/orders;orderpbb\x06proto3"
+	"\fGetOrderByID\x12\x1a.order.GetOrderByIDRequest\x1a\f.order.Order\x12M\n" +
+	"\x0eGetOrdersByIDs\x12\x1c.order.GetOrdersByIDsRequest\x1a\x1d.order.GetOrdersByIDsResponse\x12K\n" +
+	"\x12ListOrdersByStatus\x12 .order.ListOrdersByStatusRequest\x1a\f.order.Order\"\x03\x88\x02\x010\x01\x12C\n" +
+	"\n" +
+	"ListOrders\x12\x18.order.ListOrdersRequest\x1a\x19.order.ListOrdersResponse0\x01\x12>\n" +
+	"\vWatchOrders\x12\x19.order.WatchOrdersRequest\x1a\x12.order.OrderChange0\x01B>Z<github.com/Anacardo89/order_svc_hex/contracts/orders;orderpbb\x06proto3"
 
 var (
 	file_contracts_orders_order_proto_rawDescOnce sync.Once
@@ -280,31 +813,57 @@ func file_contracts_orders_order_proto_rawDescGZIP() []byte {
 	return file_contracts_orders_order_proto_rawDescData
 }
 
-var file_contracts_orders_order_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_contracts_orders_order_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_contracts_orders_order_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_contracts_orders_order_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
 var file_contracts_orders_order_proto_goTypes = []any{
 	(OrderStatus)(0),                  // 0: order.OrderStatus
-	(*Order)(nil),                     // 1: order.Order
-	(*GetOrderByIDRequest)(nil),       // 2: order.GetOrderByIDRequest
-	(*ListOrdersByStatusRequest)(nil), // 3: order.ListOrdersByStatusRequest
-	nil,                               // 4: order.Order.ItemsEntry
-	(*timestamppb.Timestamp)(nil),     // 5: google.protobuf.Timestamp
+	(OrderSort)(0),                    // 1: order.OrderSort
+	(ChangeType)(0),                   // 2: order.ChangeType
+	(*Order)(nil),                     // 3: order.Order
+	(*GetOrderByIDRequest)(nil),       // 4: order.GetOrderByIDRequest
+	(*GetOrdersByIDsRequest)(nil),     // 5: order.GetOrdersByIDsRequest
+	(*GetOrdersByIDsResponse)(nil),    // 6: order.GetOrdersByIDsResponse
+	(*ListOrdersByStatusRequest)(nil), // 7: order.ListOrdersByStatusRequest
+	(*ListOrdersRequest)(nil),         // 8: order.ListOrdersRequest
+	(*ListOrdersResponse)(nil),        // 9: order.ListOrdersResponse
+	(*WatchOrdersRequest)(nil),        // 10: order.WatchOrdersRequest
+	(*OrderChange)(nil),               // 11: order.OrderChange
+	nil,                               // 12: order.Order.ItemsEntry
+	(*timestamppb.Timestamp)(nil),     // 13: google.protobuf.Timestamp
 }
 var file_contracts_orders_order_proto_depIdxs = []int32{
-	4, // 0: order.Order.items:type_name -> order.Order.ItemsEntry
-	0, // 1: order.Order.status:type_name -> order.OrderStatus
-	5, // 2: order.Order.created_at:type_name -> google.protobuf.Timestamp
-	5, // 3: order.Order.updated_at:type_name -> google.protobuf.Timestamp
-	0, // 4: order.ListOrdersByStatusRequest.status:type_name -> order.OrderStatus
-	2, // 5: order.OrderService.GetOrderByID:input_type -> order.GetOrderByIDRequest
-	3, // 6: order.OrderService.ListOrdersByStatus:input_type -> order.ListOrdersByStatusRequest
-	1, // 7: order.OrderService.GetOrderByID:output_type -> order.Order
-	1, // 8: order.OrderService.ListOrdersByStatus:output_type -> order.Order
-	7, // [7:9] is the sub-list for method output_type
-	5, // [5:7] is the sub-list for method input_type
-	5, // [5:5] is the sub-list for extension type_name
-	5, // [5:5] is the sub-list for extension extendee
-	0, // [0:5] is the sub-list for field type_name
+	12, // 0: order.Order.items:type_name -> order.Order.ItemsEntry
+	0,  // 1: order.Order.status:type_name -> order.OrderStatus
+	13, // 2: order.Order.created_at:type_name -> google.protobuf.Timestamp
+	13, // 3: order.Order.updated_at:type_name -> google.protobuf.Timestamp
+	3,  // 4: order.GetOrdersByIDsResponse.orders:type_name -> order.Order
+	0,  // 5: order.ListOrdersByStatusRequest.status:type_name -> order.OrderStatus
+	0,  // 6: order.ListOrdersRequest.statuses:type_name -> order.OrderStatus
+	13, // 7: order.ListOrdersRequest.created_after:type_name -> google.protobuf.Timestamp
+	13, // 8: order.ListOrdersRequest.created_before:type_name -> google.protobuf.Timestamp
+	13, // 9: order.ListOrdersRequest.updated_since:type_name -> google.protobuf.Timestamp
+	1,  // 10: order.ListOrdersRequest.sort:type_name -> order.OrderSort
+	3,  // 11: order.ListOrdersResponse.order:type_name -> order.Order
+	0,  // 12: order.WatchOrdersRequest.statuses:type_name -> order.OrderStatus
+	13, // 13: order.WatchOrdersRequest.since:type_name -> google.protobuf.Timestamp
+	2,  // 14: order.OrderChange.type:type_name -> order.ChangeType
+	3,  // 15: order.OrderChange.order:type_name -> order.Order
+	13, // 16: order.OrderChange.changed_at:type_name -> google.protobuf.Timestamp
+	4,  // 17: order.OrderService.GetOrderByID:input_type -> order.GetOrderByIDRequest
+	5,  // 18: order.OrderService.GetOrdersByIDs:input_type -> order.GetOrdersByIDsRequest
+	7,  // 19: order.OrderService.ListOrdersByStatus:input_type -> order.ListOrdersByStatusRequest
+	8,  // 20: order.OrderService.ListOrders:input_type -> order.ListOrdersRequest
+	10, // 21: order.OrderService.WatchOrders:input_type -> order.WatchOrdersRequest
+	3,  // 22: order.OrderService.GetOrderByID:output_type -> order.Order
+	6,  // 23: order.OrderService.GetOrdersByIDs:output_type -> order.GetOrdersByIDsResponse
+	3,  // 24: order.OrderService.ListOrdersByStatus:output_type -> order.Order
+	9,  // 25: order.OrderService.ListOrders:output_type -> order.ListOrdersResponse
+	11, // 26: order.OrderService.WatchOrders:output_type -> order.OrderChange
+	22, // [22:27] is the sub-list for method output_type
+	17, // [17:22] is the sub-list for method input_type
+	17, // [17:17] is the sub-list for extension type_name
+	17, // [17:17] is the sub-list for extension extendee
+	0,  // [0:17] is the sub-list for field type_name
 }
 
 func init() { file_contracts_orders_order_proto_init() }
@@ -317,8 +876,8 @@ func file_contracts_orders_order_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_contracts_orders_order_proto_rawDesc), len(file_contracts_orders_order_proto_rawDesc)),
-			NumEnums:      1,
-			NumMessages:   4,
+			NumEnums:      3,
+			NumMessages:   10,
 			NumExtensions: 0,
 			NumServices:   1,
 		},